@@ -3,14 +3,23 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"text/template"
+	"time"
 
 	"github.com/chickenzord/linkding-mcp/internal/server"
 	"github.com/chickenzord/linkding-mcp/internal/version"
 )
 
 func main() {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	bindAddr := os.Getenv("BIND_ADDR")
 	linkdingURL := os.Getenv("LINKDING_URL")
@@ -39,12 +48,23 @@ func main() {
 		os.Exit(1)
 	}
 
-	if apiToken == "" {
-		fmt.Fprintf(os.Stderr, "Error: LINKDING_API_TOKEN environment variable is required\n")
-		os.Exit(1)
+	opts := serverOptions(apiToken)
+
+	mcpServer := server.NewMCP(linkdingURL, apiToken, opts...)
+
+	if mcpServer.PublicOnly() {
+		fmt.Fprintf(os.Stderr, "LINKDING_API_TOKEN is not set, running in read-only mode with publicly shared bookmarks only\n")
 	}
 
-	mcpServer := server.NewMCP(linkdingURL, apiToken)
+	if os.Getenv("LINKDING_WARMUP") == "true" {
+		start := time.Now()
+
+		if err := mcpServer.Warmup(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Linkding warmup failed: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Linkding warmup succeeded in %s\n", time.Since(start).Round(time.Millisecond))
+		}
+	}
 
 	switch mode {
 	case "http":
@@ -64,3 +84,187 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// serverOptions builds the server options from the optional environment
+// variables, exiting on invalid values.
+func serverOptions(apiToken string) []server.Option {
+	var opts []server.Option
+
+	if defaultQuery := os.Getenv("DEFAULT_QUERY"); defaultQuery != "" {
+		opts = append(opts, server.WithDefaultQuery(defaultQuery))
+	}
+
+	if protocolVersion := os.Getenv("MCP_PROTOCOL_VERSION"); protocolVersion != "" {
+		if err := server.ValidateProtocolVersion(protocolVersion); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid MCP_PROTOCOL_VERSION: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts = append(opts, server.WithProtocolVersion(protocolVersion))
+	}
+
+	if instanceName := os.Getenv("INSTANCE_NAME"); instanceName != "" {
+		opts = append(opts, server.WithInstanceName(instanceName))
+	}
+
+	if maxBulkItems := os.Getenv("MAX_BULK_ITEMS"); maxBulkItems != "" {
+		n, err := strconv.Atoi(maxBulkItems)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: MAX_BULK_ITEMS must be a number, got %q\n", maxBulkItems)
+			os.Exit(1)
+		}
+
+		opts = append(opts, server.WithMaxBulkItems(n))
+	}
+
+	if bulkRetryBudget := os.Getenv("BULK_RETRY_BUDGET"); bulkRetryBudget != "" {
+		n, err := strconv.Atoi(bulkRetryBudget)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: BULK_RETRY_BUDGET must be a number, got %q\n", bulkRetryBudget)
+			os.Exit(1)
+		}
+
+		opts = append(opts, server.WithBulkRetryBudget(n))
+	}
+
+	if maxRequests := os.Getenv("MAX_CONCURRENT_REQUESTS"); maxRequests != "" {
+		n, err := strconv.Atoi(maxRequests)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: MAX_CONCURRENT_REQUESTS must be a number, got %q\n", maxRequests)
+			os.Exit(1)
+		}
+
+		opts = append(opts, server.WithMaxConcurrentRequests(n))
+	}
+
+	if bookmarkTemplate := os.Getenv("BOOKMARK_TEMPLATE"); bookmarkTemplate != "" {
+		tmpl, err := template.New("bookmark").Parse(bookmarkTemplate)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: invalid BOOKMARK_TEMPLATE, using the built-in format: %v\n", err)
+		} else {
+			opts = append(opts, server.WithBookmarkTemplate(tmpl))
+		}
+	}
+
+	if backupDir := os.Getenv("BACKUP_DIR"); backupDir != "" {
+		if info, err := os.Stat(backupDir); err != nil || !info.IsDir() {
+			fmt.Fprintf(os.Stderr, "Error: BACKUP_DIR %q is not an existing directory\n", backupDir)
+			os.Exit(1)
+		}
+
+		opts = append(opts, server.WithBackupDir(backupDir))
+	}
+
+	if os.Getenv("NO_EMOJI") == "true" {
+		opts = append(opts, server.WithoutEmoji())
+	}
+
+	if os.Getenv("ALLOW_PRIVATE_FETCHES") == "true" {
+		opts = append(opts, server.WithPrivateFetches())
+	}
+
+	if os.Getenv("DEBUG_TOOL_LATENCY") == "true" {
+		opts = append(opts, server.WithLatencyLogging(slog.New(slog.NewTextHandler(os.Stderr, nil))))
+	}
+
+	if tokenFile := os.Getenv("LINKDING_API_TOKEN_FILE"); tokenFile != "" {
+		if _, err := os.Stat(tokenFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot read LINKDING_API_TOKEN_FILE: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts = append(opts, server.WithTokenProvider(tokenFileProvider(tokenFile, apiToken)))
+	}
+
+	if acceptLanguage := os.Getenv("LINKDING_ACCEPT_LANGUAGE"); acceptLanguage != "" {
+		opts = append(opts, server.WithAcceptLanguage(acceptLanguage))
+	}
+
+	if vocabulary := os.Getenv("TAG_VOCABULARY"); vocabulary != "" {
+		var tags []string
+
+		for _, tag := range strings.Split(vocabulary, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		opts = append(opts, server.WithTagVocabulary(tags, os.Getenv("TAG_VOCABULARY_ENFORCE") == "true"))
+	}
+
+	if defaultTimeout := os.Getenv("DEFAULT_TOOL_TIMEOUT"); defaultTimeout != "" {
+		timeout, err := time.ParseDuration(defaultTimeout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid DEFAULT_TOOL_TIMEOUT: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts = append(opts, server.WithDefaultToolTimeout(timeout))
+	}
+
+	if toolTimeouts := os.Getenv("TOOL_TIMEOUTS"); toolTimeouts != "" {
+		timeoutOpts, err := parseToolTimeouts(toolTimeouts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid TOOL_TIMEOUTS: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts = append(opts, timeoutOpts...)
+	}
+
+	return opts
+}
+
+// parseToolTimeouts parses comma-separated tool=duration pairs,
+// e.g. "archive_query=10m,tag_trends=5m"
+func parseToolTimeouts(value string) ([]server.Option, error) {
+	var opts []server.Option
+
+	for _, pair := range strings.Split(value, ",") {
+		tool, duration, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || tool == "" {
+			return nil, fmt.Errorf("expected tool=duration, got %q", pair)
+		}
+
+		timeout, err := time.ParseDuration(duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", tool, err)
+		}
+
+		opts = append(opts, server.WithToolTimeout(tool, timeout))
+	}
+
+	return opts, nil
+}
+
+// tokenFileProvider returns a token provider reading the API token from path.
+// The file is re-read whenever its modification time changes, so rotating the
+// token only requires rewriting the file. If it cannot be read the last known
+// token is used, starting with fallback.
+func tokenFileProvider(path, fallback string) func() string {
+	var (
+		mu      sync.Mutex
+		token   = fallback
+		modTime time.Time
+	)
+
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Equal(modTime) {
+			return token
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return token
+		}
+
+		token = strings.TrimSpace(string(data))
+		modTime = info.ModTime()
+
+		return token
+	}
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// backupFile is the JSON document written by backup_library
+type backupFile struct {
+	CreatedAt time.Time           `json:"created_at"`
+	Count     int                 `json:"count"`
+	Bookmarks []linkding.Bookmark `json:"bookmarks"`
+}
+
+// addFileTools registers the tools reading or writing files in the backup directory
+func (s *MCPServer) addFileTools(mcpServer *mcpsdk.Server) {
+	// Add backup_library tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "backup_library",
+		Description: "Back up all bookmarks, including archived ones, to a JSON file in the configured backup directory",
+	}, s.handleBackupLibrary)
+
+	// Add restore_library tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "restore_library",
+		Description: "Recreate bookmarks from a JSON backup file in the configured backup directory, skipping URLs that are already bookmarked",
+	}, s.handleRestoreLibrary)
+}
+
+func (s *MCPServer) handleBackupLibrary(ctx context.Context, req *mcpsdk.CallToolRequest, args BackupLibraryArgs) (*mcpsdk.CallToolResult, BackupResult, error) {
+	name := args.Path
+	if name == "" {
+		name = "linkding-backup-" + time.Now().UTC().Format("20060102-150405") + ".json"
+	}
+
+	path, err := confinePath(s.backupDir, name)
+	if err != nil {
+		return errorResult("Invalid backup path: %v", err), BackupResult{}, nil
+	}
+
+	bookmarks, err := s.getEntireLibrary(ctx)
+	if err != nil {
+		return errorResult("Failed to fetch bookmarks: %v", err), BackupResult{}, nil
+	}
+
+	data, err := json.MarshalIndent(backupFile{
+		CreatedAt: time.Now().UTC(),
+		Count:     len(bookmarks),
+		Bookmarks: bookmarks,
+	}, "", "  ")
+	if err != nil {
+		return errorResult("Failed to encode backup: %v", err), BackupResult{}, nil
+	}
+
+	if err := writeNewFile(path, data); err != nil {
+		return errorResult("Failed to write backup: %v", err), BackupResult{}, nil
+	}
+
+	backupResult := BackupResult{Path: path, Count: len(bookmarks)}
+
+	return textResult(fmt.Sprintf("%s Backed up %s to %s", s.mark(markBackup), pluralize(len(bookmarks), "bookmark"), path)), backupResult, nil
+}
+
+func (s *MCPServer) handleRestoreLibrary(ctx context.Context, req *mcpsdk.CallToolRequest, args RestoreLibraryArgs) (*mcpsdk.CallToolResult, RestoreResult, error) {
+	if args.Path == "" {
+		return errorResult("Backup path is required"), RestoreResult{}, nil
+	}
+
+	path, err := confinePath(s.backupDir, args.Path)
+	if err != nil {
+		return errorResult("Invalid backup path: %v", err), RestoreResult{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errorResult("Failed to read backup: %v", err), RestoreResult{}, nil
+	}
+
+	var backup backupFile
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return errorResult("Failed to parse backup %s: %v", path, err), RestoreResult{}, nil
+	}
+
+	requests := make([]linkding.CreateBookmarkRequest, len(backup.Bookmarks))
+	for i, bookmark := range backup.Bookmarks {
+		requests[i] = linkding.CreateBookmarkRequest{
+			URL:             bookmark.URL,
+			Title:           bookmark.Title,
+			Description:     bookmark.Description,
+			Notes:           bookmark.Notes,
+			TagNames:        bookmark.TagNames,
+			Unread:          bookmark.Unread,
+			Shared:          bookmark.Shared,
+			IsArchived:      bookmark.IsArchived,
+			DisableScraping: true,
+		}
+	}
+
+	missing, skipped, err := s.missingBookmarks(ctx, requests)
+	if err != nil {
+		return errorResult("Failed to fetch bookmarks: %v", err), RestoreResult{}, nil
+	}
+
+	// Only the bookmarks to create count, so restoring a large backup into
+	// a mostly complete library isn't refused
+	if tooMany := s.checkBulkLimit(len(missing)); tooMany != nil {
+		return tooMany, RestoreResult{}, nil
+	}
+
+	restoreResult := s.createBookmarks(ctx, req, missing)
+	restoreResult.Skipped = skipped
+
+	return textResult(s.formatRestoreResult("Restored from "+path, restoreResult)), restoreResult, nil
+}
+
+// missingBookmarks returns the requests whose URL isn't bookmarked yet and
+// how many were skipped. URLs are compared after normalizeURL, both against
+// the library and within requests, so the same page is never added twice.
+func (s *MCPServer) missingBookmarks(ctx context.Context, requests []linkding.CreateBookmarkRequest) ([]linkding.CreateBookmarkRequest, int, error) {
+	// Fetch the library once instead of checking every URL separately
+	existing, err := s.getEntireLibrary(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bookmarked := make(map[string]bool, len(existing))
+	for _, bookmark := range existing {
+		bookmarked[normalizeURL(bookmark.URL)] = true
+	}
+
+	var (
+		missing []linkding.CreateBookmarkRequest
+		skipped int
+	)
+
+	for _, createReq := range requests {
+		key := normalizeURL(createReq.URL)
+		if bookmarked[key] {
+			skipped++
+
+			continue
+		}
+
+		// Guard against the same URL appearing twice in the input
+		bookmarked[key] = true
+		missing = append(missing, createReq)
+	}
+
+	return missing, skipped, nil
+}
+
+// createBookmarks creates the given bookmarks with bounded concurrency
+func (s *MCPServer) createBookmarks(ctx context.Context, req *mcpsdk.CallToolRequest, requests []linkding.CreateBookmarkRequest) RestoreResult {
+	restoreResult := RestoreResult{Failures: []RestoreFailure{}}
+	errs := make([]error, len(requests))
+
+	started, cancelled := s.forEachConcurrently(ctx, req, len(requests), func(ctx context.Context, i int) {
+		_, errs[i] = s.linkdingClient.CreateBookmark(ctx, requests[i])
+	})
+
+	for i, err := range errs[:started] {
+		if err != nil {
+			restoreResult.Failed++
+			restoreResult.Failures = append(restoreResult.Failures, RestoreFailure{URL: requests[i].URL, Error: err.Error()})
+		} else {
+			restoreResult.Created++
+		}
+	}
+
+	restoreResult.NotStarted = len(requests) - started
+	restoreResult.Cancelled = cancelled
+
+	return restoreResult
+}
+
+// formatRestoreResult renders the outcome of createBookmarks after a summary
+// like "Restored from backup.json"
+func (s *MCPServer) formatRestoreResult(summary string, r RestoreResult) string {
+	result := fmt.Sprintf("%s %s: %d created, %d skipped (already bookmarked), %d failed",
+		s.mark(markRestore), summary, r.Created, r.Skipped, r.Failed)
+
+	if len(r.Failures) > 0 {
+		result += "\n\nFailures:\n"
+
+		for _, failure := range r.Failures {
+			result += fmt.Sprintf("• %s: %s\n", failure.URL, failure.Error)
+		}
+	}
+
+	if r.Cancelled {
+		result += fmt.Sprintf("\n\nCancelled before the remaining %s were created.", pluralize(r.NotStarted, "bookmark"))
+	}
+
+	return result
+}
+
+// writeNewFile writes data to a file that must not exist yet, so a backup never overwrites another one
+func writeNewFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+// writeBackup writes a backup_library file with the given URLs to dir
+func writeBackup(t *testing.T, dir, name string, urls ...string) {
+	t.Helper()
+
+	var bookmarks []linkding.Bookmark
+	for _, u := range urls {
+		bookmarks = append(bookmarks, linkding.Bookmark{URL: u, Title: "Title of " + u})
+	}
+
+	writeBackupBookmarks(t, dir, name, bookmarks...)
+}
+
+// writeBackupBookmarks writes a backup_library file with the given bookmarks to dir
+func writeBackupBookmarks(t *testing.T, dir, name string, bookmarks ...linkding.Bookmark) {
+	t.Helper()
+
+	data, err := json.Marshal(backupFile{Count: len(bookmarks), Bookmarks: bookmarks})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreLibraryBulkLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    int
+		wantCreated int
+		wantSkipped int
+		wantError   string
+	}{
+		{name: "missing bookmarks within the limit", existing: 3, wantCreated: 2, wantSkipped: 3},
+		{name: "missing bookmarks over the limit", existing: 1, wantError: "This would process 4 bookmarks, more than the limit of 2"},
+		{name: "nothing missing", existing: 5, wantSkipped: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var urls []string
+			for i := range 5 {
+				urls = append(urls, fmt.Sprintf("https://example.com/%d", i))
+			}
+
+			var existing []linkding.Bookmark
+			for _, u := range urls[:tt.existing] {
+				existing = append(existing, linkding.Bookmark{URL: u})
+			}
+
+			dir := t.TempDir()
+			writeBackup(t, dir, "backup.json", urls...)
+
+			fake := newFakeLinkding(t, existing...)
+			s := newTestServer(t, fake, WithBackupDir(dir), WithMaxBulkItems(2))
+
+			result := callTool(t, s, "restore_library", map[string]any{"path": "backup.json"})
+			creates := fake.received("POST", "/api/bookmarks/")
+
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+					t.Errorf("result = %q, want an error containing %q", resultText(result), tt.wantError)
+				}
+
+				if len(creates) > 0 {
+					t.Errorf("created %d bookmarks despite the limit", len(creates))
+				}
+
+				return
+			}
+
+			restoreResult := structured[RestoreResult](t, result)
+			if restoreResult.Created != tt.wantCreated || restoreResult.Skipped != tt.wantSkipped || len(creates) != tt.wantCreated {
+				t.Errorf("created %d (%d requests), skipped %d; want %d and %d",
+					restoreResult.Created, len(creates), restoreResult.Skipped, tt.wantCreated, tt.wantSkipped)
+			}
+
+			if fake.count() != 5 {
+				t.Errorf("library has %d bookmarks after restoring, want 5", fake.count())
+			}
+		})
+	}
+}
+
+func TestBackupLibrary(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		existing  bool
+		wantError string
+	}{
+		{name: "named file", path: "mine.json"},
+		{name: "default name", path: ""},
+		{name: "nested directory", path: "sub/mine.json"},
+		{name: "existing file not overwritten", path: "mine.json", existing: true, wantError: "Failed to write backup"},
+		{name: "traversal", path: "../escape.json", wantError: "must not contain"},
+		{name: "absolute path outside", path: filepath.Join(os.TempDir(), "escape.json"), wantError: "outside the backup directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.Mkdir(filepath.Join(dir, "sub"), 0o700); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.existing {
+				if err := os.WriteFile(filepath.Join(dir, tt.path), []byte("keep"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{URL: "https://a.example", Title: "A"},
+				linkding.Bookmark{URL: "https://b.example", Title: "B", IsArchived: true},
+			)
+
+			result := callTool(t, newTestServer(t, fake, WithBackupDir(dir)), "backup_library", map[string]any{"path": tt.path})
+
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+					t.Errorf("result = %q, want an error containing %q", resultText(result), tt.wantError)
+				}
+
+				if tt.existing {
+					if data, _ := os.ReadFile(filepath.Join(dir, tt.path)); string(data) != "keep" {
+						t.Errorf("existing file was overwritten with %q", data)
+					}
+				}
+
+				return
+			}
+
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			backupResult := structured[BackupResult](t, result)
+			if backupResult.Count != 2 {
+				t.Errorf("count = %d, want 2", backupResult.Count)
+			}
+
+			if resolved, _ := filepath.EvalSymlinks(dir); !strings.HasPrefix(backupResult.Path, resolved+string(filepath.Separator)) {
+				t.Errorf("backup written to %s, outside %s", backupResult.Path, dir)
+			}
+
+			data, err := os.ReadFile(backupResult.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var backup backupFile
+			if err := json.Unmarshal(data, &backup); err != nil {
+				t.Fatal(err)
+			}
+
+			if backup.Count != 2 || len(backup.Bookmarks) != 2 || !backup.Bookmarks[1].IsArchived {
+				t.Errorf("backup = %+v, want both bookmarks including the archived one", backup)
+			}
+		})
+	}
+}
+
+func TestRestoreLibrary(t *testing.T) {
+	dir := t.TempDir()
+	writeBackupBookmarks(t, dir, "backup.json",
+		linkding.Bookmark{URL: "https://exists.example/page", Title: "Exists"},
+		linkding.Bookmark{URL: "https://new.example/a", Title: "A", Notes: "notes", TagNames: []string{"go"}, Unread: true},
+		linkding.Bookmark{URL: "https://new.example/b", Title: "B", IsArchived: true},
+		linkding.Bookmark{URL: "https://NEW.example/a/?utm_source=feed", Title: "A again"},
+		linkding.Bookmark{Title: "No URL"},
+	)
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"bookmarks": [`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		wantCreated int
+		wantSkipped int
+		wantFailed  int
+		wantError   string
+	}{
+		{name: "sample backup", path: "backup.json", wantCreated: 2, wantSkipped: 2, wantFailed: 1},
+		{name: "invalid JSON", path: "broken.json", wantError: "Failed to parse backup"},
+		{name: "missing file", path: "missing.json", wantError: "Failed to read backup"},
+		{name: "traversal", path: "../backup.json", wantError: "must not contain"},
+		{name: "no path", wantError: "Backup path is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{URL: "https://exists.example/page/"})
+
+			result := callTool(t, newTestServer(t, fake, WithBackupDir(dir)), "restore_library", map[string]any{"path": tt.path})
+
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+					t.Errorf("result = %q, want an error containing %q", resultText(result), tt.wantError)
+				}
+
+				return
+			}
+
+			restoreResult := structured[RestoreResult](t, result)
+			if restoreResult.Created != tt.wantCreated || restoreResult.Skipped != tt.wantSkipped || restoreResult.Failed != tt.wantFailed {
+				t.Errorf("got %d created, %d skipped, %d failed; want %d, %d, %d",
+					restoreResult.Created, restoreResult.Skipped, restoreResult.Failed, tt.wantCreated, tt.wantSkipped, tt.wantFailed)
+			}
+
+			// The stored fields are restored as they were, without scraping
+			for _, post := range fake.received(http.MethodPost, "/api/bookmarks/") {
+				if post.Body["disable_scraping"] != true {
+					t.Errorf("created %v with scraping enabled", post.Body["url"])
+				}
+
+				switch post.Body["url"] {
+				case "https://new.example/a":
+					if post.Body["notes"] != "notes" || post.Body["unread"] != true {
+						t.Errorf("restored %v, want the notes and unread flag kept", post.Body)
+					}
+				case "https://new.example/b":
+					if post.Body["is_archived"] != true {
+						t.Errorf("restored %v, want it archived", post.Body)
+					}
+				}
+			}
+		})
+	}
+}
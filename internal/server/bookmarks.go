@@ -0,0 +1,431 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *MCPServer) handleDeleteBookmark(ctx context.Context, req *mcpsdk.CallToolRequest, args DeleteBookmarkArgs) (*mcpsdk.CallToolResult, BookmarkResult, error) {
+	if args.ID == 0 {
+		return errorResult("Bookmark ID is required"), BookmarkResult{}, nil
+	}
+
+	// Fetch the bookmark first so a wrong ID fails before asking for confirmation
+	bookmark, err := s.linkdingClient.GetBookmark(ctx, args.ID)
+	if err != nil {
+		if notFound := bookmarkNotFound(args.ID, err); notFound != nil {
+			return notFound, BookmarkResult{}, nil
+		}
+
+		return errorResult("Failed to get bookmark: %v", err), BookmarkResult{}, nil
+	}
+
+	if blocked := confirmDestructive(ctx, req, args.Confirm, describeDeletion([]int{bookmark.ID})); blocked != nil {
+		return blocked, BookmarkResult{}, nil
+	}
+
+	if err := s.linkdingClient.DeleteBookmark(ctx, bookmark.ID); err != nil {
+		if notFound := bookmarkNotFound(bookmark.ID, err); notFound != nil {
+			return notFound, BookmarkResult{}, nil
+		}
+
+		return errorResult("Failed to delete bookmark: %v", err), BookmarkResult{}, nil
+	}
+
+	bookmarkResult := BookmarkResult{
+		ID:      bookmark.ID,
+		URL:     bookmark.URL,
+		Title:   bookmark.Title,
+		Success: true,
+		Message: "Bookmark deleted successfully",
+		Deleted: true,
+	}
+
+	return textResult(fmt.Sprintf("%s Bookmark deleted\n\n• **%s**\n  URL: %s\n  ID: %d", s.mark(markDeleted), bookmark.Title, bookmark.URL, bookmark.ID)),
+		bookmarkResult, nil
+}
+
+func (s *MCPServer) handleDeleteBookmarkByURL(ctx context.Context, req *mcpsdk.CallToolRequest, args DeleteBookmarkByURLArgs) (*mcpsdk.CallToolResult, BookmarkResult, error) {
+	if args.URL == "" {
+		return errorResult("URL is required"), BookmarkResult{}, nil
+	}
+
+	matches, err := s.findBookmarksByURL(ctx, args.URL)
+	if err != nil {
+		return errorResult("Failed to look up bookmark: %v", err), BookmarkResult{}, nil
+	}
+
+	if len(matches) == 0 {
+		return errorResult("No bookmark found for URL %s", args.URL), BookmarkResult{}, nil
+	}
+
+	if len(matches) > 1 {
+		return errorResult("Multiple bookmarks match URL %s (IDs %v); delete by ID instead",
+			args.URL, bookmarkIDs(matches)), BookmarkResult{}, nil
+	}
+
+	bookmark := matches[0]
+
+	if blocked := confirmDestructive(ctx, req, args.Confirm, describeDeletion([]int{bookmark.ID})); blocked != nil {
+		return blocked, BookmarkResult{}, nil
+	}
+
+	if err := s.linkdingClient.DeleteBookmark(ctx, bookmark.ID); err != nil {
+		return errorResult("Failed to delete bookmark: %v", err), BookmarkResult{}, nil
+	}
+
+	bookmarkResult := BookmarkResult{
+		ID:      bookmark.ID,
+		URL:     bookmark.URL,
+		Title:   bookmark.Title,
+		Success: true,
+		Message: "Bookmark deleted successfully",
+		Deleted: true,
+	}
+
+	return textResult(fmt.Sprintf("%s Bookmark deleted\n\n• **%s**\n  URL: %s\n  ID: %d", s.mark(markDeleted), bookmark.Title, bookmark.URL, bookmark.ID)),
+		bookmarkResult, nil
+}
+
+func (s *MCPServer) handleUpdateBookmark(ctx context.Context, req *mcpsdk.CallToolRequest, args UpdateBookmarkArgs) (*mcpsdk.CallToolResult, BookmarkResult, error) {
+	if args.ID == 0 {
+		return errorResult("Bookmark ID is required"), BookmarkResult{}, nil
+	}
+
+	// Linkding's PUT replaces the whole record, clearing omitted fields, so
+	// start from the current bookmark and only override the supplied fields
+	existing, err := s.linkdingClient.GetBookmark(ctx, args.ID)
+	if err != nil {
+		if notFound := bookmarkNotFound(args.ID, err); notFound != nil {
+			return notFound, BookmarkResult{}, nil
+		}
+
+		return errorResult("Failed to get bookmark: %v", err), BookmarkResult{}, nil
+	}
+
+	updateReq := linkding.CreateBookmarkRequest{
+		URL:         existing.URL,
+		Title:       existing.Title,
+		Description: existing.Description,
+		Notes:       existing.Notes,
+		TagNames:    existing.TagNames,
+		Unread:      existing.Unread,
+		Shared:      existing.Shared,
+		IsArchived:  existing.IsArchived,
+	}
+
+	if args.URL != nil {
+		updateReq.URL = *args.URL
+	}
+
+	if args.Title != nil {
+		updateReq.Title = *args.Title
+	}
+
+	if args.Description != nil {
+		updateReq.Description = *args.Description
+	}
+
+	if args.Notes != nil {
+		updateReq.Notes = *args.Notes
+	}
+
+	if args.Tags != nil {
+		if disallowed := s.checkVocabulary(args.Tags); disallowed != nil {
+			return disallowed, BookmarkResult{}, nil
+		}
+
+		updateReq.TagNames = args.Tags
+	}
+
+	bookmark, err := s.linkdingClient.UpdateBookmark(ctx, args.ID, updateReq)
+	if err != nil {
+		return errorResult("Failed to update bookmark: %v", err), BookmarkResult{}, nil
+	}
+
+	result := fmt.Sprintf("%s Bookmark updated\n\n• **%s**\n  URL: %s\n  ID: %d",
+		s.mark(markSuccess), bookmark.Title, bookmark.URL, bookmark.ID)
+
+	if bookmark.Description != "" {
+		result += "\n  Description: " + bookmark.Description
+	}
+
+	if len(bookmark.TagNames) > 0 {
+		result += fmt.Sprintf("\n  Tags: %v", bookmark.TagNames)
+	}
+
+	bookmarkResult := BookmarkResult{
+		ID:          bookmark.ID,
+		URL:         bookmark.URL,
+		Title:       bookmark.Title,
+		Description: bookmark.Description,
+		Tags:        bookmark.TagNames,
+		Success:     true,
+		Message:     "Bookmark updated successfully",
+	}
+
+	return textResult(result), bookmarkResult, nil
+}
+
+func (s *MCPServer) handleArchiveBookmark(ctx context.Context, req *mcpsdk.CallToolRequest, args ArchiveBookmarkArgs) (*mcpsdk.CallToolResult, BookmarkResult, error) {
+	return s.setArchived(ctx, args.ID, true)
+}
+
+func (s *MCPServer) handleUnarchiveBookmark(ctx context.Context, req *mcpsdk.CallToolRequest, args ArchiveBookmarkArgs) (*mcpsdk.CallToolResult, BookmarkResult, error) {
+	return s.setArchived(ctx, args.ID, false)
+}
+
+// setArchived archives or unarchives a bookmark and reports its new state
+func (s *MCPServer) setArchived(ctx context.Context, id int, archived bool) (*mcpsdk.CallToolResult, BookmarkResult, error) {
+	if id == 0 {
+		return errorResult("Bookmark ID is required"), BookmarkResult{}, nil
+	}
+
+	bookmark, err := s.linkdingClient.GetBookmark(ctx, id)
+	if err != nil {
+		if notFound := bookmarkNotFound(id, err); notFound != nil {
+			return notFound, BookmarkResult{}, nil
+		}
+
+		return errorResult("Failed to get bookmark: %v", err), BookmarkResult{}, nil
+	}
+
+	action, setState, m := "archive", s.linkdingClient.ArchiveBookmark, markArchived
+	if !archived {
+		action, setState, m = "unarchive", s.linkdingClient.UnarchiveBookmark, markUnarchived
+	}
+
+	if err := setState(ctx, id); err != nil {
+		if notFound := bookmarkNotFound(id, err); notFound != nil {
+			return notFound, BookmarkResult{}, nil
+		}
+
+		return errorResult("Failed to %s bookmark: %v", action, err), BookmarkResult{}, nil
+	}
+
+	state := "archived"
+	if !archived {
+		state = "no longer archived"
+	}
+
+	bookmarkResult := BookmarkResult{
+		ID:       bookmark.ID,
+		URL:      bookmark.URL,
+		Title:    bookmark.Title,
+		Tags:     bookmark.TagNames,
+		Success:  true,
+		Message:  "Bookmark is now " + state,
+		Archived: ptr(archived),
+	}
+
+	return textResult(fmt.Sprintf("%s Bookmark is now %s\n\n• **%s**\n  URL: %s\n  ID: %d",
+		s.mark(m), state, bookmark.Title, bookmark.URL, bookmark.ID)), bookmarkResult, nil
+}
+
+// bookmarkNotFound returns an error result naming the bookmark when err is a
+// 404 from Linkding, and nil for any other error
+func bookmarkNotFound(id int, err error) *mcpsdk.CallToolResult {
+	if !errors.Is(err, linkding.ErrNotFound) {
+		return nil
+	}
+
+	return errorResult("Bookmark %d not found", id)
+}
+
+// findBookmarksByURL resolves a URL to its bookmarks. Linkding's check endpoint
+// is tried first; if it finds nothing, a search is filtered to exact URL matches
+// (ignoring a trailing slash), which may surface more than one bookmark.
+func (s *MCPServer) findBookmarksByURL(ctx context.Context, rawURL string) ([]linkding.Bookmark, error) {
+	check, err := s.linkdingClient.CheckBookmark(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if check.Bookmark != nil {
+		return []linkding.Bookmark{*check.Bookmark}, nil
+	}
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterBookmarks(bookmarks, func(b linkding.Bookmark) bool {
+		return strings.TrimSuffix(b.URL, "/") == strings.TrimSuffix(rawURL, "/")
+	}), nil
+}
+
+func (s *MCPServer) handleAppendNote(ctx context.Context, req *mcpsdk.CallToolRequest, args AppendNoteArgs) (*mcpsdk.CallToolResult, BookmarkResult, error) {
+	if args.ID == 0 {
+		return errorResult("Bookmark ID is required"), BookmarkResult{}, nil
+	}
+
+	if strings.TrimSpace(args.Note) == "" {
+		return errorResult("Note is required"), BookmarkResult{}, nil
+	}
+
+	bookmark, err := s.linkdingClient.GetBookmark(ctx, args.ID)
+	if err != nil {
+		if notFound := bookmarkNotFound(args.ID, err); notFound != nil {
+			return notFound, BookmarkResult{}, nil
+		}
+
+		return errorResult("Failed to get bookmark: %v", err), BookmarkResult{}, nil
+	}
+
+	notes := appendNoteEntry(bookmark.Notes, args.Note, time.Now())
+
+	bookmark, err = s.linkdingClient.PatchBookmark(ctx, bookmark.ID, map[string]any{"notes": notes})
+	if err != nil {
+		return errorResult("Failed to update notes: %v", err), BookmarkResult{}, nil
+	}
+
+	bookmarkResult := BookmarkResult{
+		ID:          bookmark.ID,
+		URL:         bookmark.URL,
+		Title:       bookmark.Title,
+		Description: bookmark.Description,
+		Tags:        bookmark.TagNames,
+		Success:     true,
+		Message:     "Note appended",
+	}
+
+	return textResult(fmt.Sprintf("%s Appended a note to bookmark %d (**%s**)", s.mark(markNote), bookmark.ID, bookmark.Title)), bookmarkResult, nil
+}
+
+// appendNoteEntry appends a timestamped markdown entry to existing notes, keeping them intact
+func appendNoteEntry(notes, note string, now time.Time) string {
+	entry := fmt.Sprintf("**%s**\n\n%s", now.UTC().Format("2006-01-02 15:04 UTC"), strings.TrimSpace(note))
+
+	if strings.TrimSpace(notes) == "" {
+		return entry
+	}
+
+	return strings.TrimRight(notes, "\n") + "\n\n" + entry
+}
+
+func (s *MCPServer) handleConsolidateBookmarkText(ctx context.Context, req *mcpsdk.CallToolRequest, args ConsolidateTextArgs) (*mcpsdk.CallToolResult, ConsolidateTextResult, error) {
+	if args.ID == 0 {
+		return errorResult("Bookmark ID is required"), ConsolidateTextResult{}, nil
+	}
+
+	bookmark, err := s.linkdingClient.GetBookmark(ctx, args.ID)
+	if err != nil {
+		if notFound := bookmarkNotFound(args.ID, err); notFound != nil {
+			return notFound, ConsolidateTextResult{}, nil
+		}
+
+		return errorResult("Failed to get bookmark: %v", err), ConsolidateTextResult{}, nil
+	}
+
+	consolidateResult := consolidateText(bookmark.Description, bookmark.Notes)
+	consolidateResult.ID = bookmark.ID
+
+	if !consolidateResult.Changed {
+		return textResult(fmt.Sprintf("Nothing to consolidate in bookmark %d (**%s**): %s",
+			bookmark.ID, bookmark.Title, describeTextRelation(consolidateResult.Relation))), consolidateResult, nil
+	}
+
+	if !args.Apply {
+		result := fmt.Sprintf("Bookmark %d (**%s**): %s. Proposed notes:\n\n%s\n\nCall again with apply=true to save the change.",
+			bookmark.ID, bookmark.Title, describeTextRelation(consolidateResult.Relation), orNone(consolidateResult.Notes))
+
+		return textResult(result), consolidateResult, nil
+	}
+
+	// Only the notes are ever rewritten; the description shown in listings stays as is
+	if _, err := s.linkdingClient.PatchBookmark(ctx, bookmark.ID, map[string]any{"notes": consolidateResult.Notes}); err != nil {
+		return errorResult("Failed to update notes: %v", err), ConsolidateTextResult{}, nil
+	}
+
+	consolidateResult.Applied = true
+
+	return textResult(fmt.Sprintf("%s Consolidated bookmark %d (**%s**): %s",
+		s.mark(markSuccess), bookmark.ID, bookmark.Title, describeTextRelation(consolidateResult.Relation))), consolidateResult, nil
+}
+
+// Relations between a bookmark's description and notes found by consolidateText
+const (
+	textEmpty                  = "empty"
+	textDistinct               = "distinct"
+	textIdentical              = "identical"
+	textNotesWithinDescription = "notes_within_description"
+	textDescriptionWithinNotes = "description_within_notes"
+)
+
+// consolidateText compares description and notes, ignoring case and
+// whitespace, and proposes new notes without the redundant content. The merge
+// is conservative: notes are only cleared when the description already holds
+// all of their text, and only a description repeated at the very start of the
+// notes is removed from them. Anything else is reported but left unchanged.
+func consolidateText(description, notes string) ConsolidateTextResult {
+	consolidateResult := ConsolidateTextResult{Description: description, Notes: notes}
+
+	normalizedDescription, normalizedNotes := normalizeText(description), normalizeText(notes)
+
+	switch {
+	case normalizedDescription == "" || normalizedNotes == "":
+		consolidateResult.Relation = textEmpty
+	case normalizedDescription == normalizedNotes:
+		consolidateResult.Relation = textIdentical
+		consolidateResult.Notes = ""
+	case containsWords(normalizedDescription, normalizedNotes):
+		consolidateResult.Relation = textNotesWithinDescription
+		consolidateResult.Notes = ""
+	case containsWords(normalizedNotes, normalizedDescription):
+		consolidateResult.Relation = textDescriptionWithinNotes
+
+		trimmedNotes := strings.TrimSpace(notes)
+		if prefix := strings.TrimSpace(description); strings.HasPrefix(trimmedNotes, prefix) {
+			consolidateResult.Notes = strings.TrimSpace(strings.TrimPrefix(trimmedNotes, prefix))
+		}
+	default:
+		consolidateResult.Relation = textDistinct
+	}
+
+	consolidateResult.Changed = consolidateResult.Notes != notes
+
+	return consolidateResult
+}
+
+// normalizeText lowercases text and collapses all whitespace to single spaces
+func normalizeText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// containsWords reports whether normalized text contains part as a run of
+// whole words, so a word isn't considered redundant being part of a longer one
+func containsWords(text, part string) bool {
+	return strings.Contains(" "+text+" ", " "+part+" ")
+}
+
+// describeTextRelation explains a consolidateText relation in tool output
+func describeTextRelation(relation string) string {
+	switch relation {
+	case textEmpty:
+		return "the description or notes are empty"
+	case textIdentical:
+		return "the notes duplicate the description"
+	case textNotesWithinDescription:
+		return "the description already contains the notes"
+	case textDescriptionWithinNotes:
+		return "the notes repeat the description"
+	default:
+		return "the description and notes are distinct"
+	}
+}
+
+// orNone renders empty text as a placeholder in tool output
+func orNone(text string) string {
+	if text == "" {
+		return "(none)"
+	}
+
+	return text
+}
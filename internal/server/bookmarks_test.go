@@ -0,0 +1,527 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestDeleteBookmarkByURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		wantDeleted int
+		wantError   string
+	}{
+		{name: "exact match", url: "https://a.example/post", wantDeleted: 1},
+		{name: "match ignoring the trailing slash", url: "https://b.example/page", wantDeleted: 2},
+		{name: "not found", url: "https://missing.example", wantError: "No bookmark found"},
+		{name: "ambiguous", url: "https://dup.example/x", wantError: "Multiple bookmarks match URL https://dup.example/x (IDs [4 3])"},
+		{name: "missing URL", wantError: "URL is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{ID: 1, URL: "https://a.example/post"},
+				linkding.Bookmark{ID: 2, URL: "https://b.example/page/"},
+				linkding.Bookmark{ID: 3, URL: "https://dup.example/x/"},
+				linkding.Bookmark{ID: 4, URL: "https://dup.example/x/"},
+			)
+
+			result := callTool(t, newTestServer(t, fake), "delete_bookmark_by_url", map[string]any{"url": tt.url, "confirm": true})
+			deletes := fake.received("DELETE", "/api/bookmarks/")
+
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+					t.Errorf("result = %q, want an error containing %q", resultText(result), tt.wantError)
+				}
+
+				if len(deletes) > 0 {
+					t.Errorf("deleted %v despite the error", deletes)
+				}
+
+				return
+			}
+
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			if got := structured[BookmarkResult](t, result); got.ID != tt.wantDeleted || !got.Deleted {
+				t.Errorf("result = %+v, want bookmark %d deleted", got, tt.wantDeleted)
+			}
+
+			if _, found := fake.bookmark(tt.wantDeleted); found || fake.count() != 3 {
+				t.Errorf("bookmark %d wasn't the only one deleted", tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestCreateBookmarkPreviewFields(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want map[string]any
+	}{
+		{
+			name: "sent when provided",
+			args: map[string]any{
+				"url": "https://a.example", "title": "A", "disable_scraping": true,
+				"favicon_url": "https://a.example/favicon.ico", "preview_image_url": "https://a.example/preview.png",
+			},
+			want: map[string]any{
+				"disable_scraping": true, "favicon_url": "https://a.example/favicon.ico", "preview_image_url": "https://a.example/preview.png",
+			},
+		},
+		{
+			name: "omitted otherwise",
+			args: map[string]any{"url": "https://a.example", "title": "A"},
+			want: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t)
+
+			result := callTool(t, newTestServer(t, fake), "create_bookmark", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			posts := fake.received("POST", "/api/bookmarks/")
+			if len(posts) != 1 {
+				t.Fatalf("got %d POST requests, want 1", len(posts))
+			}
+
+			for _, field := range []string{"disable_scraping", "favicon_url", "preview_image_url"} {
+				got, sent := posts[0].Body[field]
+				if want, ok := tt.want[field]; sent != ok || got != want {
+					t.Errorf("%s = %v (sent %v), want %v (sent %v)", field, got, sent, want, ok)
+				}
+			}
+		})
+	}
+}
+
+// rejectingService fails every SaveBookmark with err, like Linkding versions
+// rejecting duplicate URLs instead of updating the existing bookmark
+type rejectingService struct {
+	BookmarkService
+
+	err error
+}
+
+func (s *rejectingService) SaveBookmark(context.Context, linkding.CreateBookmarkRequest) (*linkding.Bookmark, bool, error) {
+	return nil, false, s.err
+}
+
+func TestCreateBookmarkDuplicate(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		saveErr    error
+		wantID     int
+		wantText   string
+		wantError  bool
+		wantCount  int
+		wantChecks int
+	}{
+		{name: "new URL", url: "https://new.example", wantID: 2, wantText: "Bookmark created successfully", wantCount: 2},
+		{name: "existing URL updated", url: "https://a.example", wantID: 1, wantText: "Bookmark already existed (ID 1) and was updated", wantCount: 1},
+		{
+			name: "conflict", url: "https://a.example", saveErr: &linkding.APIError{StatusCode: http.StatusConflict},
+			wantID: 1, wantText: "URL is already bookmarked (ID 1)", wantCount: 1, wantChecks: 1,
+		},
+		{
+			name: "url validation error", url: "https://a.example",
+			saveErr: &linkding.APIError{StatusCode: http.StatusBadRequest, FieldErrors: map[string][]string{"url": {"Bookmark already exists."}}},
+			wantID:  1, wantText: "URL is already bookmarked (ID 1)", wantCount: 1, wantChecks: 1,
+		},
+		{
+			name: "other validation error", url: "https://a.example",
+			saveErr:   &linkding.APIError{StatusCode: http.StatusBadRequest, FieldErrors: map[string][]string{"title": {"Too long."}}},
+			wantText:  "Failed to create bookmark",
+			wantError: true, wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "A"})
+			s := newTestServer(t, fake)
+
+			if tt.saveErr != nil {
+				s = newTestServer(t, fake, WithClient(&rejectingService{BookmarkService: s.linkdingClient, err: tt.saveErr}))
+			}
+
+			result := callTool(t, s, "create_bookmark", map[string]any{"url": tt.url, "title": "Title"})
+			if result.IsError != tt.wantError || !strings.Contains(resultText(result), tt.wantText) {
+				t.Fatalf("result = %q (error %v), want %q (error %v)", resultText(result), result.IsError, tt.wantText, tt.wantError)
+			}
+
+			if !tt.wantError {
+				got := structured[BookmarkResult](t, result)
+				if got.ID != tt.wantID {
+					t.Errorf("ID = %d, want %d", got.ID, tt.wantID)
+				}
+
+				if got.Message == "" || !strings.Contains(resultText(result), got.Message) {
+					t.Errorf("structured message %q doesn't match the output %q", got.Message, resultText(result))
+				}
+			}
+
+			if fake.count() != tt.wantCount {
+				t.Errorf("%d bookmarks, want %d", fake.count(), tt.wantCount)
+			}
+
+			// Duplicates are only looked up after Linkding rejected one
+			if checks := len(fake.received(http.MethodGet, "/api/bookmarks/check")); checks != tt.wantChecks {
+				t.Errorf("sent %d check requests, want %d", checks, tt.wantChecks)
+			}
+		})
+	}
+}
+
+func TestAppendNoteEntry(t *testing.T) {
+	now := time.Date(2024, 3, 1, 14, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name  string
+		notes string
+		note  string
+		want  string
+	}{
+		{name: "first entry", note: "Read it", want: "**2024-03-01 13:30 UTC**\n\nRead it"},
+		{name: "blank notes", notes: " \n", note: "Read it", want: "**2024-03-01 13:30 UTC**\n\nRead it"},
+		{name: "prior content kept", notes: "# Summary\n\n- point\n\n", note: "  Follow-up  ", want: "# Summary\n\n- point\n\n**2024-03-01 13:30 UTC**\n\nFollow-up"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendNoteEntry(tt.notes, tt.note, now); got != tt.want {
+				t.Errorf("appendNoteEntry() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendNote(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantNotes []string
+		wantError bool
+	}{
+		{name: "appended after the existing notes", args: map[string]any{"id": 1, "note": "Second thoughts"}, wantNotes: []string{"First entry\n\n**", " UTC**\n\nSecond thoughts"}},
+		{name: "empty note", args: map[string]any{"id": 1, "note": "  "}, wantError: true},
+		{name: "unknown bookmark", args: map[string]any{"id": 99, "note": "x"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: "https://a.example", Notes: "First entry"})
+
+			result := callTool(t, newTestServer(t, fake), "append_note", tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			bookmark, _ := fake.bookmark(1)
+
+			if tt.wantError {
+				if bookmark.Notes != "First entry" {
+					t.Errorf("notes changed to %q despite the error", bookmark.Notes)
+				}
+
+				return
+			}
+
+			if !strings.HasPrefix(bookmark.Notes, "First entry") {
+				t.Errorf("notes %q lost the prior content", bookmark.Notes)
+			}
+
+			for _, want := range tt.wantNotes {
+				if !strings.Contains(bookmark.Notes, want) {
+					t.Errorf("notes %q don't contain %q", bookmark.Notes, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConsolidateText(t *testing.T) {
+	tests := []struct {
+		name         string
+		description  string
+		notes        string
+		wantRelation string
+		wantNotes    string
+	}{
+		{name: "duplicates ignoring case and whitespace", description: "A guide to Go.", notes: "a guide\n to  go.", wantRelation: textIdentical},
+		{name: "notes within the description", description: "A guide to Go generics and interfaces", notes: "go generics", wantRelation: textNotesWithinDescription},
+		{name: "description repeated at the start of the notes", description: "A guide to Go", notes: "A guide to Go\n\nMy take: worth it", wantRelation: textDescriptionWithinNotes, wantNotes: "My take: worth it"},
+		{name: "description in the middle of the notes kept", description: "A guide to Go", notes: "Quoting a guide to go here", wantRelation: textDescriptionWithinNotes, wantNotes: "Quoting a guide to go here"},
+		{name: "distinct", description: "A guide to Go", notes: "Read chapter 3 first", wantRelation: textDistinct, wantNotes: "Read chapter 3 first"},
+		{name: "part of a word isn't redundant", description: "Go testing tips", notes: "test", wantRelation: textDistinct, wantNotes: "test"},
+		{name: "empty notes", description: "A guide to Go", wantRelation: textEmpty},
+		{name: "empty description", notes: "Mine", wantRelation: textEmpty, wantNotes: "Mine"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := consolidateText(tt.description, tt.notes)
+			if got.Relation != tt.wantRelation || got.Notes != tt.wantNotes || got.Description != tt.description {
+				t.Errorf("got %s with notes %q and description %q, want %s with notes %q", got.Relation, got.Notes, got.Description, tt.wantRelation, tt.wantNotes)
+			}
+
+			if got.Changed != (tt.wantNotes != tt.notes) {
+				t.Errorf("changed = %v with notes %q -> %q", got.Changed, tt.notes, got.Notes)
+			}
+		})
+	}
+}
+
+func TestConsolidateBookmarkText(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		notes       string
+		wantApplied bool
+		wantNotes   string
+		wantError   bool
+	}{
+		{name: "duplicate reported", args: map[string]any{"id": 1}, notes: "Same text", wantNotes: "Same text"},
+		{name: "duplicate applied", args: map[string]any{"id": 1, "apply": true}, notes: "Same text", wantApplied: true},
+		{name: "distinct left alone", args: map[string]any{"id": 1, "apply": true}, notes: "Other text", wantNotes: "Other text"},
+		{name: "unknown bookmark", args: map[string]any{"id": 99}, notes: "Same text", wantNotes: "Same text", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: "https://a.example", Description: "Same text", Notes: tt.notes})
+
+			result := callTool(t, newTestServer(t, fake), "consolidate_bookmark_text", tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if !tt.wantError {
+				if got := structured[ConsolidateTextResult](t, result); got.Applied != tt.wantApplied {
+					t.Errorf("applied = %v, want %v", got.Applied, tt.wantApplied)
+				}
+			}
+
+			bookmark, _ := fake.bookmark(1)
+			if bookmark.Notes != tt.wantNotes || bookmark.Description != "Same text" {
+				t.Errorf("notes %q and description %q, want notes %q and the description kept", bookmark.Notes, bookmark.Description, tt.wantNotes)
+			}
+
+			if patches := len(fake.received(http.MethodPatch, "/api/bookmarks/")); patches > 0 != tt.wantApplied {
+				t.Errorf("sent %d PATCH requests, want applied %v", patches, tt.wantApplied)
+			}
+		})
+	}
+}
+
+func TestUpdateBookmark(t *testing.T) {
+	original := linkding.Bookmark{
+		ID: 1, URL: "https://a.example", Title: "A", Description: "About A", Notes: "Mine",
+		TagNames: []string{"go", "web"}, Unread: true, Shared: true,
+	}
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		want      linkding.Bookmark
+		wantError string
+	}{
+		{
+			name: "omitted fields kept",
+			args: map[string]any{"id": 1, "title": "Renamed"},
+			want: linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "Renamed", Description: "About A", Notes: "Mine", TagNames: []string{"go", "web"}, Unread: true, Shared: true},
+		},
+		{
+			name: "every field",
+			args: map[string]any{"id": 1, "url": "https://b.example", "title": "B", "description": "About B", "notes": "Yours", "tags": []string{"rust"}},
+			want: linkding.Bookmark{ID: 1, URL: "https://b.example", Title: "B", Description: "About B", Notes: "Yours", TagNames: []string{"rust"}, Unread: true, Shared: true},
+		},
+		{
+			name: "fields cleared explicitly",
+			args: map[string]any{"id": 1, "description": "", "tags": []string{}},
+			want: linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "A", Notes: "Mine", Unread: true, Shared: true},
+		},
+		{name: "unknown bookmark", args: map[string]any{"id": 99, "title": "x"}, want: original, wantError: "Bookmark 99 not found"},
+		{name: "missing ID", args: map[string]any{"title": "x"}, want: original, wantError: "Bookmark ID is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, original)
+
+			result := callTool(t, newTestServer(t, fake), "update_bookmark", tt.args)
+			if (tt.wantError != "") != result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+				t.Fatalf("result = %q (error %v), want error %q", resultText(result), result.IsError, tt.wantError)
+			}
+
+			got, _ := fake.bookmark(1)
+			if got.URL != tt.want.URL || got.Title != tt.want.Title || got.Description != tt.want.Description || got.Notes != tt.want.Notes ||
+				!slices.Equal(got.TagNames, tt.want.TagNames) || got.Unread != tt.want.Unread || got.Shared != tt.want.Shared {
+				t.Errorf("bookmark = %+v, want %+v", got, tt.want)
+			}
+
+			if tt.wantError != "" {
+				return
+			}
+
+			if bookmarkResult := structured[BookmarkResult](t, result); bookmarkResult.ID != 1 || bookmarkResult.Title != tt.want.Title || !bookmarkResult.Success {
+				t.Errorf("result = %+v, want the updated bookmark", bookmarkResult)
+			}
+		})
+	}
+}
+
+func TestDeleteBookmark(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int
+		// deleteStatus, when set, answers the DELETE request instead of the fake's 204
+		deleteStatus int
+		wantText     string
+		wantError    bool
+		wantDeleted  bool
+	}{
+		{name: "204 No Content", id: 1, wantText: "Bookmark deleted", wantDeleted: true},
+		{name: "unknown ID", id: 99, wantText: "Bookmark 99 not found", wantError: true},
+		{name: "deleted meanwhile", id: 1, deleteStatus: http.StatusNotFound, wantText: "Bookmark 1 not found", wantError: true},
+		{name: "server error", id: 1, deleteStatus: http.StatusInternalServerError, wantText: "Failed to delete bookmark", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "A"})
+			fake.fail = func(r *http.Request) int {
+				if r.Method == http.MethodDelete {
+					return tt.deleteStatus
+				}
+
+				return 0
+			}
+
+			result := callTool(t, newTestServer(t, fake), "delete_bookmark", map[string]any{"id": tt.id, "confirm": true})
+			if result.IsError != tt.wantError || !strings.Contains(resultText(result), tt.wantText) {
+				t.Fatalf("result = %q (error %v), want %q (error %v)", resultText(result), result.IsError, tt.wantText, tt.wantError)
+			}
+
+			if strings.Contains(resultText(result), "status 404") {
+				t.Errorf("result %q exposes the raw status", resultText(result))
+			}
+
+			if _, found := fake.bookmark(1); found == tt.wantDeleted {
+				t.Errorf("bookmark 1 still stored = %v, want deleted %v", found, tt.wantDeleted)
+			}
+
+			if !tt.wantError {
+				if got := structured[BookmarkResult](t, result); got.ID != 1 || !got.Deleted || !got.Success {
+					t.Errorf("result = %+v, want bookmark 1 deleted", got)
+				}
+			}
+		})
+	}
+}
+
+func TestArchiveBookmark(t *testing.T) {
+	tests := []struct {
+		name         string
+		tool         string
+		id           int
+		archived     bool
+		failStatus   int
+		wantText     string
+		wantError    bool
+		wantArchived bool
+	}{
+		{name: "archive", tool: "archive_bookmark", id: 1, wantText: "Bookmark is now archived", wantArchived: true},
+		{name: "unarchive", tool: "unarchive_bookmark", id: 1, archived: true, wantText: "Bookmark is now no longer archived"},
+		{name: "archive already archived", tool: "archive_bookmark", id: 1, archived: true, wantText: "Bookmark is now archived", wantArchived: true},
+		{name: "missing ID", tool: "archive_bookmark", wantText: "Bookmark ID is required", wantError: true},
+		{name: "unknown ID", tool: "unarchive_bookmark", id: 99, wantText: "Bookmark 99 not found", wantError: true},
+		{name: "server error", tool: "archive_bookmark", id: 1, failStatus: http.StatusInternalServerError, wantText: "Failed to archive bookmark", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "A", IsArchived: tt.archived})
+			fake.fail = func(r *http.Request) int {
+				if r.Method == http.MethodPost {
+					return tt.failStatus
+				}
+
+				return 0
+			}
+
+			result := callTool(t, newTestServer(t, fake), tt.tool, map[string]any{"id": tt.id})
+			if result.IsError != tt.wantError || !strings.Contains(resultText(result), tt.wantText) {
+				t.Fatalf("result = %q (error %v), want %q (error %v)", resultText(result), result.IsError, tt.wantText, tt.wantError)
+			}
+
+			if bookmark, _ := fake.bookmark(1); !tt.wantError && bookmark.IsArchived != tt.wantArchived {
+				t.Errorf("bookmark 1 archived = %v, want %v", bookmark.IsArchived, tt.wantArchived)
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			if got := structured[BookmarkResult](t, result); got.ID != 1 || got.Archived == nil || *got.Archived != tt.wantArchived {
+				t.Errorf("result = %+v, want bookmark 1 archived %v", got, tt.wantArchived)
+			}
+		})
+	}
+}
+
+func TestCreateBookmarkAcceptLanguage(t *testing.T) {
+	fake := newFakeLinkding(t)
+	s := NewMCP(fake.URL, "test-token", WithAcceptLanguage("fr-FR"))
+
+	result := callTool(t, s, "create_bookmark", map[string]any{"url": "https://a.example"})
+	if result.IsError {
+		t.Fatal(resultText(result))
+	}
+
+	posts := fake.received(http.MethodPost, "/api/bookmarks/")
+	if len(posts) != 1 || posts[0].Header.Get("Accept-Language") != "fr-FR" {
+		t.Errorf("POST requests = %+v, want one with Accept-Language fr-FR", posts)
+	}
+}
+
+func TestSingleBookmarkToolsNotFound(t *testing.T) {
+	tests := []struct {
+		tool string
+		args map[string]any
+	}{
+		{tool: "get_share_link", args: map[string]any{"id": 99}},
+		{tool: "enrich_bookmark", args: map[string]any{"id": 99}},
+		{tool: "append_note", args: map[string]any{"id": 99, "note": "Read it"}},
+		{tool: "consolidate_bookmark_text", args: map[string]any{"id": 99}},
+		{tool: "estimate_reading_time", args: map[string]any{"id": 99}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{URL: "https://a.example"})
+
+			result := callTool(t, newTestServer(t, fake), tt.tool, tt.args)
+			if !result.IsError || resultText(result) != "Bookmark 99 not found" {
+				t.Errorf("result = %q (error %v), want error %q", resultText(result), result.IsError, "Bookmark 99 not found")
+			}
+		})
+	}
+}
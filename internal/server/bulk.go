@@ -0,0 +1,372 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// bulkConcurrency is the maximum number of Linkding requests a bulk tool runs in parallel
+	bulkConcurrency = 5
+	// defaultMaxBulkItems is the default cap on bookmarks processed by a single bulk tool call
+	defaultMaxBulkItems = 500
+	// defaultBulkRetryBudget is the default number of retries shared by all requests of a bulk tool call
+	defaultBulkRetryBudget = 20
+)
+
+// checkBulkLimit returns an error result if n items exceed the configured bulk cap
+func (s *MCPServer) checkBulkLimit(n int) *mcpsdk.CallToolResult {
+	if n <= s.maxBulkItems {
+		return nil
+	}
+
+	return errorResult("This would process %s, more than the limit of %d per call. Split the request into smaller chunks (or narrow the query).",
+		pluralize(n, "bookmark"), s.maxBulkItems)
+}
+
+// skipItemError is returned by a runBulk function for an item it left
+// unchanged on purpose, which is then counted as skipped rather than failed
+type skipItemError struct {
+	reason string
+}
+
+func (e *skipItemError) Error() string {
+	return e.reason
+}
+
+// runBulk calls fn for every ID with bounded concurrency and collects the
+// per-ID outcomes in input order. Progress is reported to the client when requested.
+// Items for which fn returns a *skipItemError are counted as skipped.
+//
+// If ctx is cancelled midway, no further items are started and the outcomes
+// of the items processed so far are returned, with the rest counted as skipped.
+func (s *MCPServer) runBulk(ctx context.Context, req *mcpsdk.CallToolRequest, ids []int, fn func(ctx context.Context, id int) error) BulkResult {
+	results := make([]BulkItemResult, len(ids))
+
+	started, cancelled := s.forEachConcurrently(ctx, req, len(ids), func(ctx context.Context, i int) {
+		results[i] = BulkItemResult{ID: ids[i], Success: true}
+
+		var skip *skipItemError
+
+		if err := fn(ctx, ids[i]); errors.As(err, &skip) {
+			results[i] = BulkItemResult{ID: ids[i], Skipped: true, Reason: skip.reason}
+		} else if err != nil {
+			results[i] = BulkItemResult{ID: ids[i], Error: err.Error()}
+		}
+	})
+
+	bulkResult := BulkResult{
+		Results:   results[:started],
+		Skipped:   len(ids) - started,
+		Cancelled: cancelled,
+	}
+
+	for _, r := range bulkResult.Results {
+		switch {
+		case r.Success:
+			bulkResult.Succeeded++
+		case r.Skipped:
+			bulkResult.Skipped++
+		default:
+			bulkResult.Failed++
+		}
+	}
+
+	return bulkResult
+}
+
+// forEachConcurrently calls fn for the indexes 0 to n-1, running at most
+// bulkConcurrency calls in parallel, and reports progress to the client when
+// requested. It returns how many items were started: once ctx is cancelled
+// no further items are, and the ones in flight are awaited.
+//
+// It also reports whether the run was cancelled, as observed while it ran:
+// either items were left unstarted or an item finished after ctx was done.
+// A context cancelled only after the last item finished doesn't count.
+//
+// All items share one retry budget, so a flaky Linkding retries at most
+// bulkRetryBudget requests in total instead of every item retrying on its own.
+func (s *MCPServer) forEachConcurrently(ctx context.Context, req *mcpsdk.CallToolRequest, n int, fn func(ctx context.Context, i int)) (int, bool) {
+	ctx = linkding.ContextWithRetryBudget(ctx, s.bulkRetryBudget)
+	sem := make(chan struct{}, bulkConcurrency)
+	progress := newProgressReporter(ctx, req, n)
+	started := 0
+
+	var (
+		wg        sync.WaitGroup
+		cancelled atomic.Bool
+	)
+
+dispatch:
+	for i := range n {
+		select {
+		case <-ctx.Done():
+			cancelled.Store(true)
+
+			break dispatch
+		case sem <- struct{}{}:
+		}
+
+		started++
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				progress.increment()
+				<-sem
+				wg.Done()
+			}()
+
+			fn(ctx, i)
+
+			if ctx.Err() != nil {
+				cancelled.Store(true)
+			}
+		}()
+	}
+
+	wg.Wait()
+	progress.stop()
+
+	return started, cancelled.Load()
+}
+
+// recordState sets the resulting bookmark state on every item processed successfully,
+// so clients can act on the state change without refetching the bookmarks
+func recordState(bulkResult *BulkResult, archived *bool, deleted bool) {
+	for i := range bulkResult.Results {
+		if bulkResult.Results[i].Success {
+			bulkResult.Results[i].Archived = archived
+			bulkResult.Results[i].Deleted = deleted
+		}
+	}
+}
+
+// ptr returns a pointer to v
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// formatBulkResult renders a bulk result as a human-readable summary
+func (s *MCPServer) formatBulkResult(action string, bulkResult BulkResult) string {
+	var skipped []BulkItemResult
+
+	for _, r := range bulkResult.Results {
+		if r.Skipped {
+			skipped = append(skipped, r)
+		}
+	}
+
+	notStarted := bulkResult.Skipped - len(skipped)
+	total := len(bulkResult.Results) + notStarted
+	result := fmt.Sprintf("%s %d of %s", action, bulkResult.Succeeded, pluralize(total, "bookmark"))
+
+	if bulkResult.Failed > 0 {
+		result += fmt.Sprintf(" (%d failed):\n\n", bulkResult.Failed)
+
+		for _, r := range bulkResult.Results {
+			if !r.Success && !r.Skipped {
+				result += fmt.Sprintf("• ID %d: %s\n", r.ID, r.Error)
+			}
+		}
+	}
+
+	if len(skipped) > 0 {
+		// The failure list already ends in a newline
+		separator := "\n\n"
+		if bulkResult.Failed > 0 {
+			separator = "\n"
+		}
+
+		result += fmt.Sprintf("%sSkipped %d:\n\n", separator, len(skipped))
+
+		for _, r := range skipped {
+			result += fmt.Sprintf("• ID %d: %s\n", r.ID, r.Reason)
+		}
+	}
+
+	if bulkResult.Cancelled {
+		result += fmt.Sprintf("\n\n%s Operation was cancelled, %d not processed", s.mark(markWarning), notStarted)
+	}
+
+	return result
+}
+
+func (s *MCPServer) handleUpdateBookmarks(ctx context.Context, req *mcpsdk.CallToolRequest, args UpdateBookmarksArgs) (*mcpsdk.CallToolResult, BulkResult, error) {
+	if len(args.IDs) == 0 {
+		return errorResult("At least one bookmark ID is required"), BulkResult{}, nil
+	}
+
+	if tooMany := s.checkBulkLimit(len(args.IDs)); tooMany != nil {
+		return tooMany, BulkResult{}, nil
+	}
+
+	fields := map[string]any{}
+
+	if args.Title != nil {
+		fields["title"] = *args.Title
+	}
+
+	if args.Description != nil {
+		fields["description"] = *args.Description
+	}
+
+	if args.Notes != nil {
+		fields["notes"] = *args.Notes
+	}
+
+	if args.Unread != nil {
+		fields["unread"] = *args.Unread
+	}
+
+	if args.Shared != nil {
+		fields["shared"] = *args.Shared
+	}
+
+	if len(fields) == 0 && len(args.AddTags) == 0 && len(args.RemoveTags) == 0 {
+		return errorResult("No changes specified"), BulkResult{}, nil
+	}
+
+	if disallowed := s.checkVocabulary(args.AddTags); disallowed != nil {
+		return disallowed, BulkResult{}, nil
+	}
+
+	bulkResult := s.runBulk(ctx, req, args.IDs, func(ctx context.Context, id int) error {
+		patch := fields
+
+		// Tag changes are relative to each bookmark's current tags, and
+		// PATCH replaces tag_names wholesale, so merge per bookmark.
+		if len(args.AddTags) > 0 || len(args.RemoveTags) > 0 {
+			bookmark, err := s.linkdingClient.GetBookmark(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			patch = maps.Clone(fields)
+			patch["tag_names"] = mergeTags(bookmark.TagNames, args.AddTags, args.RemoveTags)
+		}
+
+		_, err := s.linkdingClient.PatchBookmark(ctx, id, patch)
+
+		return err
+	})
+
+	return textResult(s.formatBulkResult("Updated", bulkResult)), bulkResult, nil
+}
+
+func (s *MCPServer) handleDeleteBookmarks(ctx context.Context, req *mcpsdk.CallToolRequest, args DeleteBookmarksArgs) (*mcpsdk.CallToolResult, BulkResult, error) {
+	if len(args.IDs) == 0 {
+		return errorResult("At least one bookmark ID is required"), BulkResult{}, nil
+	}
+
+	if tooMany := s.checkBulkLimit(len(args.IDs)); tooMany != nil {
+		return tooMany, BulkResult{}, nil
+	}
+
+	if blocked := confirmDestructive(ctx, req, args.Confirm, describeDeletion(args.IDs)); blocked != nil {
+		return blocked, BulkResult{}, nil
+	}
+
+	bulkResult := s.runBulk(ctx, req, args.IDs, s.linkdingClient.DeleteBookmark)
+	recordState(&bulkResult, nil, true)
+
+	return textResult(s.formatBulkResult(s.mark(markDeleted)+" Deleted", bulkResult)), bulkResult, nil
+}
+
+func (s *MCPServer) handleMarkQueryRead(ctx context.Context, req *mcpsdk.CallToolRequest, args QueryArgs) (*mcpsdk.CallToolResult, BulkResult, error) {
+	if args.Query == "" {
+		return errorResult("Query is required"), BulkResult{}, nil
+	}
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query), linkding.WithUnread(true))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), BulkResult{}, nil
+	}
+
+	if len(bookmarks) == 0 {
+		return textResult("No unread bookmarks match the query"), BulkResult{}, nil
+	}
+
+	if tooMany := s.checkBulkLimit(len(bookmarks)); tooMany != nil {
+		return tooMany, BulkResult{}, nil
+	}
+
+	bulkResult := s.runBulk(ctx, req, bookmarkIDs(bookmarks), func(ctx context.Context, id int) error {
+		_, err := s.linkdingClient.PatchBookmark(ctx, id, map[string]any{"unread": false})
+
+		return err
+	})
+
+	return textResult(s.formatBulkResult("Marked as read", bulkResult)), bulkResult, nil
+}
+
+func (s *MCPServer) handleArchiveQuery(ctx context.Context, req *mcpsdk.CallToolRequest, args QueryArgs) (*mcpsdk.CallToolResult, BulkResult, error) {
+	if args.Query == "" {
+		return errorResult("Query is required"), BulkResult{}, nil
+	}
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), BulkResult{}, nil
+	}
+
+	if len(bookmarks) == 0 {
+		return textResult("No bookmarks match the query"), BulkResult{}, nil
+	}
+
+	if tooMany := s.checkBulkLimit(len(bookmarks)); tooMany != nil {
+		return tooMany, BulkResult{}, nil
+	}
+
+	bulkResult := s.runBulk(ctx, req, bookmarkIDs(bookmarks), s.linkdingClient.ArchiveBookmark)
+	recordState(&bulkResult, ptr(true), false)
+
+	return textResult(s.formatBulkResult(s.mark(markArchived)+" Archived", bulkResult)), bulkResult, nil
+}
+
+// bookmarkIDs returns the IDs of the given bookmarks
+func bookmarkIDs(bookmarks []linkding.Bookmark) []int {
+	ids := make([]int, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		ids[i] = bookmark.ID
+	}
+
+	return ids
+}
+
+// mergeTags returns current with the added tags appended and the removed tags dropped.
+// Linkding tag names are case-insensitive, so tags are compared with Unicode case folding.
+func mergeTags(current, add, remove []string) []string {
+	merged := make([]string, 0, len(current)+len(add))
+
+	for _, tag := range current {
+		if !containsTag(remove, tag) {
+			merged = append(merged, tag)
+		}
+	}
+
+	for _, tag := range add {
+		if !containsTag(merged, tag) && !containsTag(remove, tag) {
+			merged = append(merged, tag)
+		}
+	}
+
+	return merged
+}
+
+// containsTag reports whether tags contains tag, ignoring case
+func containsTag(tags []string, tag string) bool {
+	return slices.ContainsFunc(tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
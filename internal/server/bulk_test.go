@@ -0,0 +1,680 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestUpdateBookmarks(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		status    func(id int) int
+		wantOK    []int
+		wantFail  []int
+		wantTitle string
+		wantTags  []string
+		wantError string
+	}{
+		{
+			name:      "same change for every ID",
+			args:      map[string]any{"ids": []int{1, 2, 3}, "title": "Renamed"},
+			wantOK:    []int{1, 2, 3},
+			wantTitle: "Renamed",
+			wantTags:  []string{"go", "web"},
+		},
+		{
+			name:     "tags merged per bookmark",
+			args:     map[string]any{"ids": []int{1, 2, 3}, "add_tags": []string{"new"}, "remove_tags": []string{"WEB"}},
+			wantOK:   []int{1, 2, 3},
+			wantTags: []string{"go", "new"},
+		},
+		{
+			name:     "partial failure",
+			args:     map[string]any{"ids": []int{1, 2, 3}, "unread": true},
+			status:   func(id int) int { return map[int]int{2: http.StatusInternalServerError}[id] },
+			wantOK:   []int{1, 3},
+			wantFail: []int{2},
+			wantTags: []string{"go", "web"},
+		},
+		{
+			name:     "unknown ID",
+			args:     map[string]any{"ids": []int{1, 99}, "shared": true},
+			wantOK:   []int{1},
+			wantFail: []int{99},
+			wantTags: []string{"go", "web"},
+		},
+		{
+			name:      "no changes",
+			args:      map[string]any{"ids": []int{1}},
+			wantError: "No changes specified",
+		},
+		{
+			name:      "no IDs",
+			args:      map[string]any{"ids": []int{}, "title": "x"},
+			wantError: "At least one bookmark ID is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "A", TagNames: []string{"go", "web"}},
+				linkding.Bookmark{ID: 2, URL: "https://b.example", Title: "B", TagNames: []string{"go", "web"}},
+				linkding.Bookmark{ID: 3, URL: "https://c.example", Title: "C", TagNames: []string{"go", "web"}},
+			)
+
+			if tt.status != nil {
+				fake.fail = func(r *http.Request) int {
+					if r.Method != http.MethodPatch {
+						return 0
+					}
+
+					id, _ := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/bookmarks/"), "/"))
+
+					return tt.status(id)
+				}
+			}
+
+			result := callTool(t, newTestServer(t, fake), "update_bookmarks", tt.args)
+
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+					t.Fatalf("got %q, want error %q", resultText(result), tt.wantError)
+				}
+
+				if patches := fake.received(http.MethodPatch, "/api/bookmarks/"); len(patches) > 0 {
+					t.Errorf("sent %d PATCH requests for an invalid call", len(patches))
+				}
+
+				return
+			}
+
+			bulkResult := structured[BulkResult](t, result)
+			if bulkResult.Succeeded != len(tt.wantOK) || bulkResult.Failed != len(tt.wantFail) {
+				t.Errorf("succeeded %d, failed %d; want %d, %d", bulkResult.Succeeded, bulkResult.Failed, len(tt.wantOK), len(tt.wantFail))
+			}
+
+			for _, r := range bulkResult.Results {
+				want := slices.Contains(tt.wantOK, r.ID)
+				if r.Success != want {
+					t.Errorf("ID %d success = %v, want %v (error %q)", r.ID, r.Success, want, r.Error)
+				}
+			}
+
+			for _, id := range tt.wantOK {
+				bookmark, _ := fake.bookmark(id)
+
+				if tt.wantTitle != "" && bookmark.Title != tt.wantTitle {
+					t.Errorf("ID %d title = %q, want %q", id, bookmark.Title, tt.wantTitle)
+				}
+
+				if !slices.Equal(bookmark.TagNames, tt.wantTags) {
+					t.Errorf("ID %d tags = %v, want %v", id, bookmark.TagNames, tt.wantTags)
+				}
+			}
+
+			if len(tt.wantFail) > 0 && !strings.Contains(resultText(result), "failed") {
+				t.Errorf("output doesn't mention the failures: %q", resultText(result))
+			}
+		})
+	}
+}
+
+func TestForEachConcurrentlyProgress(t *testing.T) {
+	const n = 20
+
+	var (
+		mu       sync.Mutex
+		progress []float64
+	)
+
+	s := newTestServer(t, newFakeLinkding(t))
+
+	session := connect(t, s, &mcpsdk.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcpsdk.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			progress = append(progress, req.Params.Progress)
+		},
+	})
+
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	// SetProgressToken only works on existing metadata
+	params := &mcpsdk.CallToolParams{Meta: mcpsdk.Meta{}, Name: "update_bookmarks", Arguments: map[string]any{"ids": ids, "title": "x"}}
+	params.SetProgressToken("bulk")
+
+	if _, err := session.CallTool(context.Background(), params); err != nil {
+		t.Fatal(err)
+	}
+
+	// Notifications are handled asynchronously by the client
+	deadline := time.Now().Add(time.Second)
+
+	for {
+		mu.Lock()
+		received := len(progress) > 0 && progress[len(progress)-1] == n
+		mu.Unlock()
+
+		if received || time.Now().After(deadline) {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(progress) == 0 || progress[len(progress)-1] != n {
+		t.Fatalf("progress = %v, want the last notification at %d", progress, n)
+	}
+
+	if !slices.IsSorted(progress) {
+		t.Errorf("progress went backwards: %v", progress)
+	}
+}
+
+func TestRunBulkCancellation(t *testing.T) {
+	tests := []struct {
+		name          string
+		cancelAfter   int
+		wantCancelled bool
+	}{
+		{name: "cancelled midway", cancelAfter: 3, wantCancelled: true},
+		{name: "cancelled by the last item", cancelAfter: 50, wantCancelled: true},
+		{name: "not cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, newFakeLinkding(t))
+			ctx, cancel := context.WithCancel(context.Background())
+
+			defer cancel()
+
+			ids := make([]int, 50)
+			for i := range ids {
+				ids[i] = i + 1
+			}
+
+			var (
+				mu   sync.Mutex
+				done int
+			)
+
+			bulkResult := s.runBulk(ctx, nil, ids, func(ctx context.Context, id int) error {
+				mu.Lock()
+				done++
+
+				if done == tt.cancelAfter {
+					cancel()
+				}
+				mu.Unlock()
+
+				return ctx.Err()
+			})
+
+			if bulkResult.Cancelled != tt.wantCancelled {
+				t.Errorf("cancelled = %v, want %v", bulkResult.Cancelled, tt.wantCancelled)
+			}
+
+			if got := len(bulkResult.Results) + bulkResult.Skipped; got != len(ids) {
+				t.Errorf("results + skipped = %d, want %d", got, len(ids))
+			}
+
+			if !tt.wantCancelled && bulkResult.Succeeded != len(ids) {
+				t.Errorf("succeeded = %d, want %d", bulkResult.Succeeded, len(ids))
+			}
+		})
+	}
+}
+
+func TestMarkQueryRead(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantRead  []int
+		wantText  string
+		wantError bool
+	}{
+		{name: "marks the unread matches", query: "#go", wantRead: []int{1, 2}, wantText: "Marked as read 2 of 2 bookmarks"},
+		{name: "no unread matches", query: "#web", wantText: "No unread bookmarks match the query"},
+		{name: "query required", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{ID: 1, URL: "https://a.example", Unread: true, TagNames: []string{"go"}},
+				linkding.Bookmark{ID: 2, URL: "https://b.example", Unread: true, TagNames: []string{"go"}},
+				linkding.Bookmark{ID: 3, URL: "https://c.example", TagNames: []string{"go", "web"}},
+				linkding.Bookmark{ID: 4, URL: "https://d.example", Unread: true},
+			)
+
+			result := callTool(t, newTestServer(t, fake), "mark_query_read", map[string]any{"query": tt.query})
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("got %q, want %q", resultText(result), tt.wantText)
+			}
+
+			var patched []int
+
+			for _, r := range fake.received(http.MethodPatch, "/api/bookmarks/") {
+				if r.Body["unread"] != false || len(r.Body) != 1 {
+					t.Errorf("PATCH %s body = %v, want only unread: false", r.Path, r.Body)
+				}
+
+				id, _ := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.Path, "/api/bookmarks/"), "/"))
+				patched = append(patched, id)
+			}
+
+			slices.Sort(patched)
+
+			if !slices.Equal(patched, tt.wantRead) {
+				t.Errorf("patched %v, want %v", patched, tt.wantRead)
+			}
+		})
+	}
+}
+
+func TestMergeTagsUnicode(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		add     []string
+		remove  []string
+		want    []string
+	}{
+		{name: "emoji tag added once", current: []string{"🚀", "go"}, add: []string{"🚀", "✨"}, want: []string{"🚀", "go", "✨"}},
+		{name: "non-ASCII case folding", current: []string{"Ärger", "ΣΙΣΥΦΟΣ"}, add: []string{"ärger", "σισυφος"}, want: []string{"Ärger", "ΣΙΣΥΦΟΣ"}},
+		{name: "remove ignores case", current: []string{"Café", "日本語"}, remove: []string{"CAFÉ"}, want: []string{"日本語"}},
+		{name: "removal wins over adding", current: []string{"go"}, add: []string{"日本語"}, remove: []string{"日本語"}, want: []string{"go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeTags(tt.current, tt.add, tt.remove); !slices.Equal(got, tt.want) {
+				t.Errorf("mergeTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateBookmarksUnicode(t *testing.T) {
+	fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "Old", TagNames: []string{"Ärger"}})
+
+	result := callTool(t, newTestServer(t, fake), "update_bookmarks", map[string]any{
+		"ids":         []int{1},
+		"title":       "日本語のタイトル 🎉",
+		"add_tags":    []string{"ärger", "🚀"},
+		"remove_tags": []string{},
+	})
+	if result.IsError {
+		t.Fatal(resultText(result))
+	}
+
+	bookmark, _ := fake.bookmark(1)
+	if bookmark.Title != "日本語のタイトル 🎉" || !slices.Equal(bookmark.TagNames, []string{"Ärger", "🚀"}) {
+		t.Errorf("bookmark = %q %v, want the title and tags unchanged by encoding", bookmark.Title, bookmark.TagNames)
+	}
+}
+
+func TestArchiveQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		opts          []Option
+		wantSucceeded int
+		wantFailed    int
+		wantError     string
+	}{
+		{name: "matches over several pages with partial failures", query: "#done", wantSucceeded: 226, wantFailed: 4},
+		{name: "no matches", query: "#nothing"},
+		{name: "more than the bulk limit", query: "#done", opts: []Option{WithMaxBulkItems(100)}, wantError: "more than the limit of 100"},
+		{name: "query required", wantError: "Query is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bookmarks []linkding.Bookmark
+			for i := range 230 {
+				bookmarks = append(bookmarks, linkding.Bookmark{URL: fmt.Sprintf("https://example.com/%d", i), TagNames: []string{"done"}})
+			}
+
+			bookmarks = append(bookmarks, linkding.Bookmark{URL: "https://other.example", TagNames: []string{"todo"}})
+
+			fake := newFakeLinkding(t, bookmarks...)
+			fake.fail = func(r *http.Request) int {
+				id, _ := strconv.Atoi(strings.Split(strings.TrimPrefix(r.URL.Path, "/api/bookmarks/"), "/")[0])
+				if strings.HasSuffix(r.URL.Path, "/archive/") && id%50 == 0 {
+					return http.StatusInternalServerError
+				}
+
+				return 0
+			}
+
+			result := callTool(t, newTestServer(t, fake, tt.opts...), "archive_query", map[string]any{"query": tt.query})
+			archives := fake.received(http.MethodPost, "/api/bookmarks/")
+
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+					t.Errorf("result = %q, want an error containing %q", resultText(result), tt.wantError)
+				}
+
+				if len(archives) > 0 {
+					t.Errorf("archived %d bookmarks despite the error", len(archives))
+				}
+
+				return
+			}
+
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			bulkResult := structured[BulkResult](t, result)
+			if bulkResult.Succeeded != tt.wantSucceeded || bulkResult.Failed != tt.wantFailed {
+				t.Errorf("succeeded %d, failed %d; want %d and %d", bulkResult.Succeeded, bulkResult.Failed, tt.wantSucceeded, tt.wantFailed)
+			}
+
+			if len(archives) != tt.wantSucceeded+tt.wantFailed {
+				t.Errorf("sent %d archive requests, want %d", len(archives), tt.wantSucceeded+tt.wantFailed)
+			}
+
+			if other, _ := fake.bookmark(231); other.IsArchived {
+				t.Error("archived a bookmark that doesn't match the query")
+			}
+
+			if tt.wantSucceeded > 0 && len(fake.received(http.MethodGet, "/api/bookmarks/")) < 3 {
+				t.Error("expected the matches to be paged")
+			}
+		})
+	}
+}
+
+func TestBulkToolsCancelled(t *testing.T) {
+	ids := make([]int, 30)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	tests := []struct {
+		tool string
+		args map[string]any
+	}{
+		{tool: "update_bookmarks", args: map[string]any{"ids": ids, "title": "x"}},
+		{tool: "delete_bookmarks", args: map[string]any{"ids": ids, "confirm": true}},
+		{tool: "mark_query_read", args: map[string]any{"query": "#go"}},
+		{tool: "archive_query", args: map[string]any{"query": "#go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			var bookmarks []linkding.Bookmark
+			for i := range ids {
+				bookmarks = append(bookmarks, linkding.Bookmark{URL: fmt.Sprintf("https://example.com/%d", i), TagNames: []string{"go"}, Unread: true})
+			}
+
+			fake := newFakeLinkding(t, bookmarks...)
+			// Six rounds of bulkConcurrency slow requests outlast the tool timeout
+			fake.fail = func(r *http.Request) int {
+				if r.Method != http.MethodGet {
+					time.Sleep(50 * time.Millisecond)
+				}
+
+				return 0
+			}
+
+			result := callTool(t, newTestServer(t, fake, WithToolTimeout(tt.tool, 120*time.Millisecond)), tt.tool, tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			bulkResult := structured[BulkResult](t, result)
+			if !bulkResult.Cancelled || len(bulkResult.Results) == 0 || bulkResult.Skipped == 0 {
+				t.Errorf("cancelled = %v with %d results and %d skipped, want partial results",
+					bulkResult.Cancelled, len(bulkResult.Results), bulkResult.Skipped)
+			}
+
+			if len(bulkResult.Results)+bulkResult.Skipped != len(ids) {
+				t.Errorf("results + skipped = %d, want %d", len(bulkResult.Results)+bulkResult.Skipped, len(ids))
+			}
+
+			if !strings.Contains(resultText(result), "Operation was cancelled") {
+				t.Errorf("output %q doesn't mention the cancellation", resultText(result))
+			}
+		})
+	}
+}
+
+func TestBulkLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]any
+		refused bool
+	}{
+		{name: "update under the cap", tool: "update_bookmarks", args: map[string]any{"ids": []int{1, 2}, "title": "x"}},
+		{name: "update over the cap", tool: "update_bookmarks", args: map[string]any{"ids": []int{1, 2, 3}, "title": "x"}, refused: true},
+		{name: "delete under the cap", tool: "delete_bookmarks", args: map[string]any{"ids": []int{1}, "confirm": true}},
+		{name: "delete over the cap", tool: "delete_bookmarks", args: map[string]any{"ids": []int{1, 2, 3}, "confirm": true}, refused: true},
+		{name: "archive under the cap", tool: "archive_query", args: map[string]any{"query": "#two"}},
+		{name: "archive over the cap", tool: "archive_query", args: map[string]any{"query": "#go"}, refused: true},
+		{name: "import under the cap", tool: "import_csv", args: map[string]any{"csv": "url\nhttps://x.example\nhttps://y.example"}},
+		{name: "import over the cap", tool: "import_csv", args: map[string]any{"csv": "url\nhttps://x.example\nhttps://y.example\nhttps://z.example"}, refused: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{ID: 1, URL: "https://a.example", TagNames: []string{"go", "two"}},
+				linkding.Bookmark{ID: 2, URL: "https://b.example", TagNames: []string{"go", "two"}},
+				linkding.Bookmark{ID: 3, URL: "https://c.example", TagNames: []string{"go"}},
+			)
+
+			result := callTool(t, newTestServer(t, fake, WithMaxBulkItems(2)), tt.tool, tt.args)
+
+			refused := result.IsError && strings.Contains(resultText(result), "more than the limit of 2 per call")
+			if refused != tt.refused {
+				t.Errorf("refused = %v, want %v: %s", refused, tt.refused, resultText(result))
+			}
+
+			if !tt.refused && result.IsError {
+				t.Errorf("unexpected error: %s", resultText(result))
+			}
+
+			if changes := len(fake.received(http.MethodPatch, "/")) + len(fake.received(http.MethodDelete, "/")) +
+				len(fake.received(http.MethodPost, "/")); tt.refused && changes > 0 {
+				t.Errorf("made %d changes despite the cap", changes)
+			}
+		})
+	}
+}
+
+func TestBulkRetryBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       int
+		budget      int
+		retries     int
+		transient   bool
+		wantPatches int
+		wantOK      int
+	}{
+		{name: "zero budget disables retries", items: 10, budget: 0, retries: 3, wantPatches: 10},
+		{name: "budget caps the retries of all items", items: 10, budget: 3, retries: 3, wantPatches: 13},
+		{name: "transient failures recover within the budget", items: 10, budget: 3, retries: 3, transient: true, wantPatches: 13, wantOK: 3},
+		{name: "per-request limit still applies", items: 2, budget: 20, retries: 1, wantPatches: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				bookmarks []linkding.Bookmark
+				ids       []int
+			)
+
+			for i := range tt.items {
+				bookmarks = append(bookmarks, linkding.Bookmark{ID: i + 1, URL: fmt.Sprintf("https://example.com/%d", i)})
+				ids = append(ids, i+1)
+			}
+
+			fake := newFakeLinkding(t, bookmarks...)
+
+			var (
+				mu    sync.Mutex
+				tried = map[string]bool{}
+			)
+
+			fake.fail = func(r *http.Request) int {
+				if r.Method != http.MethodPatch {
+					return 0
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if tt.transient && tried[r.URL.Path] {
+					return 0
+				}
+
+				tried[r.URL.Path] = true
+
+				return http.StatusServiceUnavailable
+			}
+
+			client := linkding.NewClient(fake.URL, "test-token", linkding.WithRetries(tt.retries))
+			s := newTestServer(t, fake, WithClient(client), WithBulkRetryBudget(tt.budget))
+
+			result := callTool(t, s, "update_bookmarks", map[string]any{"ids": ids, "title": "x"})
+
+			bulkResult := structured[BulkResult](t, result)
+			if bulkResult.Succeeded != tt.wantOK || bulkResult.Failed != tt.items-tt.wantOK {
+				t.Errorf("succeeded %d and failed %d, want %d and %d", bulkResult.Succeeded, bulkResult.Failed, tt.wantOK, tt.items-tt.wantOK)
+			}
+
+			if patches := len(fake.received(http.MethodPatch, "/api/bookmarks/")); patches != tt.wantPatches {
+				t.Errorf("sent %d PATCH requests, want %d", patches, tt.wantPatches)
+			}
+		})
+	}
+}
+
+func TestResultState(t *testing.T) {
+	tests := []struct {
+		tool         string
+		args         map[string]any
+		wantArchived *bool
+		wantDeleted  bool
+	}{
+		{tool: "archive_bookmark", args: map[string]any{"id": 1}, wantArchived: ptr(true)},
+		{tool: "unarchive_bookmark", args: map[string]any{"id": 2}, wantArchived: ptr(false)},
+		{tool: "delete_bookmark", args: map[string]any{"id": 1, "confirm": true}, wantDeleted: true},
+		{tool: "delete_bookmark_by_url", args: map[string]any{"url": "https://a.example", "confirm": true}, wantDeleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{ID: 1, URL: "https://a.example", TagNames: []string{"go"}},
+				linkding.Bookmark{ID: 2, URL: "https://b.example", IsArchived: true},
+			)
+
+			result := callTool(t, newTestServer(t, fake), tt.tool, tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			got := structured[BookmarkResult](t, result)
+			if !equalState(got.Archived, tt.wantArchived) || got.Deleted != tt.wantDeleted {
+				t.Errorf("archived %v, deleted %v; want %v, %v", fmtState(got.Archived), got.Deleted, fmtState(tt.wantArchived), tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestBulkResultState(t *testing.T) {
+	bulk := func(t *testing.T, result *mcpsdk.CallToolResult) []BulkItemResult {
+		return structured[BulkResult](t, result).Results
+	}
+	searchAction := func(t *testing.T, result *mcpsdk.CallToolResult) []BulkItemResult {
+		return structured[SearchActionResult](t, result).Result.Results
+	}
+
+	tests := []struct {
+		tool         string
+		args         map[string]any
+		items        func(t *testing.T, result *mcpsdk.CallToolResult) []BulkItemResult
+		wantArchived *bool
+		wantDeleted  bool
+	}{
+		{tool: "archive_query", args: map[string]any{"query": "#go"}, items: bulk, wantArchived: ptr(true)},
+		{tool: "delete_bookmarks", args: map[string]any{"ids": []int{1, 2, 99}, "confirm": true}, items: bulk, wantDeleted: true},
+		{tool: "search_and_archive", args: map[string]any{"query": "#go", "confirm": true}, items: searchAction, wantArchived: ptr(true)},
+		{tool: "search_and_delete", args: map[string]any{"query": "#go", "confirm": true}, items: searchAction, wantDeleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{ID: 1, URL: "https://a.example", TagNames: []string{"go"}},
+				linkding.Bookmark{ID: 2, URL: "https://b.example", TagNames: []string{"go"}},
+			)
+
+			result := callTool(t, newTestServer(t, fake), tt.tool, tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			items := tt.items(t, result)
+			if len(items) == 0 {
+				t.Fatal("no item results")
+			}
+
+			for _, item := range items {
+				// Failed items keep no state: nothing changed
+				wantArchived, wantDeleted := tt.wantArchived, tt.wantDeleted
+				if !item.Success {
+					wantArchived, wantDeleted = nil, false
+				}
+
+				if !equalState(item.Archived, wantArchived) || item.Deleted != wantDeleted {
+					t.Errorf("item %d: archived %v, deleted %v; want %v, %v", item.ID, fmtState(item.Archived), item.Deleted, fmtState(wantArchived), wantDeleted)
+				}
+			}
+		})
+	}
+}
+
+// equalState compares optional states, which are equal when both are unset
+func equalState(a, b *bool) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// fmtState renders an optional state for test failures
+func fmtState(state *bool) string {
+	if state == nil {
+		return "unset"
+	}
+
+	return strconv.FormatBool(*state)
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// addTool registers a tool with the SDK server and records it for list_capabilities.
+// The input schema is inferred from In the same way the SDK does.
+func addTool[In, Out any](s *MCPServer, mcpServer *mcpsdk.Server, tool *mcpsdk.Tool, handler mcpsdk.ToolHandlerFor[In, Out]) {
+	if tool.InputSchema == nil {
+		schema, err := jsonschema.For[In](&jsonschema.ForOptions{})
+		if err != nil {
+			panic(fmt.Sprintf("tool %q: input schema: %v", tool.Name, err))
+		}
+
+		tool.InputSchema = schema
+	}
+
+	mcpsdk.AddTool(mcpServer, tool, handler)
+
+	s.tools = append(s.tools, tool)
+
+	if s.latencies != nil {
+		s.latencies.register(tool.Name)
+	}
+}
+
+func (s *MCPServer) handleListCapabilities(ctx context.Context, req *mcpsdk.CallToolRequest, args ListCapabilitiesArgs) (*mcpsdk.CallToolResult, CapabilitiesResult, error) {
+	capabilitiesResult := CapabilitiesResult{Tools: make([]ToolSummary, len(s.tools))}
+
+	for i, tool := range s.tools {
+		capabilitiesResult.Tools[i] = summarizeTool(tool)
+	}
+
+	result := fmt.Sprintf("%s available:\n\n", pluralize(len(capabilitiesResult.Tools), "tool"))
+	for _, tool := range capabilitiesResult.Tools {
+		result += fmt.Sprintf("• **%s**: %s\n", tool.Name, tool.Description)
+
+		for _, param := range tool.Parameters {
+			required := ""
+			if param.Required {
+				required = ", required"
+			}
+
+			result += fmt.Sprintf("  - `%s` (%s%s)\n", param.Name, param.Type, required)
+		}
+	}
+
+	return textResult(result), capabilitiesResult, nil
+}
+
+// summarizeTool describes a tool and the top-level properties of its input schema
+func summarizeTool(tool *mcpsdk.Tool) ToolSummary {
+	summary := ToolSummary{
+		Name:        tool.Name,
+		Description: tool.Description,
+		Parameters:  []ParameterSummary{},
+	}
+
+	if tool.InputSchema == nil {
+		return summary
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(tool.InputSchema.Properties)) {
+		property := tool.InputSchema.Properties[name]
+		summary.Parameters = append(summary.Parameters, ParameterSummary{
+			Name:        name,
+			Type:        schemaType(property),
+			Description: property.Description,
+			Required:    slices.Contains(tool.InputSchema.Required, name),
+		})
+	}
+
+	return summary
+}
+
+// schemaType renders the type of a schema, e.g. "string", "array of string" or "null|boolean"
+func schemaType(schema *jsonschema.Schema) string {
+	typ := schema.Type
+	if typ == "" {
+		typ = strings.Join(schema.Types, "|")
+	}
+
+	if schema.Items != nil && strings.Contains(typ, "array") {
+		typ += " of " + schemaType(schema.Items)
+	}
+
+	return typ
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestListCapabilities(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		opts  []Option
+	}{
+		{name: "default", token: "test-token"},
+		{name: "with file tools", token: "test-token", opts: []Option{WithBackupDir(t.TempDir())}},
+		{name: "public mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t)
+			client := linkding.NewClient(fake.URL, tt.token, linkding.WithRetries(0))
+			session := connect(t, NewMCP(fake.URL, tt.token, append([]Option{WithClient(client)}, tt.opts...)...), nil)
+
+			listed, err := session.ListTools(context.Background(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want []string
+			for _, tool := range listed.Tools {
+				want = append(want, tool.Name)
+			}
+
+			result, err := session.CallTool(context.Background(), &mcpsdk.CallToolParams{Name: "list_capabilities", Arguments: map[string]any{}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, tool := range structured[CapabilitiesResult](t, result).Tools {
+				got = append(got, tool.Name)
+			}
+
+			slices.Sort(want)
+			slices.Sort(got)
+
+			if !slices.Equal(got, want) {
+				t.Errorf("list_capabilities = %v, want the registered tools %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSummarizeToolParameters(t *testing.T) {
+	s := newTestServer(t, newFakeLinkding(t))
+
+	var summary ToolSummary
+
+	for _, tool := range s.tools {
+		if tool.Name == "create_bookmark" {
+			summary = summarizeTool(tool)
+		}
+	}
+
+	want := map[string]ParameterSummary{
+		"url":  {Name: "url", Type: "string", Required: true},
+		"tags": {Name: "tags", Type: "array of string"},
+	}
+
+	found := 0
+
+	for _, param := range summary.Parameters {
+		param.Description = ""
+		if expected, ok := want[param.Name]; ok {
+			found++
+
+			if param != expected {
+				t.Errorf("parameter %s = %+v, want %+v", param.Name, param, expected)
+			}
+		}
+	}
+
+	if found != len(want) {
+		t.Errorf("parameters = %+v, want them to include %v", summary.Parameters, want)
+	}
+
+	if !slices.IsSortedFunc(summary.Parameters, func(a, b ParameterSummary) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Errorf("parameters aren't sorted by name: %+v", summary.Parameters)
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// trackingParams lists query parameters that only identify the referring
+// campaign or click, besides the utm_* family
+var trackingParams = []string{
+	"fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid", "yclid",
+	"igshid", "mc_cid", "mc_eid", "_hsenc", "_hsmi",
+}
+
+func (s *MCPServer) handleCleanURLs(ctx context.Context, req *mcpsdk.CallToolRequest, args CleanURLsArgs) (*mcpsdk.CallToolResult, CleanURLsResult, error) {
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), CleanURLsResult{}, nil
+	}
+
+	cleanResult := CleanURLsResult{Checked: len(bookmarks), Bookmarks: findTrackedURLs(bookmarks)}
+
+	if len(cleanResult.Bookmarks) == 0 {
+		return textResult(fmt.Sprintf("None of %s has tracking parameters in its URL", pluralize(len(bookmarks), "bookmark"))), cleanResult, nil
+	}
+
+	result := fmt.Sprintf("Found %s with tracking parameters among %d:\n\n", pluralize(len(cleanResult.Bookmarks), "bookmark"), len(bookmarks))
+	for _, tracked := range cleanResult.Bookmarks {
+		result += fmt.Sprintf("• **%s** (ID %d)\n  URL: %s\n  Clean: %s\n", tracked.Title, tracked.ID, tracked.URL, tracked.CleanURL)
+
+		if tracked.DuplicateOf != 0 {
+			result += fmt.Sprintf("  %s Already bookmarked as ID %d, left unchanged\n", s.mark(markWarning), tracked.DuplicateOf)
+		}
+
+		result += "\n"
+	}
+
+	// Changing the URL changes which page the bookmark identifies, so only do
+	// it when explicitly asked to
+	if !args.Confirm {
+		return textResult(result + "Call the tool again with confirm: true to update them to the clean URLs."), cleanResult, nil
+	}
+
+	pending := make(map[int]*TrackedURL, len(cleanResult.Bookmarks))
+
+	var ids []int
+
+	for i, tracked := range cleanResult.Bookmarks {
+		if tracked.DuplicateOf == 0 {
+			pending[tracked.ID] = &cleanResult.Bookmarks[i]
+			ids = append(ids, tracked.ID)
+		}
+	}
+
+	if tooMany := s.checkBulkLimit(len(ids)); tooMany != nil {
+		return tooMany, CleanURLsResult{}, nil
+	}
+
+	// The clean URL may belong to a bookmark outside the query, such as an
+	// archived one, or one added since the search, so ask Linkding right
+	// before each update
+	updated := s.runBulk(ctx, req, ids, func(ctx context.Context, id int) error {
+		tracked := pending[id]
+
+		check, err := s.linkdingClient.CheckBookmark(ctx, tracked.CleanURL)
+		if err != nil {
+			return fmt.Errorf("failed to check the clean URL: %w", err)
+		}
+
+		if check.Bookmark != nil && check.Bookmark.ID != id {
+			tracked.DuplicateOf = check.Bookmark.ID
+
+			return &skipItemError{reason: fmt.Sprintf("already bookmarked as ID %d, left unchanged", check.Bookmark.ID)}
+		}
+
+		_, err = s.linkdingClient.PatchBookmark(ctx, id, map[string]any{"url": tracked.CleanURL})
+
+		return err
+	})
+
+	cleanResult.Updated = &updated
+	result += s.formatBulkResult("Cleaned the URL of", updated)
+
+	return textResult(result), cleanResult, nil
+}
+
+// findTrackedURLs returns the bookmarks whose URL has tracking parameters,
+// with the clean URL. A bookmark whose clean URL is already bookmarked, or
+// is the clean URL of an earlier one, is marked as a duplicate of that one.
+func findTrackedURLs(bookmarks []linkding.Bookmark) []TrackedURL {
+	bookmarked := make(map[string]int, len(bookmarks))
+
+	for _, bookmark := range bookmarks {
+		if _, removed := stripTrackingParams(bookmark.URL); len(removed) == 0 {
+			bookmarked[normalizeURL(bookmark.URL)] = bookmark.ID
+		}
+	}
+
+	tracked := make([]TrackedURL, 0)
+
+	for _, bookmark := range bookmarks {
+		cleanURL, removed := stripTrackingParams(bookmark.URL)
+		if len(removed) == 0 {
+			continue
+		}
+
+		trackedURL := TrackedURL{
+			ID:       bookmark.ID,
+			Title:    bookmark.Title,
+			URL:      bookmark.URL,
+			CleanURL: cleanURL,
+			Removed:  removed,
+		}
+
+		key := normalizeURL(cleanURL)
+		if id, ok := bookmarked[key]; ok {
+			trackedURL.DuplicateOf = id
+		} else {
+			bookmarked[key] = bookmark.ID
+		}
+
+		tracked = append(tracked, trackedURL)
+	}
+
+	return tracked
+}
+
+// stripTrackingParams removes tracking parameters (utm_*, fbclid, gclid and
+// the like) from a URL, returning the clean URL and the removed parameter
+// names. The remaining parameters keep their order and encoding; URLs that
+// don't parse are returned unchanged.
+func stripTrackingParams(rawURL string) (string, []string) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return rawURL, nil
+	}
+
+	var (
+		kept    []string
+		removed []string
+	)
+
+	for _, param := range strings.Split(parsed.RawQuery, "&") {
+		name, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+
+		if isTrackingParam(name) {
+			removed = append(removed, name)
+		} else {
+			kept = append(kept, param)
+		}
+	}
+
+	if len(removed) == 0 {
+		return rawURL, nil
+	}
+
+	parsed.RawQuery = strings.Join(kept, "&")
+	parsed.ForceQuery = false
+
+	return parsed.String(), removed
+}
+
+// isTrackingParam reports whether a query parameter name is a known tracking parameter
+func isTrackingParam(name string) bool {
+	name = strings.ToLower(name)
+
+	return strings.HasPrefix(name, "utm_") || slices.Contains(trackingParams, name)
+}
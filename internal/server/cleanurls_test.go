@@ -0,0 +1,159 @@
+package server
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestStripTrackingParams(t *testing.T) {
+	tests := []struct {
+		url         string
+		want        string
+		wantRemoved []string
+	}{
+		{url: "https://a.example/post", want: "https://a.example/post"},
+		{url: "https://a.example/?utm_source=x&utm_medium=y", want: "https://a.example/", wantRemoved: []string{"utm_source", "utm_medium"}},
+		{url: "https://a.example/?id=1&fbclid=abc&b=%2F", want: "https://a.example/?id=1&b=%2F", wantRemoved: []string{"fbclid"}},
+		{url: "https://a.example/?UTM_Campaign=z#top", want: "https://a.example/#top", wantRemoved: []string{"UTM_Campaign"}},
+		{url: " https://a.example/?gclid=1 ", want: "https://a.example/", wantRemoved: []string{"gclid"}},
+		{url: "https://a.example/?page=2", want: "https://a.example/?page=2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, removed := stripTrackingParams(tt.url)
+			if got != tt.want || !slices.Equal(removed, tt.wantRemoved) {
+				t.Errorf("stripTrackingParams(%q) = %q, %v; want %q, %v", tt.url, got, removed, tt.want, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestCleanURLs(t *testing.T) {
+	bookmarks := []linkding.Bookmark{
+		{ID: 1, URL: "https://a.example/post", TagNames: []string{"archive"}},
+		{ID: 2, URL: "https://a.example/post?utm_source=feed", TagNames: []string{"news"}},
+		{ID: 3, URL: "https://b.example/?fbclid=abc", TagNames: []string{"news"}},
+		{ID: 4, URL: "https://b.example/", TagNames: []string{"news"}},
+		{ID: 5, URL: "https://c.example/?gclid=1&page=2", TagNames: []string{"news"}},
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		fail           func(r *http.Request) int
+		wantTracked    []int
+		wantDuplicates map[int]int
+		wantPatched    map[string]string
+		wantUpdated    *BulkResult
+		wantText       []string
+		notText        []string
+	}{
+		{
+			name:           "preview",
+			args:           map[string]any{},
+			wantTracked:    []int{5, 3, 2},
+			wantDuplicates: map[int]int{3: 4, 2: 1},
+			wantText:       []string{"Found 3 bookmarks with tracking parameters among 5", "confirm: true"},
+		},
+		{
+			name:           "confirm skips duplicates found in the search",
+			args:           map[string]any{"confirm": true},
+			wantTracked:    []int{5, 3, 2},
+			wantDuplicates: map[int]int{3: 4, 2: 1},
+			wantPatched:    map[string]string{"/api/bookmarks/5/": "https://c.example/?page=2"},
+			wantUpdated:    &BulkResult{Succeeded: 1},
+			wantText:       []string{"Cleaned the URL of 1 of 1 bookmark"},
+		},
+		{
+			name:           "confirm skips duplicates outside the query",
+			args:           map[string]any{"query": "#news", "confirm": true},
+			wantTracked:    []int{5, 3, 2},
+			wantDuplicates: map[int]int{3: 4, 2: 1},
+			wantPatched:    map[string]string{"/api/bookmarks/5/": "https://c.example/?page=2"},
+			wantUpdated:    &BulkResult{Succeeded: 1, Skipped: 1},
+			wantText:       []string{"Cleaned the URL of 1 of 2 bookmarks", "Skipped 1", "ID 2: already bookmarked as ID 1"},
+		},
+		{
+			name: "failures and skips listed apart",
+			args: map[string]any{"query": "#news", "confirm": true},
+			fail: func(r *http.Request) int {
+				if r.Method == http.MethodPatch {
+					return http.StatusInternalServerError
+				}
+
+				return 0
+			},
+			wantTracked:    []int{5, 3, 2},
+			wantDuplicates: map[int]int{3: 4, 2: 1},
+			wantPatched:    map[string]string{"/api/bookmarks/5/": "https://c.example/?page=2"},
+			wantUpdated:    &BulkResult{Failed: 1, Skipped: 1},
+			wantText:       []string{"Cleaned the URL of 0 of 2 bookmarks (1 failed):\n\n• ID 5: ", "\n\nSkipped 1:\n\n• ID 2: already bookmarked as ID 1"},
+			notText:        []string{"• ID 2: \n", "\n\n\nSkipped"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, bookmarks...)
+			if tt.fail != nil {
+				fake.fail = tt.fail
+			}
+
+			result := callTool(t, newTestServer(t, fake), "clean_urls", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			cleanResult := structured[CleanURLsResult](t, result)
+
+			var tracked []int
+
+			duplicates := map[int]int{}
+
+			for _, bookmark := range cleanResult.Bookmarks {
+				tracked = append(tracked, bookmark.ID)
+
+				if bookmark.DuplicateOf != 0 {
+					duplicates[bookmark.ID] = bookmark.DuplicateOf
+				}
+			}
+
+			if !slices.Equal(tracked, tt.wantTracked) || !maps.Equal(duplicates, tt.wantDuplicates) {
+				t.Errorf("tracked %v, duplicates %v; want %v, %v", tracked, duplicates, tt.wantTracked, tt.wantDuplicates)
+			}
+
+			patched := map[string]string{}
+			for _, r := range fake.received("PATCH", "/api/bookmarks/") {
+				url, _ := r.Body["url"].(string)
+				patched[r.Path] = url
+			}
+
+			if !maps.Equal(patched, tt.wantPatched) {
+				t.Errorf("patched %v, want %v", patched, tt.wantPatched)
+			}
+
+			if updated := cleanResult.Updated; (updated == nil) != (tt.wantUpdated == nil) ||
+				updated != nil && (updated.Succeeded != tt.wantUpdated.Succeeded || updated.Failed != tt.wantUpdated.Failed || updated.Skipped != tt.wantUpdated.Skipped) {
+				t.Errorf("updated = %+v, want %+v", updated, tt.wantUpdated)
+			}
+
+			for _, want := range tt.wantText {
+				if !strings.Contains(resultText(result), want) {
+					t.Errorf("output %q doesn't contain %q", resultText(result), want)
+				}
+			}
+
+			for _, unwanted := range tt.notText {
+				if strings.Contains(resultText(result), unwanted) {
+					t.Errorf("output %q contains %q", resultText(result), unwanted)
+				}
+			}
+		})
+	}
+}
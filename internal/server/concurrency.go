@@ -0,0 +1,26 @@
+package server
+
+import "net/http"
+
+// limitConcurrency rejects requests with 503 Service Unavailable while limit
+// requests are already being served. Rejected clients are asked to retry
+// shortly, so a burst of agents is admitted again as soon as capacity frees
+// up. Long-lived event streams hold their slot until they end.
+func limitConcurrency(next http.Handler, limit int) http.Handler {
+	slots := make(chan struct{}, limit)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "too many concurrent requests", http.StatusServiceUnavailable)
+
+			return
+		}
+
+		defer func() { <-slots }()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestLimitConcurrency(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		// held requests are kept in flight while one more is sent
+		held       int
+		wantStatus int
+	}{
+		{name: "below the limit", limit: 2, held: 1, wantStatus: http.StatusOK},
+		{name: "at the limit", limit: 2, held: 2, wantStatus: http.StatusServiceUnavailable},
+		{name: "limit of one", limit: 1, held: 1, wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entered := make(chan struct{})
+			release := make(chan struct{})
+
+			handler := limitConcurrency(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/hold" {
+					entered <- struct{}{}
+					<-release
+				}
+			}), tt.limit)
+
+			srv := httptest.NewServer(handler)
+			t.Cleanup(srv.Close)
+
+			var wg sync.WaitGroup
+			for range tt.held {
+				wg.Go(func() {
+					resp, err := http.Get(srv.URL + "/hold")
+					if err != nil {
+						t.Error(err)
+						return
+					}
+
+					resp.Body.Close()
+				})
+				<-entered
+			}
+
+			resp, err := http.Get(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			if tt.wantStatus == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") == "" {
+				t.Error("rejected response has no Retry-After header")
+			}
+
+			// Once the held requests finish, their slots are free again
+			close(release)
+			wg.Wait()
+
+			resp, err = http.Get(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("status after release = %d, want %d", resp.StatusCode, http.StatusOK)
+			}
+		})
+	}
+}
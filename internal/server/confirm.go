@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// confirmDestructive decides whether a destructive tool call may proceed.
+//
+// When the client supports elicitation the user is asked directly, so an
+// over-eager agent can't approve the action on the user's behalf. Otherwise
+// the agent must pass an explicit confirm: true argument.
+// It returns a non-nil tool result explaining why the action was not performed.
+func confirmDestructive(ctx context.Context, req *mcpsdk.CallToolRequest, confirm bool, message string) *mcpsdk.CallToolResult {
+	if !supportsElicitation(req) {
+		if confirm {
+			return nil
+		}
+
+		return errorResult("%s\n\nThis action cannot be undone. Call the tool again with confirm: true to proceed.", message)
+	}
+
+	elicitResult, err := req.Session.Elicit(ctx, &mcpsdk.ElicitParams{
+		Message: message,
+		RequestedSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"confirm": {
+					Type:        "boolean",
+					Description: "Confirm that this action should be performed",
+				},
+			},
+			Required: []string{"confirm"},
+		},
+	})
+	if err != nil {
+		return errorResult("Failed to request confirmation: %v", err)
+	}
+
+	if elicitResult.Action != "accept" {
+		return errorResult("User did not confirm (%s), nothing was changed", elicitResult.Action)
+	}
+
+	if confirmed, _ := elicitResult.Content["confirm"].(bool); !confirmed {
+		return errorResult("Action not confirmed by user, nothing was changed")
+	}
+
+	return nil
+}
+
+// supportsElicitation reports whether the calling client declared the elicitation capability
+func supportsElicitation(req *mcpsdk.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+
+	params := req.Session.InitializeParams()
+
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// describeDeletion renders the confirmation prompt for deleting bookmarks
+func describeDeletion(ids []int) string {
+	if len(ids) == 1 {
+		return fmt.Sprintf("Permanently delete bookmark %d?", ids[0])
+	}
+
+	return fmt.Sprintf("Permanently delete %s (IDs %v)?", pluralize(len(ids), "bookmark"), ids)
+}
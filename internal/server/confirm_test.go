@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestDeleteBookmarksConfirmation(t *testing.T) {
+	tests := []struct {
+		name        string
+		confirm     bool
+		elicitation *mcpsdk.ElicitResult
+		wantDeleted bool
+		wantText    string
+	}{
+		{name: "no confirm without elicitation", wantText: "confirm: true"},
+		{name: "confirm argument", confirm: true, wantDeleted: true},
+		{
+			name:        "user accepts",
+			elicitation: &mcpsdk.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}},
+			wantDeleted: true,
+		},
+		{
+			name:        "user declines",
+			elicitation: &mcpsdk.ElicitResult{Action: "decline"},
+			wantText:    "User did not confirm (decline)",
+		},
+		{
+			name:        "user accepts without confirming",
+			elicitation: &mcpsdk.ElicitResult{Action: "accept", Content: map[string]any{"confirm": false}},
+			wantText:    "not confirmed",
+		},
+		{
+			// The agent can't approve on the user's behalf when the user can be asked
+			name:        "confirm argument ignored with elicitation",
+			confirm:     true,
+			elicitation: &mcpsdk.ElicitResult{Action: "cancel"},
+			wantText:    "User did not confirm (cancel)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{ID: 1, URL: "https://a.example"},
+				linkding.Bookmark{ID: 2, URL: "https://b.example"},
+			)
+
+			var opts *mcpsdk.ClientOptions
+			if tt.elicitation != nil {
+				opts = &mcpsdk.ClientOptions{
+					ElicitationHandler: func(context.Context, *mcpsdk.ElicitRequest) (*mcpsdk.ElicitResult, error) {
+						return tt.elicitation, nil
+					},
+				}
+			}
+
+			session := connect(t, newTestServer(t, fake), opts)
+
+			result, err := session.CallTool(context.Background(), &mcpsdk.CallToolParams{
+				Name:      "delete_bookmarks",
+				Arguments: map[string]any{"ids": []int{1, 2}, "confirm": tt.confirm},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			deletes := fake.received(http.MethodDelete, "/api/bookmarks/")
+
+			if tt.wantDeleted {
+				if result.IsError || len(deletes) != 2 || fake.count() != 0 {
+					t.Fatalf("got %q with %d deletes, want both bookmarks deleted", resultText(result), len(deletes))
+				}
+
+				return
+			}
+
+			if !result.IsError || !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("got %q, want an error containing %q", resultText(result), tt.wantText)
+			}
+
+			if len(deletes) > 0 {
+				t.Errorf("sent %d DELETE requests without confirmation", len(deletes))
+			}
+		})
+	}
+}
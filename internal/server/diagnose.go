@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *MCPServer) handleDiagnose(ctx context.Context, req *mcpsdk.CallToolRequest, args DiagnoseArgs) (*mcpsdk.CallToolResult, DiagnosticReport, error) {
+	report := DiagnosticReport{Healthy: true}
+
+	addCheck := func(name string, err error, detail string) bool {
+		check := DiagnosticCheck{Name: name, OK: err == nil, Detail: detail}
+
+		if err != nil {
+			check.Detail = err.Error()
+			report.Healthy = false
+		}
+
+		report.Checks = append(report.Checks, check)
+
+		return err == nil
+	}
+
+	health, err := s.linkdingClient.Health(ctx)
+	if !addCheck("reachable", err, "Linkding responded to the health check") {
+		return s.diagnosticResult(report), report, nil
+	}
+
+	report.Version = health.Version
+
+	bookmarks, err := s.linkdingClient.GetBookmarks(ctx, 1, 0, "")
+	if err != nil {
+		var apiErr *linkding.APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+			err = fmt.Errorf("API token was rejected (status %d), check LINKDING_API_TOKEN", apiErr.StatusCode)
+		}
+	}
+
+	if !addCheck("authenticated", err, "API token is valid") {
+		return s.diagnosticResult(report), report, nil
+	}
+
+	report.Features = map[string]bool{}
+
+	bundles, err := s.linkdingClient.SupportsEndpoint(ctx, "/api/bundles/")
+	if addCheck("bundles", err, "") {
+		report.Features["bundles"] = bundles
+	}
+
+	// Assets are per bookmark, so support can only be probed when one exists.
+	if len(bookmarks.Results) > 0 {
+		assets, err := s.linkdingClient.SupportsEndpoint(ctx, fmt.Sprintf("/api/bookmarks/%d/assets/", bookmarks.Results[0].ID))
+		if addCheck("assets", err, "") {
+			report.Features["assets"] = assets
+		}
+	}
+
+	return s.diagnosticResult(report), report, nil
+}
+
+// diagnosticResult renders a diagnostic report as a human-readable checklist
+func (s *MCPServer) diagnosticResult(report DiagnosticReport) *mcpsdk.CallToolResult {
+	result := s.mark(markSuccess) + " Linkding setup looks healthy\n\n"
+	if !report.Healthy {
+		result = s.mark(markFailure) + " Linkding setup has problems\n\n"
+	}
+
+	if report.Version != "" {
+		result += fmt.Sprintf("Version: %s\n\n", report.Version)
+	}
+
+	for _, check := range report.Checks {
+		mark := s.mark(markSuccess)
+		if !check.OK {
+			mark = s.mark(markFailure)
+		}
+
+		result += fmt.Sprintf("%s %s", mark, check.Name)
+
+		if check.Detail != "" {
+			result += ": " + check.Detail
+		}
+
+		result += "\n"
+	}
+
+	for _, feature := range slices.Sorted(maps.Keys(report.Features)) {
+		result += fmt.Sprintf("• Feature %s: %t\n", feature, report.Features[feature])
+	}
+
+	toolResult := textResult(result)
+	toolResult.IsError = !report.Healthy
+
+	return toolResult
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name         string
+		bookmarks    []linkding.Bookmark
+		fail         func(r *http.Request) int
+		unreachable  bool
+		wantHealthy  bool
+		wantChecks   []string
+		wantFailed   string
+		wantDetail   string
+		wantFeatures map[string]bool
+	}{
+		{
+			name:         "healthy",
+			bookmarks:    []linkding.Bookmark{{URL: "https://a.example"}},
+			wantHealthy:  true,
+			wantChecks:   []string{"reachable", "authenticated", "bundles", "assets"},
+			wantFeatures: map[string]bool{"bundles": false, "assets": false},
+		},
+		{
+			name:         "empty library skips the assets probe",
+			wantHealthy:  true,
+			wantChecks:   []string{"reachable", "authenticated", "bundles"},
+			wantFeatures: map[string]bool{"bundles": false},
+		},
+		{
+			name:        "unreachable",
+			unreachable: true,
+			wantChecks:  []string{"reachable"},
+			wantFailed:  "reachable",
+		},
+		{
+			name: "health check failing",
+			fail: func(r *http.Request) int {
+				if r.URL.Path == "/health" {
+					return http.StatusBadGateway
+				}
+
+				return 0
+			},
+			wantChecks: []string{"reachable"},
+			wantFailed: "reachable",
+		},
+		{
+			name: "token rejected",
+			fail: func(r *http.Request) int {
+				if strings.HasPrefix(r.URL.Path, "/api/") {
+					return http.StatusUnauthorized
+				}
+
+				return 0
+			},
+			wantChecks: []string{"reachable", "authenticated"},
+			wantFailed: "authenticated",
+			wantDetail: "check LINKDING_API_TOKEN",
+		},
+		{
+			name: "feature probe failing",
+			fail: func(r *http.Request) int {
+				if strings.HasPrefix(r.URL.Path, "/api/bundles/") {
+					return http.StatusInternalServerError
+				}
+
+				return 0
+			},
+			wantChecks:   []string{"reachable", "authenticated", "bundles"},
+			wantFailed:   "bundles",
+			wantFeatures: map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, tt.bookmarks...)
+			fake.fail = tt.fail
+
+			s := newTestServer(t, fake)
+			if tt.unreachable {
+				fake.Close()
+			}
+
+			result := callTool(t, s, "diagnose", map[string]any{})
+			report := structured[DiagnosticReport](t, result)
+
+			var names []string
+			for _, check := range report.Checks {
+				names = append(names, check.Name)
+
+				if check.OK == (check.Name == tt.wantFailed) {
+					t.Errorf("check %s ok = %v, detail %q", check.Name, check.OK, check.Detail)
+				}
+
+				if check.Name == tt.wantFailed && !strings.Contains(check.Detail, tt.wantDetail) {
+					t.Errorf("check %s detail = %q, want it to contain %q", check.Name, check.Detail, tt.wantDetail)
+				}
+			}
+
+			if report.Healthy != tt.wantHealthy || !slices.Equal(names, tt.wantChecks) {
+				t.Errorf("healthy = %v with checks %v, want %v with %v", report.Healthy, names, tt.wantHealthy, tt.wantChecks)
+			}
+
+			if tt.wantHealthy && report.Version != "1.41.0" {
+				t.Errorf("version = %q, want 1.41.0", report.Version)
+			}
+
+			if len(report.Features) != len(tt.wantFeatures) {
+				t.Errorf("features = %v, want %v", report.Features, tt.wantFeatures)
+			}
+
+			for feature, want := range tt.wantFeatures {
+				if got, ok := report.Features[feature]; !ok || got != want {
+					t.Errorf("feature %s = %v, want %v", feature, got, want)
+				}
+			}
+		})
+	}
+}
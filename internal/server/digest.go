@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultDigestHours = 24
+	maxDigestHours     = 24 * 31
+	// digestDescriptionLength caps descriptions in the digest, in characters
+	digestDescriptionLength = 200
+	// untaggedGroup is the digest heading of bookmarks without tags
+	untaggedGroup = "Untagged"
+)
+
+func (s *MCPServer) handleDailyDigest(ctx context.Context, req *mcpsdk.CallToolRequest, args DailyDigestArgs) (*mcpsdk.CallToolResult, DigestResult, error) {
+	hours := clamp(args.Hours, defaultDigestHours, maxDigestHours)
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(""))
+	if err != nil {
+		return errorResult("Failed to get bookmarks: %v", err), DigestResult{}, nil
+	}
+
+	recent := filterBookmarks(bookmarks, func(b linkding.Bookmark) bool {
+		return !b.DateAdded.Before(since)
+	})
+
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].DateAdded.After(recent[j].DateAdded)
+	})
+
+	digestResult := DigestResult{
+		Hours:  hours,
+		Total:  len(recent),
+		Groups: groupByTag(recent),
+	}
+
+	return textResult(renderDigest(digestResult)), digestResult, nil
+}
+
+// groupByTag groups bookmarks under each of their tags, sorted by tag name,
+// with untagged bookmarks last. A bookmark with several tags appears in each group.
+func groupByTag(bookmarks []linkding.Bookmark) []DigestGroup {
+	groups := map[string][]linkding.Bookmark{}
+	// Linkding tags are case-insensitive; groups are headed by the first spelling seen
+	names := map[string]string{}
+
+	var untagged []linkding.Bookmark
+
+	for _, bookmark := range bookmarks {
+		if len(bookmark.TagNames) == 0 {
+			untagged = append(untagged, bookmark)
+
+			continue
+		}
+
+		for _, tag := range bookmark.TagNames {
+			key := strings.ToLower(tag)
+			if _, ok := names[key]; !ok {
+				names[key] = tag
+			}
+
+			groups[key] = append(groups[key], bookmark)
+		}
+	}
+
+	tags := make([]string, 0, len(groups))
+	for tag := range groups {
+		tags = append(tags, tag)
+	}
+
+	slices.Sort(tags)
+
+	digestGroups := make([]DigestGroup, 0, len(tags)+1)
+	for _, tag := range tags {
+		digestGroups = append(digestGroups, DigestGroup{Tag: names[tag], Bookmarks: summarizeBookmarks(groups[tag]).Bookmarks})
+	}
+
+	if len(untagged) > 0 {
+		digestGroups = append(digestGroups, DigestGroup{Tag: untaggedGroup, Bookmarks: summarizeBookmarks(untagged).Bookmarks})
+	}
+
+	return digestGroups
+}
+
+// renderDigest renders a digest as markdown suitable for pasting into a note or email
+func renderDigest(d DigestResult) string {
+	result := fmt.Sprintf("# Bookmarks from the last %s\n\n", pluralize(d.Hours, "hour"))
+
+	if d.Total == 0 {
+		return result + "No new bookmarks.\n"
+	}
+
+	result += fmt.Sprintf("%s added.\n", pluralize(d.Total, "new bookmark"))
+
+	for _, group := range d.Groups {
+		heading := "#" + group.Tag
+		if group.Tag == untaggedGroup {
+			heading = untaggedGroup
+		}
+
+		result += fmt.Sprintf("\n## %s\n\n", heading)
+
+		for _, bookmark := range group.Bookmarks {
+			title := bookmark.Title
+			if title == "" {
+				title = bookmark.URL
+			}
+
+			result += fmt.Sprintf("- [%s](%s)", title, bookmark.URL)
+
+			if bookmark.Description != "" {
+				result += " — " + shorten(bookmark.Description, digestDescriptionLength)
+			}
+
+			result += "\n"
+		}
+	}
+
+	return result
+}
+
+// shorten truncates text to at most n characters on a word boundary, appending "…"
+func shorten(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+
+	cut := string(runes[:n])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+
+	return cut + "…"
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestDailyDigest(t *testing.T) {
+	hoursAgo := func(h int) time.Time { return time.Now().Add(-time.Duration(h) * time.Hour) }
+
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "Go tips", Description: "Short tips", TagNames: []string{"go"}, DateAdded: hoursAgo(2)},
+		linkding.Bookmark{ID: 2, URL: "https://b.example", Title: "Web and Go", TagNames: []string{"Go", "web"}, DateAdded: hoursAgo(5)},
+		linkding.Bookmark{ID: 3, URL: "https://c.example", DateAdded: hoursAgo(10)},
+		linkding.Bookmark{ID: 4, URL: "https://d.example", Title: "Long", Description: strings.Repeat("lorem ipsum ", 40), TagNames: []string{"web"}, DateAdded: hoursAgo(30)},
+		linkding.Bookmark{ID: 5, URL: "https://e.example", Title: "Old", TagNames: []string{"go"}, DateAdded: hoursAgo(24 * 40)},
+	)
+
+	tests := []struct {
+		name       string
+		args       map[string]any
+		wantHours  int
+		wantTotal  int
+		wantGroups map[string][]int
+		wantText   []string
+	}{
+		{
+			name:       "default window grouped by tag",
+			args:       map[string]any{},
+			wantHours:  24,
+			wantTotal:  3,
+			wantGroups: map[string][]int{"go": {1, 2}, "web": {2}, "Untagged": {3}},
+			wantText:   []string{"# Bookmarks from the last 24 hours", "## #go\n\n- [Go tips](https://a.example) — Short tips\n- [Web and Go](https://b.example)\n", "## Untagged\n\n- [https://c.example](https://c.example)\n"},
+		},
+		{
+			name:       "longer window with shortened descriptions",
+			args:       map[string]any{"hours": 48},
+			wantHours:  48,
+			wantTotal:  4,
+			wantGroups: map[string][]int{"go": {1, 2}, "web": {2, 4}, "Untagged": {3}},
+			wantText:   []string{"- [Long](https://d.example) — lorem ipsum", "…\n"},
+		},
+		{
+			name:       "nothing recent",
+			args:       map[string]any{"hours": 1},
+			wantHours:  1,
+			wantGroups: map[string][]int{},
+			wantText:   []string{"No new bookmarks."},
+		},
+		{
+			name:       "window capped",
+			args:       map[string]any{"hours": 100000},
+			wantHours:  maxDigestHours,
+			wantTotal:  4,
+			wantGroups: map[string][]int{"go": {1, 2}, "web": {2, 4}, "Untagged": {3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "daily_digest", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			digest := structured[DigestResult](t, result)
+			if digest.Hours != tt.wantHours || digest.Total != tt.wantTotal {
+				t.Errorf("got %d bookmarks in %d hours, want %d in %d", digest.Total, digest.Hours, tt.wantTotal, tt.wantHours)
+			}
+
+			groups := map[string][]int{}
+
+			for _, group := range digest.Groups {
+				for _, bookmark := range group.Bookmarks {
+					groups[group.Tag] = append(groups[group.Tag], bookmark.ID)
+				}
+			}
+
+			if len(groups) != len(tt.wantGroups) {
+				t.Errorf("groups = %v, want %v", groups, tt.wantGroups)
+			}
+
+			for tag, ids := range tt.wantGroups {
+				if !slices.Equal(groups[tag], ids) {
+					t.Errorf("group %s = %v, want %v", tag, groups[tag], ids)
+				}
+			}
+
+			for _, want := range tt.wantText {
+				if !strings.Contains(resultText(result), want) {
+					t.Errorf("output %q doesn't contain %q", resultText(result), want)
+				}
+			}
+		})
+	}
+}
+
+func TestShorten(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want string
+	}{
+		{text: "short", n: 10, want: "short"},
+		{text: "  spaced\n  out  ", n: 20, want: "spaced out"},
+		{text: "cut on a word boundary", n: 12, want: "cut on a…"},
+		{text: "ünïcödé wörds", n: 9, want: "ünïcödé…"},
+		{text: "unbroken", n: 4, want: "unbr…"},
+	}
+
+	for _, tt := range tests {
+		if got := shorten(tt.text, tt.n); got != tt.want {
+			t.Errorf("shorten(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+}
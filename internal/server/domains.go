@@ -0,0 +1,116 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *MCPServer) handleListDomains(ctx context.Context, req *mcpsdk.CallToolRequest, args ListDomainsArgs) (*mcpsdk.CallToolResult, DomainsResult, error) {
+	limit := args.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(""))
+	if err != nil {
+		return errorResult("Failed to get bookmarks: %v", err), DomainsResult{}, nil
+	}
+
+	counts := map[string]int{}
+
+	for _, bookmark := range bookmarks {
+		if host := bookmarkHost(bookmark.URL); host != "" {
+			counts[host]++
+		}
+	}
+
+	domainsResult := DomainsResult{
+		Bookmarks: len(bookmarks),
+		Total:     len(counts),
+		Domains:   make([]DomainCount, 0, len(counts)),
+	}
+
+	for domain, count := range counts {
+		domainsResult.Domains = append(domainsResult.Domains, DomainCount{Domain: domain, Count: count})
+	}
+
+	// Most bookmarked domains first, ties in alphabetical order
+	slices.SortFunc(domainsResult.Domains, func(a, b DomainCount) int {
+		return cmp.Or(b.Count-a.Count, strings.Compare(a.Domain, b.Domain))
+	})
+
+	if len(domainsResult.Domains) > limit {
+		domainsResult.Domains = domainsResult.Domains[:limit]
+	}
+
+	result := fmt.Sprintf("%s come from %s:\n\n", pluralize(len(bookmarks), "bookmark"), pluralize(len(counts), "domain"))
+	for _, domain := range domainsResult.Domains {
+		result += fmt.Sprintf("• %s: %s\n", domain.Domain, pluralize(domain.Count, "bookmark"))
+	}
+
+	return textResult(result), domainsResult, nil
+}
+
+func (s *MCPServer) handleSearchByDomain(ctx context.Context, req *mcpsdk.CallToolRequest, args SearchByDomainArgs) (*mcpsdk.CallToolResult, BookmarkListResult, error) {
+	domain := normalizeDomain(strings.TrimSpace(args.Domain))
+	if domain == "" {
+		return errorResult("Domain is required"), BookmarkListResult{}, nil
+	}
+
+	limit := args.Limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// Linkding also searches URLs, so the domain narrows the candidates before
+	// hosts are matched exactly; matching titles or notes are filtered out below
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(domain))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), BookmarkListResult{}, nil
+	}
+
+	matches := filterBookmarks(bookmarks, func(b linkding.Bookmark) bool {
+		return matchesDomain(bookmarkHost(b.URL), domain)
+	})
+
+	listResult := summarizeBookmarks(matches)
+	if len(listResult.Bookmarks) > limit {
+		listResult.Bookmarks = listResult.Bookmarks[:limit]
+	}
+
+	result := fmt.Sprintf("Found %s on %s:\n\n", pluralize(listResult.Total, "bookmark"), domain)
+	for _, bookmark := range listResult.Bookmarks {
+		result += fmt.Sprintf("• **%s**\n  URL: %s\n  ID: %d\n\n", bookmark.Title, bookmark.URL, bookmark.ID)
+	}
+
+	return textResult(result), listResult, nil
+}
+
+// matchesDomain reports whether a normalized host is the domain or one of its subdomains
+func matchesDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// bookmarkHost returns the lowercased host of a bookmark URL without port
+// and "www." prefix, or "" if the URL can't be parsed
+func bookmarkHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return normalizeDomain(parsed.Hostname())
+}
+
+// normalizeDomain lowercases a domain and strips its "www." prefix, so
+// www.example.com and example.com are treated as the same site
+func normalizeDomain(domain string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSuffix(domain, ".")), "www.")
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestListDomains(t *testing.T) {
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{URL: "https://www.example.com/a"},
+		linkding.Bookmark{URL: "https://Example.com:8443/b"},
+		linkding.Bookmark{URL: "http://example.com/c"},
+		linkding.Bookmark{URL: "https://blog.example.com/post"},
+		linkding.Bookmark{URL: "https://go.dev/doc"},
+		linkding.Bookmark{URL: "https://go.dev/blog"},
+		linkding.Bookmark{URL: "https://alpha.example/x"},
+		linkding.Bookmark{URL: "not a url\x7f"},
+	)
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		wantDomains []DomainCount
+	}{
+		{
+			name: "all domains, most bookmarked first",
+			args: map[string]any{},
+			wantDomains: []DomainCount{
+				{Domain: "example.com", Count: 3},
+				{Domain: "go.dev", Count: 2},
+				{Domain: "alpha.example", Count: 1},
+				{Domain: "blog.example.com", Count: 1},
+			},
+		},
+		{
+			name:        "limit",
+			args:        map[string]any{"limit": 1},
+			wantDomains: []DomainCount{{Domain: "example.com", Count: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "list_domains", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			domains := structured[DomainsResult](t, result)
+			if !slices.Equal(domains.Domains, tt.wantDomains) {
+				t.Errorf("domains = %v, want %v", domains.Domains, tt.wantDomains)
+			}
+
+			if domains.Bookmarks != 8 || domains.Total != 4 {
+				t.Errorf("got %d bookmarks on %d domains, want 8 on 4", domains.Bookmarks, domains.Total)
+			}
+
+			if !strings.Contains(resultText(result), "• example.com: 3 bookmarks") {
+				t.Errorf("output %q doesn't list example.com", resultText(result))
+			}
+		})
+	}
+}
+
+func TestSearchByDomain(t *testing.T) {
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{ID: 1, URL: "https://www.example.com/a", Title: "A"},
+		linkding.Bookmark{ID: 2, URL: "https://blog.example.com/post", Title: "Post"},
+		linkding.Bookmark{ID: 3, URL: "https://notexample.com/x", Title: "Lookalike"},
+		linkding.Bookmark{ID: 4, URL: "https://go.dev/doc", Title: "Mentions example.com"},
+		linkding.Bookmark{ID: 5, URL: "https://example.com.evil.example/", Title: "Suffix trick"},
+	)
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantIDs   []int
+		wantTotal int
+		wantError bool
+	}{
+		{name: "exact and subdomains", args: map[string]any{"domain": "example.com"}, wantIDs: []int{2, 1}, wantTotal: 2},
+		{name: "www prefix and case ignored", args: map[string]any{"domain": "WWW.Example.com"}, wantIDs: []int{2, 1}, wantTotal: 2},
+		{name: "subdomain only", args: map[string]any{"domain": "blog.example.com"}, wantIDs: []int{2}, wantTotal: 1},
+		{name: "limit", args: map[string]any{"domain": "example.com", "limit": 1}, wantIDs: []int{2}, wantTotal: 2},
+		{name: "no match", args: map[string]any{"domain": "rust-lang.org"}, wantTotal: 0},
+		{name: "missing domain", args: map[string]any{"domain": " "}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "search_by_domain", tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			listResult := structured[BookmarkListResult](t, result)
+
+			var ids []int
+			for _, bookmark := range listResult.Bookmarks {
+				ids = append(ids, bookmark.ID)
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) || listResult.Total != tt.wantTotal {
+				t.Errorf("got IDs %v of %d, want %v of %d", ids, listResult.Total, tt.wantIDs, tt.wantTotal)
+			}
+		})
+	}
+}
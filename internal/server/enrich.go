@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *MCPServer) handleEnrichBookmark(ctx context.Context, req *mcpsdk.CallToolRequest, args EnrichBookmarkArgs) (*mcpsdk.CallToolResult, EnrichBookmarkResult, error) {
+	if args.ID == 0 {
+		return errorResult("Bookmark ID is required"), EnrichBookmarkResult{}, nil
+	}
+
+	bookmark, err := s.linkdingClient.GetBookmark(ctx, args.ID)
+	if err != nil {
+		if notFound := bookmarkNotFound(args.ID, err); notFound != nil {
+			return notFound, EnrichBookmarkResult{}, nil
+		}
+
+		return errorResult("Failed to get bookmark: %v", err), EnrichBookmarkResult{}, nil
+	}
+
+	enrichResult := EnrichBookmarkResult{
+		ID:          bookmark.ID,
+		Updated:     []string{},
+		Title:       bookmark.Title,
+		Description: bookmark.Description,
+	}
+
+	if bookmark.Title != "" && bookmark.Description != "" {
+		return textResult(fmt.Sprintf("Bookmark %d already has a title and description, nothing to enrich", bookmark.ID)), enrichResult, nil
+	}
+
+	metadata, err := s.previewPage(ctx, bookmark.URL)
+	if err != nil {
+		// The bookmark itself is fine, so report the fetch failure without failing the tool
+		return textResult(fmt.Sprintf("Could not fetch page metadata for %s, bookmark %d left unchanged: %v", bookmark.URL, bookmark.ID, err)), enrichResult, nil
+	}
+
+	fields := map[string]any{}
+
+	if bookmark.Title == "" && metadata.Title != "" {
+		fields["title"] = metadata.Title
+		enrichResult.Updated = append(enrichResult.Updated, "title")
+	}
+
+	if bookmark.Description == "" && metadata.Description != "" {
+		fields["description"] = metadata.Description
+		enrichResult.Updated = append(enrichResult.Updated, "description")
+	}
+
+	if len(fields) == 0 {
+		return textResult(fmt.Sprintf("The page at %s has no metadata to fill in, bookmark %d left unchanged", bookmark.URL, bookmark.ID)), enrichResult, nil
+	}
+
+	bookmark, err = s.linkdingClient.PatchBookmark(ctx, bookmark.ID, fields)
+	if err != nil {
+		return errorResult("Failed to update bookmark: %v", err), EnrichBookmarkResult{}, nil
+	}
+
+	enrichResult.Title = bookmark.Title
+	enrichResult.Description = bookmark.Description
+
+	result := fmt.Sprintf("%s Enriched bookmark %d (%s)\n\n• **%s**\n  URL: %s\n", s.mark(markEnriched), bookmark.ID, strings.Join(enrichResult.Updated, ", "), bookmark.Title, bookmark.URL)
+	if bookmark.Description != "" {
+		result += fmt.Sprintf("  Description: %s\n", bookmark.Description)
+	}
+
+	return textResult(result), enrichResult, nil
+}
+
+// previewPage fetches the live metadata of a page, as scraped by Linkding.
+// Linkding fetches the page on our behalf, so the URL must pass checkFetchURL.
+func (s *MCPServer) previewPage(ctx context.Context, rawURL string) (*linkding.WebsiteMetadata, error) {
+	if err := s.checkFetchURL(ctx, rawURL); err != nil {
+		return nil, err
+	}
+
+	checkResult, err := s.linkdingClient.CheckBookmark(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkResult.Metadata == nil {
+		return nil, errors.New("no metadata returned")
+	}
+
+	return checkResult.Metadata, nil
+}
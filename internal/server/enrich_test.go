@@ -0,0 +1,94 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestEnrichBookmark(t *testing.T) {
+	tests := []struct {
+		name        string
+		bookmark    linkding.Bookmark
+		private     bool
+		wantTitle   string
+		wantText    string
+		wantFetched bool
+		wantPatched bool
+	}{
+		{
+			name:        "empty title filled in",
+			bookmark:    linkding.Bookmark{ID: 1, URL: "https://a.example"},
+			private:     true,
+			wantTitle:   "Scraped https://a.example",
+			wantText:    "Enriched bookmark 1 (title)",
+			wantFetched: true,
+			wantPatched: true,
+		},
+		{
+			name:      "nothing to enrich",
+			bookmark:  linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "A", Description: "About A"},
+			private:   true,
+			wantTitle: "A",
+			wantText:  "nothing to enrich",
+		},
+		{
+			name:     "non-http URL refused",
+			bookmark: linkding.Bookmark{ID: 1, URL: "file:///etc/passwd"},
+			private:  true,
+			wantText: "only http and https URLs",
+		},
+		{
+			name:     "loopback address refused",
+			bookmark: linkding.Bookmark{ID: 1, URL: "http://127.0.0.1:8080/admin"},
+			wantText: "refusing to fetch",
+		},
+		{
+			name:     "metadata endpoint refused",
+			bookmark: linkding.Bookmark{ID: 1, URL: "http://169.254.169.254/latest/meta-data"},
+			wantText: "refusing to fetch",
+		},
+		{
+			name:        "private address allowed when opted in",
+			bookmark:    linkding.Bookmark{ID: 1, URL: "http://10.0.0.5/wiki"},
+			private:     true,
+			wantTitle:   "Scraped http://10.0.0.5/wiki",
+			wantText:    "Enriched bookmark 1",
+			wantFetched: true,
+			wantPatched: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, tt.bookmark)
+
+			var opts []Option
+			if tt.private {
+				opts = append(opts, WithPrivateFetches())
+			}
+
+			result := callTool(t, newTestServer(t, fake, opts...), "enrich_bookmark", map[string]any{"id": tt.bookmark.ID})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't contain %q", resultText(result), tt.wantText)
+			}
+
+			if fetched := len(fake.received("GET", "/api/bookmarks/check")) > 0; fetched != tt.wantFetched {
+				t.Errorf("page fetched = %v, want %v", fetched, tt.wantFetched)
+			}
+
+			if patched := len(fake.received("PATCH", "/api/bookmarks/")) > 0; patched != tt.wantPatched {
+				t.Errorf("bookmark patched = %v, want %v", patched, tt.wantPatched)
+			}
+
+			if bookmark, _ := fake.bookmark(tt.bookmark.ID); bookmark.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", bookmark.Title, tt.wantTitle)
+			}
+		})
+	}
+}
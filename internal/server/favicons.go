@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *MCPServer) handleFindMissingFavicons(ctx context.Context, req *mcpsdk.CallToolRequest, args FindMissingFaviconsArgs) (*mcpsdk.CallToolResult, MissingFaviconsResult, error) {
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), MissingFaviconsResult{}, nil
+	}
+
+	missing := filterBookmarks(bookmarks, func(b linkding.Bookmark) bool {
+		return b.FaviconURL == ""
+	})
+
+	missingResult := MissingFaviconsResult{Checked: len(bookmarks), Bookmarks: summarizeBookmarks(missing).Bookmarks}
+
+	if len(missing) == 0 {
+		return textResult(fmt.Sprintf("All %s have a favicon", pluralize(len(bookmarks), "bookmark"))), missingResult, nil
+	}
+
+	result := fmt.Sprintf("Found %s without a favicon among %d:\n\n", pluralize(len(missing), "bookmark"), len(bookmarks))
+	for _, bookmark := range missing {
+		result += fmt.Sprintf("• **%s**\n  URL: %s\n  ID: %d\n\n", bookmark.Title, bookmark.URL, bookmark.ID)
+	}
+
+	if !args.Refresh {
+		return textResult(result), missingResult, nil
+	}
+
+	if tooMany := s.checkBulkLimit(len(missing)); tooMany != nil {
+		return tooMany, MissingFaviconsResult{}, nil
+	}
+
+	// Linkding loads the favicon whenever a bookmark is saved (if favicons are
+	// enabled in its settings), so re-saving the unchanged URL triggers a reload.
+	// Favicons are loaded in the background and show up on a later call.
+	urls := make(map[int]string, len(missing))
+	for _, bookmark := range missing {
+		urls[bookmark.ID] = bookmark.URL
+	}
+
+	refreshResult := s.runBulk(ctx, req, bookmarkIDs(missing), func(ctx context.Context, id int) error {
+		_, err := s.linkdingClient.PatchBookmark(ctx, id, map[string]any{"url": urls[id]})
+
+		return err
+	})
+
+	missingResult.Refresh = &refreshResult
+	result += s.formatBulkResult("Requested a favicon reload for", refreshResult)
+
+	return textResult(result), missingResult, nil
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestFindMissingFavicons(t *testing.T) {
+	bookmarks := []linkding.Bookmark{
+		{ID: 1, URL: "https://a.example", FaviconURL: "https://linkding.example/static/a.png"},
+		{ID: 2, URL: "https://b.example", TagNames: []string{"go"}},
+		{ID: 3, URL: "https://c.example", FaviconURL: "https://linkding.example/static/c.png", TagNames: []string{"go"}},
+		{ID: 4, URL: "https://d.example"},
+	}
+
+	tests := []struct {
+		name        string
+		bookmarks   []linkding.Bookmark
+		args        map[string]any
+		wantChecked int
+		wantIDs     []int
+		wantPatched map[string]string
+		wantText    string
+	}{
+		{
+			name:        "report only",
+			bookmarks:   bookmarks,
+			args:        map[string]any{},
+			wantChecked: 4,
+			wantIDs:     []int{4, 2},
+			wantText:    "Found 2 bookmarks without a favicon among 4",
+		},
+		{
+			name:        "narrowed by query",
+			bookmarks:   bookmarks,
+			args:        map[string]any{"query": "#go"},
+			wantChecked: 2,
+			wantIDs:     []int{2},
+		},
+		{
+			name:        "refresh re-saves the unchanged URL",
+			bookmarks:   bookmarks,
+			args:        map[string]any{"refresh": true},
+			wantChecked: 4,
+			wantIDs:     []int{4, 2},
+			wantPatched: map[string]string{"/api/bookmarks/2/": "https://b.example", "/api/bookmarks/4/": "https://d.example"},
+			wantText:    "Requested a favicon reload for 2 of 2 bookmarks",
+		},
+		{
+			name:        "all have a favicon",
+			bookmarks:   bookmarks[:1],
+			args:        map[string]any{"refresh": true},
+			wantChecked: 1,
+			wantText:    "All 1 bookmark have a favicon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, tt.bookmarks...)
+
+			result := callTool(t, newTestServer(t, fake), "find_missing_favicons", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			missingResult := structured[MissingFaviconsResult](t, result)
+
+			var ids []int
+			for _, bookmark := range missingResult.Bookmarks {
+				ids = append(ids, bookmark.ID)
+			}
+
+			if missingResult.Checked != tt.wantChecked || !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("checked %d, missing %v; want %d, %v", missingResult.Checked, ids, tt.wantChecked, tt.wantIDs)
+			}
+
+			patched := map[string]string{}
+			for _, r := range fake.received("PATCH", "/api/bookmarks/") {
+				url, _ := r.Body["url"].(string)
+				patched[r.Path] = url
+
+				if len(r.Body) != 1 {
+					t.Errorf("PATCH %s sent %v, want only the url", r.Path, r.Body)
+				}
+			}
+
+			if !maps.Equal(patched, tt.wantPatched) {
+				t.Errorf("patched %v, want %v", patched, tt.wantPatched)
+			}
+
+			if refresh := missingResult.Refresh; tt.wantPatched != nil && (refresh == nil || refresh.Succeeded != len(tt.wantPatched)) {
+				t.Errorf("refresh = %+v, want %d succeeded", refresh, len(tt.wantPatched))
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't contain %q", resultText(result), tt.wantText)
+			}
+		})
+	}
+}
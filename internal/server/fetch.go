@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+// maxPageRedirects is the number of redirects the page client follows
+const maxPageRedirects = 10
+
+// errDisallowedAddress is returned for page fetches to private, loopback or
+// link-local addresses, which are refused unless WithPrivateFetches is set
+var errDisallowedAddress = errors.New("refusing to fetch a private, loopback or link-local address (set ALLOW_PRIVATE_FETCHES=true to allow it)")
+
+// checkFetchURL returns an error unless rawURL may be fetched on behalf of a
+// tool, by this server or by Linkding. Only http and https URLs are allowed,
+// and unless private fetches are enabled, the host must not resolve to a
+// private, loopback or link-local address.
+func (s *MCPServer) checkFetchURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("only http and https URLs can be fetched, not %q", rawURL)
+	}
+
+	if s.allowPrivateFetches {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", u.Hostname(), err)
+	}
+
+	for _, addr := range addrs {
+		if disallowedAddress(addr) {
+			return errDisallowedAddress
+		}
+	}
+
+	return nil
+}
+
+// disallowedAddress reports whether addr is private, loopback, link-local
+// (which includes cloud metadata endpoints) or unspecified
+func disallowedAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsUnspecified()
+}
+
+// newPageClient returns the client used to fetch bookmarked pages directly,
+// not through Linkding. Unless allowPrivate is set, it refuses to connect to
+// disallowed addresses; checking at connect time also covers redirects and
+// hosts resolving differently than when checkFetchURL looked them up.
+// Timeouts come from the request context.
+func newPageClient(allowPrivate bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if !allowPrivate {
+		dialer := &net.Dialer{
+			Control: func(_, address string, _ syscall.RawConn) error {
+				addrPort, err := netip.ParseAddrPort(address)
+				if err != nil {
+					return err
+				}
+
+				if disallowedAddress(addrPort.Addr()) {
+					return errDisallowedAddress
+				}
+
+				return nil
+			},
+		}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxPageRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxPageRedirects)
+			}
+
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("refusing to follow a redirect to %q", req.URL.Redacted())
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestDisallowedAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "10.1.2.3", want: true},
+		{addr: "172.16.0.1", want: true},
+		{addr: "192.168.1.1", want: true},
+		{addr: "127.0.0.1", want: true},
+		{addr: "169.254.169.254", want: true},
+		{addr: "0.0.0.0", want: true},
+		{addr: "::1", want: true},
+		{addr: "fe80::1", want: true},
+		{addr: "fd00::1", want: true},
+		{addr: "::ffff:127.0.0.1", want: true},
+		{addr: "93.184.216.34", want: false},
+		{addr: "2606:4700::1111", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := disallowedAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("disallowedAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckFetchURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		private    bool
+		wantErr    bool
+		wantDenied bool
+	}{
+		{name: "public address", url: "https://93.184.216.34/page"},
+		{name: "loopback", url: "http://127.0.0.1:9090/", wantErr: true, wantDenied: true},
+		{name: "IPv6 loopback", url: "http://[::1]/", wantErr: true, wantDenied: true},
+		{name: "localhost", url: "http://localhost/", wantErr: true, wantDenied: true},
+		{name: "loopback when opted in", url: "http://127.0.0.1:9090/", private: true},
+		// Opting in skips the lookup, so unresolvable hosts are left to the fetch
+		{name: "unresolved host when opted in", url: "https://a.example/", private: true},
+		{name: "file URL", url: "file:///etc/passwd", private: true, wantErr: true},
+		{name: "ftp URL", url: "ftp://93.184.216.34/", wantErr: true},
+		{name: "relative URL", url: "/etc/passwd", private: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &MCPServer{allowPrivateFetches: tt.private}
+
+			err := s.checkFetchURL(context.Background(), tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkFetchURL(%q) = %v, want error %v", tt.url, err, tt.wantErr)
+			}
+
+			if denied := errors.Is(err, errDisallowedAddress); denied != tt.wantDenied {
+				t.Errorf("checkFetchURL(%q) = %v, want a disallowed address error: %v", tt.url, err, tt.wantDenied)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// errFileToolsDisabled is returned when a file tool runs without a configured directory
+var errFileToolsDisabled = errors.New("file tools are disabled, set BACKUP_DIR to enable them")
+
+// confinePath resolves name relative to dir and makes sure the result stays
+// inside dir, so file tools can't read or write anywhere else on the host.
+// Every file tool must resolve its paths through here.
+//
+// Names containing ".." are rejected outright, and symlinks are resolved so
+// that a link inside dir can't point outside of it.
+func confinePath(dir, name string) (string, error) {
+	if dir == "" {
+		return "", errFileToolsDisabled
+	}
+
+	if slices.Contains(strings.Split(filepath.ToSlash(name), "/"), "..") {
+		return "", fmt.Errorf("%s must not contain \"..\"", name)
+	}
+
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	base, err = filepath.EvalSymlinks(base)
+	if err != nil {
+		return "", fmt.Errorf("backup directory is not accessible: %w", err)
+	}
+
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+
+	path = filepath.Clean(path)
+
+	// The file may not exist yet (e.g. a new backup), but its directory must,
+	// and both must resolve to somewhere inside base
+	resolved, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+
+	resolved = filepath.Join(resolved, filepath.Base(path))
+
+	if target, err := filepath.EvalSymlinks(resolved); err == nil {
+		resolved = target
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	if !isWithin(base, resolved) {
+		return "", fmt.Errorf("%s is outside the backup directory %s", name, base)
+	}
+
+	return resolved, nil
+}
+
+// isWithin reports whether path is strictly inside dir
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConfinePath(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, target := range []string{filepath.Join(dir, "sub", "target.json"), filepath.Join(outside, "target.json")} {
+		if err := os.WriteFile(target, []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, target := range map[string]string{
+		"inner-link.json": filepath.Join(dir, "sub", "target.json"),
+		"outer-link.json": filepath.Join(outside, "target.json"),
+		"outer-dir":       outside,
+	} {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	base, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		dir       string
+		path      string
+		want      string
+		wantError string
+	}{
+		{name: "file name", dir: dir, path: "backup.json", want: filepath.Join(base, "backup.json")},
+		{name: "nested file", dir: dir, path: "sub/backup.json", want: filepath.Join(base, "sub", "backup.json")},
+		{name: "absolute path inside", dir: dir, path: filepath.Join(base, "backup.json"), want: filepath.Join(base, "backup.json")},
+		{name: "symlink staying inside", dir: dir, path: "inner-link.json", want: filepath.Join(base, "sub", "target.json")},
+		{name: "parent directory", dir: dir, path: "../backup.json", wantError: `must not contain ".."`},
+		{name: "traversal back inside", dir: dir, path: "sub/../backup.json", wantError: `must not contain ".."`},
+		{name: "absolute path outside", dir: dir, path: filepath.Join(outside, "backup.json"), wantError: "outside the backup directory"},
+		{name: "the directory itself", dir: dir, path: ".", wantError: "outside the backup directory"},
+		{name: "symlink pointing outside", dir: dir, path: "outer-link.json", wantError: "outside the backup directory"},
+		{name: "symlinked directory outside", dir: dir, path: "outer-dir/backup.json", wantError: "outside the backup directory"},
+		{name: "missing subdirectory", dir: dir, path: "missing/backup.json", wantError: "no such file"},
+		{name: "disabled", path: "backup.json", wantError: errFileToolsDisabled.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := confinePath(tt.dir, tt.path)
+
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Errorf("confinePath(%q) = %q, %v; want an error containing %q", tt.path, got, err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("confinePath(%q) = %q, %v; want %q", tt.path, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileToolsDisabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantTools bool
+	}{
+		{name: "without BACKUP_DIR"},
+		{name: "with BACKUP_DIR", opts: []Option{WithBackupDir(t.TempDir())}, wantTools: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools, err := connect(t, newTestServer(t, newFakeLinkding(t), tt.opts...), nil).ListTools(context.Background(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, tool := range tools.Tools {
+				names = append(names, tool.Name)
+			}
+
+			for _, name := range []string{"backup_library", "restore_library"} {
+				if got := slices.Contains(names, name); got != tt.wantTools {
+					t.Errorf("%s registered = %v, want %v", name, got, tt.wantTools)
+				}
+			}
+		})
+	}
+}
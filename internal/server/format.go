@@ -0,0 +1,90 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+// pluralize formats a count with its noun, e.g. "1 bookmark", "0 bookmarks", "2 bookmarks".
+// The plural is formed by appending "s", which covers every noun used in tool output.
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// outputFields lists the bookmark fields search_bookmarks can render, in output order
+var outputFields = []string{"id", "title", "url", "description", "tags", "date_added"}
+
+// defaultOutputFields are rendered when the caller doesn't select any fields
+var defaultOutputFields = []string{"title", "url", "description", "tags"}
+
+// validateOutputFields returns an error naming the first unknown field
+func validateOutputFields(fields []string) error {
+	for _, field := range fields {
+		if !slices.Contains(outputFields, field) {
+			return fmt.Errorf("unknown field %q (supported: %s)", field, strings.Join(outputFields, ", "))
+		}
+	}
+
+	return nil
+}
+
+// formatBookmark renders a bookmark in search output. When fields are
+// selected only those are rendered; otherwise the configured bookmark
+// template is used if there is one. Bookmarks the template fails to render
+// (e.g. it references an unknown field) fall back to the built-in format.
+func (s *MCPServer) formatBookmark(bookmark linkding.Bookmark, fields []string) string {
+	if len(fields) == 0 && s.bookmarkTemplate != nil {
+		var rendered strings.Builder
+
+		summary := summarizeBookmarks([]linkding.Bookmark{bookmark}).Bookmarks[0]
+		if err := s.bookmarkTemplate.Execute(&rendered, summary); err == nil {
+			return strings.TrimRight(rendered.String(), "\n") + "\n\n"
+		}
+	}
+
+	if len(fields) == 0 {
+		fields = defaultOutputFields
+	}
+
+	var lines []string
+
+	// Fields are rendered in a fixed order regardless of how they were requested
+	for _, field := range outputFields {
+		if !slices.Contains(fields, field) {
+			continue
+		}
+
+		switch field {
+		case "id":
+			lines = append(lines, fmt.Sprintf("ID: %d", bookmark.ID))
+		case "title":
+			lines = append(lines, fmt.Sprintf("**%s**", bookmark.Title))
+		case "url":
+			lines = append(lines, "URL: "+bookmark.URL)
+		case "description":
+			if bookmark.Description != "" {
+				lines = append(lines, "Description: "+bookmark.Description)
+			}
+		case "tags":
+			if len(bookmark.TagNames) > 0 {
+				lines = append(lines, fmt.Sprintf("Tags: %v", bookmark.TagNames))
+			}
+		case "date_added":
+			lines = append(lines, "Added: "+bookmark.DateAdded.Format(time.DateOnly))
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return "• " + strings.Join(lines, "\n  ") + "\n\n"
+}
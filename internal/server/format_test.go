@@ -0,0 +1,136 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		n    int
+		noun string
+		want string
+	}{
+		{n: 0, noun: "bookmark", want: "0 bookmarks"},
+		{n: 1, noun: "bookmark", want: "1 bookmark"},
+		{n: 2, noun: "bookmark", want: "2 bookmarks"},
+		{n: 1, noun: "orphaned tag", want: "1 orphaned tag"},
+		{n: 12, noun: "orphaned tag", want: "12 orphaned tags"},
+		{n: -1, noun: "day", want: "-1 days"},
+	}
+
+	for _, tt := range tests {
+		if got := pluralize(tt.n, tt.noun); got != tt.want {
+			t.Errorf("pluralize(%d, %q) = %q, want %q", tt.n, tt.noun, got, tt.want)
+		}
+	}
+}
+
+func TestPluralizedOutput(t *testing.T) {
+	tests := []struct {
+		bookmarks int
+		want      string
+	}{
+		{bookmarks: 0, want: "Found 0 bookmarks:"},
+		{bookmarks: 1, want: "Found 1 bookmark:"},
+		{bookmarks: 2, want: "Found 2 bookmarks:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			fake := newFakeLinkding(t)
+			for i := range tt.bookmarks {
+				fake.add(linkding.Bookmark{URL: fmt.Sprintf("https://example.com/%d", i)})
+			}
+
+			if got := resultText(callTool(t, newTestServer(t, fake), "search_bookmarks", map[string]any{})); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("output %q doesn't start with %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookmarkTemplate(t *testing.T) {
+	fake := newFakeLinkding(t, linkding.Bookmark{URL: "https://a.example", Title: "A", Description: "About A", TagNames: []string{"go", "web"}})
+
+	tests := []struct {
+		name     string
+		template string
+		args     map[string]any
+		want     string
+	}{
+		{name: "custom template", template: `- [{{.Title}}]({{.URL}}){{range .Tags}} #{{.}}{{end}}`, args: map[string]any{}, want: "- [A](https://a.example) #go #web\n"},
+		{name: "no template", args: map[string]any{}, want: "• **A**\n  URL: https://a.example\n  Description: About A\n  Tags: [go web]\n"},
+		{name: "failing template falls back", template: `{{.Missing}}`, args: map[string]any{}, want: "• **A**\n  URL: https://a.example\n"},
+		{name: "selected fields override the template", template: `{{.Title}}`, args: map[string]any{"fields": []string{"url"}}, want: "URL: https://a.example\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.template != "" {
+				opts = append(opts, WithBookmarkTemplate(template.Must(template.New("bookmark").Parse(tt.template))))
+			}
+
+			result := callTool(t, newTestServer(t, fake, opts...), "search_bookmarks", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			if got := resultText(result); !strings.Contains(got, tt.want) {
+				t.Errorf("output %q doesn't contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchOutputFields(t *testing.T) {
+	fake := newFakeLinkding(t, linkding.Bookmark{
+		ID: 7, URL: "https://a.example", Title: "A", Description: "About A", TagNames: []string{"go"},
+		DateAdded: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+
+	tests := []struct {
+		name      string
+		fields    []string
+		want      string
+		wantError bool
+	}{
+		{name: "default fields", want: "• **A**\n  URL: https://a.example\n  Description: About A\n  Tags: [go]\n\n"},
+		{name: "url and title", fields: []string{"url", "title"}, want: "• **A**\n  URL: https://a.example\n\n"},
+		{name: "url only", fields: []string{"url"}, want: "• URL: https://a.example\n\n"},
+		{name: "id and date", fields: []string{"date_added", "id"}, want: "• ID: 7\n  Added: 2024-03-01\n\n"},
+		{name: "unknown field", fields: []string{"url", "notes"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]any{}
+			if tt.fields != nil {
+				args["fields"] = tt.fields
+			}
+
+			result := callTool(t, newTestServer(t, fake), "search_bookmarks", args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				if !strings.Contains(resultText(result), `unknown field "notes"`) {
+					t.Errorf("output %q doesn't name the unknown field", resultText(result))
+				}
+
+				return
+			}
+
+			if got := strings.SplitN(resultText(result), "\n\n", 2)[1]; got != tt.want {
+				t.Errorf("rendered %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,554 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// fakeLinkding is an in-memory Linkding API served by an httptest.Server.
+// It records every request and lets tests inject failures.
+type fakeLinkding struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	bookmarks []linkding.Bookmark
+	tags      []linkding.Tag
+	nextID    int
+	requests  []fakeRequest
+	// fail, when set, is asked before every request; a non-zero status is
+	// returned instead of handling the request
+	fail func(r *http.Request) int
+}
+
+// fakeRequest is a request received by fakeLinkding
+type fakeRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   map[string]any
+}
+
+// newFakeLinkding starts a fake Linkding serving the given bookmarks.
+// Bookmarks without an ID get one, and their tags are added to the tag list.
+func newFakeLinkding(t *testing.T, bookmarks ...linkding.Bookmark) *fakeLinkding {
+	t.Helper()
+
+	f := &fakeLinkding{nextID: 1}
+
+	for _, bookmark := range bookmarks {
+		f.add(bookmark)
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+// add stores a bookmark, assigning an ID and dates if missing
+func (f *fakeLinkding) add(bookmark linkding.Bookmark) linkding.Bookmark {
+	if bookmark.ID == 0 {
+		bookmark.ID = f.nextID
+	}
+
+	f.nextID = max(f.nextID, bookmark.ID) + 1
+
+	if bookmark.DateAdded.IsZero() {
+		bookmark.DateAdded = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(bookmark.ID) * time.Hour)
+	}
+
+	if bookmark.DateModified.IsZero() {
+		bookmark.DateModified = bookmark.DateAdded
+	}
+
+	f.ensureTags(bookmark.TagNames)
+	f.bookmarks = append(f.bookmarks, bookmark)
+
+	return bookmark
+}
+
+// ensureTags adds the tags missing from the tag list
+func (f *fakeLinkding) ensureTags(names []string) {
+	for _, name := range names {
+		f.ensureTag(name)
+	}
+}
+
+// ensureTag returns the tag with the given name, creating it if needed
+func (f *fakeLinkding) ensureTag(name string) (linkding.Tag, bool) {
+	for _, tag := range f.tags {
+		if strings.EqualFold(tag.Name, name) {
+			return tag, false
+		}
+	}
+
+	tag := linkding.Tag{ID: len(f.tags) + 1, Name: name, DateAdded: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	f.tags = append(f.tags, tag)
+
+	return tag, true
+}
+
+// bookmark returns a copy of the stored bookmark with the given ID
+func (f *fakeLinkding) bookmark(id int) (linkding.Bookmark, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, bookmark := range f.bookmarks {
+		if bookmark.ID == id {
+			return bookmark, true
+		}
+	}
+
+	return linkding.Bookmark{}, false
+}
+
+// count returns how many bookmarks are stored, including archived ones
+func (f *fakeLinkding) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.bookmarks)
+}
+
+// received returns the recorded requests matching method and path prefix
+func (f *fakeLinkding) received(method, pathPrefix string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matching []fakeRequest
+
+	for _, r := range f.requests {
+		if r.Method == method && strings.HasPrefix(r.Path, pathPrefix) {
+			matching = append(matching, r)
+		}
+	}
+
+	return matching
+}
+
+func (f *fakeLinkding) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	recorded := fakeRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Header: r.Header.Clone()}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&recorded.Body)
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, recorded)
+	fail := f.fail
+	f.mu.Unlock()
+
+	if fail != nil {
+		if status := fail(r); status != 0 {
+			w.WriteHeader(status)
+			_, _ = fmt.Fprintf(w, `{"detail": "injected failure %d"}`, status)
+
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	switch {
+	case path == "/health":
+		writeJSON(w, http.StatusOK, map[string]string{"version": "1.41.0", "status": "healthy"})
+	case path == "/api":
+		writeJSON(w, http.StatusOK, map[string]string{})
+	case path == "/feeds/shared":
+		f.serveFeed(w, recorded)
+	case path == "/api/bookmarks" && r.Method == http.MethodGet:
+		f.serveList(w, recorded, false)
+	case path == "/api/bookmarks/archived":
+		f.serveList(w, recorded, true)
+	case path == "/api/bookmarks" && r.Method == http.MethodPost:
+		f.serveCreate(w, recorded)
+	case path == "/api/bookmarks/check":
+		f.serveCheck(w, recorded)
+	case len(parts) >= 3 && parts[1] == "bookmarks":
+		f.serveBookmark(w, recorded, parts[2:])
+	case path == "/api/tags" && r.Method == http.MethodGet:
+		f.serveTags(w, recorded)
+	case path == "/api/tags" && r.Method == http.MethodPost:
+		name, _ := recorded.Body["name"].(string)
+		tag, created := f.ensureTag(name)
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+
+		writeJSON(w, status, tag)
+	case len(parts) == 3 && parts[1] == "tags":
+		id, _ := strconv.Atoi(parts[2])
+		if id < 1 || id > len(f.tags) {
+			writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, f.tags[id-1])
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+	}
+}
+
+func (f *fakeLinkding) serveList(w http.ResponseWriter, r fakeRequest, archived bool) {
+	var matching []linkding.Bookmark
+
+	for _, bookmark := range f.bookmarks {
+		if bookmark.IsArchived == archived && matchesFakeQuery(bookmark, r.Query) {
+			matching = append(matching, bookmark)
+		}
+	}
+
+	// Newest first, like Linkding; the sort parameter is ignored like older versions do
+	slices.SortStableFunc(matching, func(a, b linkding.Bookmark) int { return b.DateAdded.Compare(a.DateAdded) })
+
+	limit, offset := pageParams(r.Query)
+	page := pageOf(matching, limit, offset)
+
+	writeJSON(w, http.StatusOK, linkding.BookmarkResponse{
+		Count:   len(matching),
+		Next:    f.nextURL(r, len(matching), limit, offset),
+		Results: page,
+	})
+}
+
+func (f *fakeLinkding) serveTags(w http.ResponseWriter, r fakeRequest) {
+	limit, offset := pageParams(r.Query)
+
+	writeJSON(w, http.StatusOK, linkding.TagResponse{
+		Count:   len(f.tags),
+		Next:    f.nextURL(r, len(f.tags), limit, offset),
+		Results: pageOf(f.tags, limit, offset),
+	})
+}
+
+// nextURL returns the URL of the page after the requested one, if there is one
+func (f *fakeLinkding) nextURL(r fakeRequest, count, limit, offset int) *string {
+	if offset+limit >= count {
+		return nil
+	}
+
+	query := url.Values{}
+	for name, values := range r.Query {
+		query[name] = values
+	}
+
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset+limit))
+
+	next := f.URL + r.Path + "?" + query.Encode()
+
+	return &next
+}
+
+func (f *fakeLinkding) serveCreate(w http.ResponseWriter, r fakeRequest) {
+	rawURL, _ := r.Body["url"].(string)
+	if rawURL == "" {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"url": {"This field is required."}})
+
+		return
+	}
+
+	// Like Linkding, saving a URL twice updates the existing bookmark
+	for i, bookmark := range f.bookmarks {
+		if bookmark.URL == rawURL {
+			applyFields(&f.bookmarks[i], r.Body)
+			writeJSON(w, http.StatusOK, f.bookmarks[i])
+
+			return
+		}
+	}
+
+	bookmark := linkding.Bookmark{URL: rawURL}
+	applyFields(&bookmark, r.Body)
+
+	if bookmark.Title == "" && r.Body["disable_scraping"] != true {
+		bookmark.Title = "Scraped " + rawURL
+	}
+
+	writeJSON(w, http.StatusCreated, f.add(bookmark))
+}
+
+func (f *fakeLinkding) serveCheck(w http.ResponseWriter, r fakeRequest) {
+	rawURL := r.Query.Get("url")
+	check := linkding.CheckResult{Metadata: &linkding.WebsiteMetadata{URL: rawURL, Title: "Scraped " + rawURL}}
+
+	for _, bookmark := range f.bookmarks {
+		if bookmark.URL == rawURL {
+			check.Bookmark = &bookmark
+
+			break
+		}
+	}
+
+	writeJSON(w, http.StatusOK, check)
+}
+
+func (f *fakeLinkding) serveBookmark(w http.ResponseWriter, r fakeRequest, parts []string) {
+	id, _ := strconv.Atoi(parts[0])
+
+	i := slices.IndexFunc(f.bookmarks, func(b linkding.Bookmark) bool { return b.ID == id })
+	if i < 0 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+
+		return
+	}
+
+	action := ""
+	if len(parts) > 1 {
+		action = parts[1]
+	}
+
+	switch {
+	case action == "archive" && r.Method == http.MethodPost:
+		f.bookmarks[i].IsArchived = true
+		w.WriteHeader(http.StatusNoContent)
+	case action == "unarchive" && r.Method == http.MethodPost:
+		f.bookmarks[i].IsArchived = false
+		w.WriteHeader(http.StatusNoContent)
+	case action != "":
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, f.bookmarks[i])
+	case r.Method == http.MethodPatch:
+		applyFields(&f.bookmarks[i], r.Body)
+		f.ensureTags(f.bookmarks[i].TagNames)
+		writeJSON(w, http.StatusOK, f.bookmarks[i])
+	case r.Method == http.MethodPut:
+		id := f.bookmarks[i].ID
+		f.bookmarks[i] = linkding.Bookmark{ID: id, DateAdded: f.bookmarks[i].DateAdded}
+		applyFields(&f.bookmarks[i], r.Body)
+		f.ensureTags(f.bookmarks[i].TagNames)
+		writeJSON(w, http.StatusOK, f.bookmarks[i])
+	case r.Method == http.MethodDelete:
+		f.bookmarks = slices.Delete(f.bookmarks, i, i+1)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeLinkding) serveFeed(w http.ResponseWriter, r fakeRequest) {
+	w.Header().Set("Content-Type", "application/rss+xml")
+
+	feed := `<?xml version="1.0" encoding="utf-8"?><rss version="2.0"><channel><title>Shared bookmarks</title>`
+
+	for _, bookmark := range f.bookmarks {
+		if !bookmark.Shared || !matchesFakeQuery(bookmark, r.Query) {
+			continue
+		}
+
+		feed += fmt.Sprintf("<item><title>%s</title><link>%s</link><description>%s</description>",
+			xmlEscape(bookmark.Title), xmlEscape(bookmark.URL), xmlEscape(bookmark.Description))
+
+		for _, tag := range bookmark.TagNames {
+			feed += "<category>" + xmlEscape(tag) + "</category>"
+		}
+
+		feed += "<pubDate>" + bookmark.DateAdded.Format(time.RFC1123Z) + "</pubDate></item>"
+	}
+
+	_, _ = fmt.Fprint(w, feed+"</channel></rss>")
+}
+
+// applyFields sets the bookmark fields present in a JSON request body
+func applyFields(bookmark *linkding.Bookmark, body map[string]any) {
+	for name, value := range body {
+		switch name {
+		case "url":
+			bookmark.URL, _ = value.(string)
+		case "title":
+			bookmark.Title, _ = value.(string)
+		case "description":
+			bookmark.Description, _ = value.(string)
+		case "notes":
+			bookmark.Notes, _ = value.(string)
+		case "unread":
+			bookmark.Unread, _ = value.(bool)
+		case "shared":
+			bookmark.Shared, _ = value.(bool)
+		case "is_archived":
+			bookmark.IsArchived, _ = value.(bool)
+		case "favicon_url":
+			bookmark.FaviconURL, _ = value.(string)
+		case "preview_image_url":
+			bookmark.PreviewImageURL, _ = value.(string)
+		case "tag_names":
+			bookmark.TagNames = []string{}
+
+			values, _ := value.([]any)
+			for _, tag := range values {
+				name, _ := tag.(string)
+				bookmark.TagNames = append(bookmark.TagNames, name)
+			}
+		}
+	}
+}
+
+// matchesFakeQuery implements a small subset of Linkding's search: words
+// match title, description, notes or URL, "#tag" matches a tag, "!untagged"
+// and "!unread" work, a leading "-" negates a term, and the unread and shared
+// filters are honored
+func matchesFakeQuery(bookmark linkding.Bookmark, query url.Values) bool {
+	if unread := query.Get("unread"); unread != "" && bookmark.Unread != (unread == "yes") {
+		return false
+	}
+
+	if shared := query.Get("shared"); shared != "" && bookmark.Shared != (shared == "yes") {
+		return false
+	}
+
+	for _, term := range strings.Fields(query.Get("q")) {
+		negated := strings.HasPrefix(term, "-")
+		term = strings.TrimPrefix(term, "-")
+
+		var matches bool
+
+		switch tag, isTag := strings.CutPrefix(term, "#"); {
+		case isTag:
+			matches = containsTag(bookmark.TagNames, tag)
+		case term == "!untagged":
+			matches = len(bookmark.TagNames) == 0
+		case term == "!unread":
+			matches = bookmark.Unread
+		default:
+			text := strings.ToLower(bookmark.Title + " " + bookmark.Description + " " + bookmark.Notes + " " + bookmark.URL)
+			matches = strings.Contains(text, strings.ToLower(term))
+		}
+
+		if matches == negated {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pageParams returns the requested page, defaulting to Linkding's page size of 100
+func pageParams(query url.Values) (int, int) {
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	return limit, offset
+}
+
+// pageOf returns the items of the page starting at offset, never nil
+func pageOf[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+
+	return items[offset:min(offset+limit, len(items))]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// newTestServer creates an MCPServer talking to the fake Linkding through a
+// client without retries, so injected failures fail fast
+func newTestServer(t *testing.T, fake *fakeLinkding, opts ...Option) *MCPServer {
+	t.Helper()
+
+	client := linkding.NewClient(fake.URL, "test-token", linkding.WithRetries(0))
+
+	return NewMCP(fake.URL, "test-token", append([]Option{WithClient(client)}, opts...)...)
+}
+
+// connect opens an MCP client session to s over in-memory transports
+func connect(t *testing.T, s *MCPServer, opts *mcpsdk.ClientOptions) *mcpsdk.ClientSession {
+	t.Helper()
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+
+	serverSession, err := s.mcpServer.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "v0.0.1"}, opts)
+
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = session.Close() })
+
+	return session
+}
+
+// callTool calls a tool on s and returns the result, failing the test on protocol errors
+func callTool(t *testing.T, s *MCPServer, name string, args any) *mcpsdk.CallToolResult {
+	t.Helper()
+
+	result, err := connect(t, s, nil).CallTool(context.Background(), &mcpsdk.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("call %s: %v", name, err)
+	}
+
+	return result
+}
+
+// resultText returns the concatenated text content of a tool result
+func resultText(result *mcpsdk.CallToolResult) string {
+	var text string
+
+	for _, content := range result.Content {
+		if textContent, ok := content.(*mcpsdk.TextContent); ok {
+			text += textContent.Text
+		}
+	}
+
+	return text
+}
+
+// structured decodes the structured content of a tool result into v
+func structured[T any](t *testing.T, result *mcpsdk.CallToolResult) T {
+	t.Helper()
+
+	var v T
+
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatalf("marshal structured content: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("unmarshal structured content %s: %v", data, err)
+	}
+
+	return v
+}
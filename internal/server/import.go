@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// csvColumns lists the header names recognized for each field when no column
+// is configured, covering Pocket ("url", "title", "tags") and Instapaper
+// ("URL", "Title", "Folder") exports. Headers are matched case-insensitively.
+var csvColumns = map[string][]string{
+	"url":   {"url", "href", "link"},
+	"title": {"title", "name"},
+	"tags":  {"tags", "tag", "folder", "labels"},
+}
+
+func (s *MCPServer) handleImportCSV(ctx context.Context, req *mcpsdk.CallToolRequest, args ImportCSVArgs) (*mcpsdk.CallToolResult, RestoreResult, error) {
+	if strings.TrimSpace(args.CSV) == "" {
+		return errorResult("CSV content is required"), RestoreResult{}, nil
+	}
+
+	requests, err := parseBookmarkCSV(args)
+	if err != nil {
+		return errorResult("Failed to parse CSV: %v", err), RestoreResult{}, nil
+	}
+
+	if tooMany := s.checkBulkLimit(len(requests)); tooMany != nil {
+		return tooMany, RestoreResult{}, nil
+	}
+
+	if disallowed := s.checkVocabulary(importedTags(requests)); disallowed != nil {
+		return disallowed, RestoreResult{}, nil
+	}
+
+	missing, skipped, err := s.missingBookmarks(ctx, requests)
+	if err != nil {
+		return errorResult("Failed to fetch bookmarks: %v", err), RestoreResult{}, nil
+	}
+
+	importResult := s.createBookmarks(ctx, req, missing)
+	importResult.Skipped = skipped
+
+	return textResult(s.formatRestoreResult(fmt.Sprintf("Imported %s from CSV", pluralize(len(requests), "row")), importResult)), importResult, nil
+}
+
+// parseBookmarkCSV turns CSV rows into bookmark requests. The first row is the
+// header; the URL column is required, title and tags are optional. Tags may be
+// separated by "|" (Pocket), commas or spaces. An Instapaper "Folder" column
+// holds a single folder name instead, which becomes one tag.
+func parseBookmarkCSV(args ImportCSVArgs) ([]linkding.CreateBookmarkRequest, error) {
+	reader := csv.NewReader(strings.NewReader(args.CSV))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("no header row")
+	}
+
+	header := rows[0]
+
+	urlIndex := csvColumn(header, args.URLColumn, "url")
+	if urlIndex < 0 {
+		return nil, fmt.Errorf("no URL column found in header %v", header)
+	}
+
+	titleIndex := csvColumn(header, args.TitleColumn, "title")
+	tagsIndex := csvColumn(header, args.TagsColumn, "tags")
+
+	parseTags := splitTags
+	if tagsIndex >= 0 && strings.EqualFold(strings.TrimSpace(header[tagsIndex]), "folder") {
+		parseTags = folderTag
+	}
+
+	requests := make([]linkding.CreateBookmarkRequest, 0, len(rows)-1)
+
+	for line, row := range rows[1:] {
+		rawURL := strings.TrimSpace(csvField(row, urlIndex))
+		if rawURL == "" {
+			continue
+		}
+
+		if parsed, err := url.Parse(rawURL); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("row %d: invalid URL %q", line+2, rawURL)
+		}
+
+		requests = append(requests, linkding.CreateBookmarkRequest{
+			URL:      rawURL,
+			Title:    strings.TrimSpace(csvField(row, titleIndex)),
+			TagNames: parseTags(csvField(row, tagsIndex)),
+			Unread:   args.Unread,
+		})
+	}
+
+	return requests, nil
+}
+
+// csvColumn returns the index of the configured column, or of the first
+// known header name for field when none is configured, or -1 if missing
+func csvColumn(header []string, configured, field string) int {
+	names := csvColumns[field]
+	if configured != "" {
+		names = []string{configured}
+	}
+
+	for _, name := range names {
+		if i := slices.IndexFunc(header, func(h string) bool { return strings.EqualFold(strings.TrimSpace(h), name) }); i >= 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// csvField returns the field at index i, or "" if the row is shorter or i is -1
+func csvField(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+
+	return row[i]
+}
+
+// splitTags splits a tag list on "|", commas and whitespace
+func splitTags(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == '|' || r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// folderTag turns a folder name into a single tag, lowercased with runs of
+// other characters than letters and digits replaced by "-", so that "Read
+// Later" becomes "read-later"
+func folderTag(folder string) []string {
+	words := strings.FieldsFunc(strings.ToLower(folder), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return nil
+	}
+
+	return []string{strings.Join(words, "-")}
+}
+
+// importedTags returns the distinct tags of all requests
+func importedTags(requests []linkding.CreateBookmarkRequest) []string {
+	var tags []string
+
+	for _, createReq := range requests {
+		for _, tag := range createReq.TagNames {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// normalizeURL returns a key identifying a URL for duplicate detection. The
+// scheme and host are compared case-insensitively, and a trailing slash and
+// tracking parameters such as utm_source are ignored; URLs that don't parse
+// are only trimmed.
+func normalizeURL(rawURL string) string {
+	rawURL, _ = stripTrackingParams(rawURL)
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+
+	return parsed.String()
+}
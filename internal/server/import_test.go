@@ -0,0 +1,202 @@
+package server
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+const pocketCSV = `title,url,time_added,tags,status
+Go Blog,https://go.dev/blog,1700000000,go|blog,unread
+Rust Book,https://doc.rust-lang.org/book/,1700000001,,archive
+`
+
+const instapaperCSV = `URL,Title,Selection,Folder,Timestamp
+https://example.com/a,Article A,,Read Later,1700000000
+https://example.com/b,Article B,,Unread,1700000001
+https://example.com/c,Article C,,,1700000002
+`
+
+func TestParseBookmarkCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      ImportCSVArgs
+		want      []linkding.CreateBookmarkRequest
+		wantError string
+	}{
+		{
+			name: "pocket export",
+			args: ImportCSVArgs{CSV: pocketCSV},
+			want: []linkding.CreateBookmarkRequest{
+				{URL: "https://go.dev/blog", Title: "Go Blog", TagNames: []string{"go", "blog"}},
+				{URL: "https://doc.rust-lang.org/book/", Title: "Rust Book"},
+			},
+		},
+		{
+			name: "instapaper folder becomes one slugified tag",
+			args: ImportCSVArgs{CSV: instapaperCSV, Unread: true},
+			want: []linkding.CreateBookmarkRequest{
+				{URL: "https://example.com/a", Title: "Article A", TagNames: []string{"read-later"}, Unread: true},
+				{URL: "https://example.com/b", Title: "Article B", TagNames: []string{"unread"}, Unread: true},
+				{URL: "https://example.com/c", Title: "Article C", Unread: true},
+			},
+		},
+		{
+			name: "configured columns",
+			args: ImportCSVArgs{
+				CSV:         "Address,Label,Keywords\nhttps://example.com,Example,\"a, b c\"\n",
+				URLColumn:   "address",
+				TitleColumn: "Label",
+				TagsColumn:  "keywords",
+			},
+			want: []linkding.CreateBookmarkRequest{{URL: "https://example.com", Title: "Example", TagNames: []string{"a", "b", "c"}}},
+		},
+		{
+			name: "alternative header names and short rows",
+			args: ImportCSVArgs{CSV: "Name, Link\nExample, https://example.com\n\nhttps://ignored.example\n"},
+			want: []linkding.CreateBookmarkRequest{{URL: "https://example.com", Title: "Example"}},
+		},
+		{
+			name:      "no URL column",
+			args:      ImportCSVArgs{CSV: "title,tags\nExample,go\n"},
+			wantError: "no URL column",
+		},
+		{
+			name:      "configured column missing",
+			args:      ImportCSVArgs{CSV: pocketCSV, URLColumn: "address"},
+			wantError: "no URL column",
+		},
+		{
+			name:      "invalid URL",
+			args:      ImportCSVArgs{CSV: "url\nhttps://example.com\nnot a url\n"},
+			wantError: `row 3: invalid URL "not a url"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests, err := parseBookmarkCSV(tt.args)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("error = %v, want %q", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.EqualFunc(requests, tt.want, func(a, b linkding.CreateBookmarkRequest) bool {
+				return a.URL == b.URL && a.Title == b.Title && slices.Equal(a.TagNames, b.TagNames) && a.Unread == b.Unread
+			}) {
+				t.Errorf("requests = %+v, want %+v", requests, tt.want)
+			}
+		})
+	}
+}
+
+func TestFolderTag(t *testing.T) {
+	tests := []struct {
+		folder string
+		want   []string
+	}{
+		{folder: "Read Later", want: []string{"read-later"}},
+		{folder: "  Tech / Go  ", want: []string{"tech-go"}},
+		{folder: "Café 2024", want: []string{"café-2024"}},
+		{folder: "unread", want: []string{"unread"}},
+		{folder: " - ", want: nil},
+		{folder: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.folder, func(t *testing.T) {
+			if got := folderTag(tt.folder); !slices.Equal(got, tt.want) {
+				t.Errorf("folderTag(%q) = %v, want %v", tt.folder, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		wantCreated int
+		wantSkipped int
+		wantError   string
+	}{
+		{
+			name:        "existing URLs skipped",
+			args:        map[string]any{"csv": instapaperCSV},
+			wantCreated: 2,
+			wantSkipped: 1,
+		},
+		{
+			name:        "duplicates within the CSV skipped",
+			args:        map[string]any{"csv": "url\nhttps://new.example/?utm_source=feed\nhttps://NEW.example\n"},
+			wantCreated: 1,
+			wantSkipped: 1,
+		},
+		{name: "empty CSV", args: map[string]any{"csv": " "}, wantError: "CSV content is required"},
+		{name: "parse error", args: map[string]any{"csv": "title\nExample\n"}, wantError: "Failed to parse CSV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: "https://EXAMPLE.com/b/"})
+
+			result := callTool(t, newTestServer(t, fake), "import_csv", tt.args)
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+					t.Fatalf("result = %q, want error %q", resultText(result), tt.wantError)
+				}
+
+				return
+			}
+
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			importResult := structured[RestoreResult](t, result)
+			if importResult.Created != tt.wantCreated || importResult.Skipped != tt.wantSkipped || importResult.Failed != 0 {
+				t.Errorf("result = %+v, want %d created and %d skipped", importResult, tt.wantCreated, tt.wantSkipped)
+			}
+
+			if got := fake.count(); got != 1+tt.wantCreated {
+				t.Errorf("stored %d bookmarks, want %d", got, 1+tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestImportCSVFolderTags(t *testing.T) {
+	fake := newFakeLinkding(t)
+
+	result := callTool(t, newTestServer(t, fake), "import_csv", map[string]any{"csv": instapaperCSV})
+	if result.IsError {
+		t.Fatal(resultText(result))
+	}
+
+	// Bookmarks are created concurrently, so match them by URL
+	want := map[string][]string{
+		"https://example.com/a": {"read-later"},
+		"https://example.com/b": {"unread"},
+		"https://example.com/c": nil,
+	}
+
+	for id := 1; id <= len(want); id++ {
+		bookmark, found := fake.bookmark(id)
+		if !found {
+			t.Fatalf("bookmark %d not created", id)
+		}
+
+		if !slices.Equal(bookmark.TagNames, want[bookmark.URL]) {
+			t.Errorf("%s tags = %v, want %v", bookmark.URL, bookmark.TagNames, want[bookmark.URL])
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// latencyBuckets is the number of histogram buckets. Bucket i counts calls
+// taking up to 2^i milliseconds; the last one also collects anything slower.
+const latencyBuckets = 20
+
+// latencyHistogram accumulates the latencies of one tool without locking
+type latencyHistogram struct {
+	counts [latencyBuckets]atomic.Int64
+	max    atomic.Int64
+}
+
+func (h *latencyHistogram) record(d time.Duration) {
+	bucket := 0
+	for bucket < latencyBuckets-1 && d > bucketBound(bucket) {
+		bucket++
+	}
+
+	h.counts[bucket].Add(1)
+
+	for {
+		current := h.max.Load()
+		if int64(d) <= current || h.max.CompareAndSwap(current, int64(d)) {
+			return
+		}
+	}
+}
+
+// percentile returns the upper bound of the bucket containing the p-th
+// percentile, capped at the slowest call seen. The last bucket has no upper
+// bound, so percentiles falling into it are reported as the slowest call.
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	var total int64
+	for i := range h.counts {
+		total += h.counts[i].Load()
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	rank := int64(float64(total)*p + 0.5)
+	maxLatency := time.Duration(h.max.Load())
+
+	var seen int64
+
+	for i := range h.counts {
+		seen += h.counts[i].Load()
+		if seen >= rank && i < latencyBuckets-1 {
+			return min(bucketBound(i), maxLatency)
+		}
+	}
+
+	return maxLatency
+}
+
+// bucketBound returns the upper bound of a histogram bucket
+func bucketBound(bucket int) time.Duration {
+	return time.Millisecond << bucket
+}
+
+// latencyRecorder keeps a histogram per registered tool. Calls to other
+// names are ignored, since clients can send any tool name and each would
+// otherwise allocate a histogram for good.
+type latencyRecorder struct {
+	mu    sync.RWMutex
+	tools map[string]*latencyHistogram
+}
+
+func newLatencyRecorder() *latencyRecorder {
+	return &latencyRecorder{tools: map[string]*latencyHistogram{}}
+}
+
+// register adds a histogram for a tool, keeping any existing one
+func (r *latencyRecorder) register(tool string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tools[tool]; !ok {
+		r.tools[tool] = &latencyHistogram{}
+	}
+}
+
+func (r *latencyRecorder) record(tool string, d time.Duration) {
+	r.mu.RLock()
+	histogram, ok := r.tools[tool]
+	r.mu.RUnlock()
+
+	if ok {
+		histogram.record(d)
+	}
+}
+
+// log writes one record per called tool with its call count and p50, p95
+// and max latencies, in tool name order
+func (r *latencyRecorder) log(logger *slog.Logger) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, tool := range slices.Sorted(maps.Keys(r.tools)) {
+		histogram := r.tools[tool]
+
+		var calls int64
+		for i := range histogram.counts {
+			calls += histogram.counts[i].Load()
+		}
+
+		if calls == 0 {
+			continue
+		}
+
+		logger.Info("tool latency",
+			slog.String("tool", tool),
+			slog.Int64("calls", calls),
+			slog.Duration("p50", histogram.percentile(0.50)),
+			slog.Duration("p95", histogram.percentile(0.95)),
+			slog.Duration("max", time.Duration(histogram.max.Load())),
+		)
+	}
+}
+
+// recordLatencies measures how long each tool call takes
+func recordLatencies(recorder *latencyRecorder) mcpsdk.Middleware {
+	return func(next mcpsdk.MethodHandler) mcpsdk.MethodHandler {
+		return func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+			params, ok := req.GetParams().(*mcpsdk.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			defer func() { recorder.record(params.Name, time.Since(start)) }()
+
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// logLatencies logs the latency summary when latency logging is enabled
+func (s *MCPServer) logLatencies() {
+	if s.latencyLogger != nil {
+		s.latencies.log(s.latencyLogger)
+	}
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// latencyRecord is a "tool latency" log record as written by slog's JSON handler
+type latencyRecord struct {
+	Msg   string `json:"msg"`
+	Tool  string `json:"tool"`
+	Calls int64  `json:"calls"`
+	P50   int64  `json:"p50"`
+	P95   int64  `json:"p95"`
+	Max   int64  `json:"max"`
+}
+
+// latencyLog returns a logger writing JSON records and a function decoding them
+func latencyLog(t *testing.T) (*slog.Logger, func() []latencyRecord) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	return slog.New(slog.NewJSONHandler(&buf, nil)), func() []latencyRecord {
+		var records []latencyRecord
+
+		decoder := json.NewDecoder(&buf)
+		for decoder.More() {
+			var record latencyRecord
+			if err := decoder.Decode(&record); err != nil {
+				t.Fatal(err)
+			}
+
+			records = append(records, record)
+		}
+
+		return records
+	}
+}
+
+func TestLatencySummary(t *testing.T) {
+	// find_duplicates is registered but never called, so it isn't logged
+	recorder := newLatencyRecorder()
+	for _, tool := range []string{"check_links", "get_tags", "search_bookmarks", "find_duplicates"} {
+		recorder.register(tool)
+	}
+
+	// 1ms to 100ms: p50 falls into the 64ms bucket, p95 into the 128ms one,
+	// which is capped at the slowest call
+	for i := 1; i <= 100; i++ {
+		recorder.record("search_bookmarks", time.Duration(i)*time.Millisecond)
+	}
+
+	recorder.record("get_tags", 3*time.Millisecond)
+	// Slower than the last bucket
+	recorder.record("check_links", 20*time.Minute)
+	// Not a registered tool
+	recorder.record("made_up", time.Millisecond)
+
+	logger, records := latencyLog(t)
+	recorder.log(logger)
+
+	want := []latencyRecord{
+		{Msg: "tool latency", Tool: "check_links", Calls: 1, P50: int64(20 * time.Minute), P95: int64(20 * time.Minute), Max: int64(20 * time.Minute)},
+		{Msg: "tool latency", Tool: "get_tags", Calls: 1, P50: int64(3 * time.Millisecond), P95: int64(3 * time.Millisecond), Max: int64(3 * time.Millisecond)},
+		{Msg: "tool latency", Tool: "search_bookmarks", Calls: 100, P50: int64(64 * time.Millisecond), P95: int64(100 * time.Millisecond), Max: int64(100 * time.Millisecond)},
+	}
+
+	if got := records(); !slices.Equal(got, want) {
+		t.Errorf("logged %+v, want %+v", got, want)
+	}
+}
+
+func TestLatencyHistogramPercentile(t *testing.T) {
+	tests := []struct {
+		name      string
+		latencies []time.Duration
+		p         float64
+		want      time.Duration
+	}{
+		{name: "no calls", p: 0.5, want: 0},
+		{name: "bucket bound", latencies: []time.Duration{3 * time.Millisecond, 5 * time.Millisecond, 6 * time.Millisecond, 7 * time.Millisecond, 20 * time.Millisecond}, p: 0.5, want: 8 * time.Millisecond},
+		{name: "capped at the maximum", latencies: []time.Duration{5 * time.Millisecond, 6 * time.Millisecond}, p: 0.95, want: 6 * time.Millisecond},
+		{name: "sub-millisecond", latencies: []time.Duration{100 * time.Microsecond, 200 * time.Microsecond}, p: 0.5, want: 200 * time.Microsecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var histogram latencyHistogram
+			for _, d := range tt.latencies {
+				histogram.record(d)
+			}
+
+			if got := histogram.percentile(tt.p); got != tt.want {
+				t.Errorf("percentile(%v) = %s, want %s", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatencyLogging(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		wantTools []string
+	}{
+		{name: "disabled by default"},
+		{name: "enabled", enabled: true, wantTools: []string{"get_tags", "search_bookmarks"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, records := latencyLog(t)
+
+			var opts []Option
+			if tt.enabled {
+				opts = append(opts, WithLatencyLogging(logger))
+			}
+
+			s := newTestServer(t, newFakeLinkding(t, linkding.Bookmark{URL: "https://a.example"}), opts...)
+
+			for _, tool := range []string{"search_bookmarks", "search_bookmarks", "get_tags"} {
+				callTool(t, s, tool, map[string]any{})
+			}
+
+			// Unknown tools are rejected by the SDK and not recorded
+			if _, err := connect(t, s, nil).CallTool(context.Background(), &mcpsdk.CallToolParams{Name: "made_up", Arguments: map[string]any{}}); err == nil {
+				t.Error("calling an unknown tool succeeded")
+			}
+
+			s.logLatencies()
+
+			var tools []string
+			for _, record := range records() {
+				tools = append(tools, record.Tool)
+
+				if record.Max <= 0 || record.P50 > record.Max || record.P95 > record.Max {
+					t.Errorf("%s latencies out of order: %+v", record.Tool, record)
+				}
+			}
+
+			if !slices.Equal(tools, tt.wantTools) {
+				t.Errorf("logged %v, want %v", tools, tt.wantTools)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultLinkCheckConcurrency = 5
+	maxLinkCheckConcurrency     = 20
+	// Per-URL timeout and total time budget, in seconds
+	defaultLinkCheckTimeout = 10
+	maxLinkCheckTimeout     = 60
+	defaultLinkCheckBudget  = 60
+	maxLinkCheckBudget      = 300
+)
+
+func (s *MCPServer) handleCheckLinks(ctx context.Context, req *mcpsdk.CallToolRequest, args CheckLinksArgs) (*mcpsdk.CallToolResult, LinkCheckResult, error) {
+	concurrency := clamp(args.Concurrency, defaultLinkCheckConcurrency, maxLinkCheckConcurrency)
+	timeout := time.Duration(clamp(args.Timeout, defaultLinkCheckTimeout, maxLinkCheckTimeout)) * time.Second
+	budget := time.Duration(clamp(args.Budget, defaultLinkCheckBudget, maxLinkCheckBudget)) * time.Second
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), LinkCheckResult{}, nil
+	}
+
+	// The budget bounds the whole check; links not checked in time are reported as such
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	statuses := make([]LinkStatus, len(bookmarks))
+	checked := make([]bool, len(bookmarks))
+	sem := make(chan struct{}, concurrency)
+	progress := newProgressReporter(ctx, req, len(bookmarks))
+
+	var wg sync.WaitGroup
+
+dispatch:
+	for i, bookmark := range bookmarks {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				progress.increment()
+				<-sem
+				wg.Done()
+			}()
+
+			status := s.checkLink(ctx, bookmark.URL, timeout)
+			status.ID = bookmark.ID
+
+			// A link cut short by the budget (not its own timeout) counts as unchecked
+			if ctx.Err() == nil {
+				statuses[i], checked[i] = status, true
+			}
+		}()
+	}
+
+	wg.Wait()
+	progress.stop()
+
+	checkResult := LinkCheckResult{Broken: []LinkStatus{}}
+
+	for i, status := range statuses {
+		switch {
+		case !checked[i]:
+			checkResult.Unchecked++
+		case status.OK:
+			checkResult.Checked++
+		default:
+			checkResult.Checked++
+			checkResult.Broken = append(checkResult.Broken, status)
+		}
+	}
+
+	result := fmt.Sprintf("Checked %s, %d broken", pluralize(checkResult.Checked, "link"), len(checkResult.Broken))
+	if checkResult.Unchecked > 0 {
+		result += fmt.Sprintf(", %d not checked within the %s budget", checkResult.Unchecked, budget)
+	}
+
+	result += "\n\n"
+
+	for _, status := range checkResult.Broken {
+		problem := status.Error
+		if problem == "" {
+			problem = fmt.Sprintf("status %d", status.StatusCode)
+		}
+
+		result += fmt.Sprintf("• %s (ID: %d): %s\n", status.URL, status.ID, problem)
+	}
+
+	return textResult(result), checkResult, nil
+}
+
+// checkLink requests a URL within timeout and reports whether it is reachable.
+// HEAD is tried first; servers rejecting it are retried with GET. Like other
+// page fetches, the URL must pass checkFetchURL.
+func (s *MCPServer) checkLink(ctx context.Context, rawURL string, timeout time.Duration) LinkStatus {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := LinkStatus{URL: rawURL}
+
+	err := s.checkFetchURL(ctx, rawURL)
+
+	var statusCode int
+	if err == nil {
+		statusCode, err = s.requestStatus(ctx, http.MethodHead, rawURL)
+	}
+
+	if err == nil && (statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented) {
+		statusCode, err = s.requestStatus(ctx, http.MethodGet, rawURL)
+	}
+
+	if err != nil {
+		status.Error = err.Error()
+
+		return status
+	}
+
+	status.StatusCode = statusCode
+	status.OK = statusCode < http.StatusBadRequest
+
+	return status
+}
+
+// requestStatus performs a request and returns the response status, discarding the body
+func (s *MCPServer) requestStatus(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := s.pageClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
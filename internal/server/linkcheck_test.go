@@ -0,0 +1,178 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+// linkTarget serves the pages checked by check_links: /ok answers 200,
+// /missing 404, /no-head rejects HEAD with 405, /slow answers after delay
+func linkTarget(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/slow":
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestCheckLinks(t *testing.T) {
+	target := linkTarget(t, 2*time.Second)
+
+	tests := []struct {
+		name          string
+		paths         []string
+		args          map[string]any
+		private       bool
+		wantChecked   int
+		wantUnchecked int
+		wantBroken    []string
+		wantError     string
+	}{
+		{
+			name:        "statuses and HEAD fallback",
+			paths:       []string{"/ok", "/missing", "/no-head"},
+			args:        map[string]any{},
+			private:     true,
+			wantChecked: 3,
+			wantBroken:  []string{"/missing"},
+		},
+		{
+			name:        "per-link timeout",
+			paths:       []string{"/ok", "/slow"},
+			args:        map[string]any{"timeout": 1},
+			private:     true,
+			wantChecked: 2,
+			wantBroken:  []string{"/slow"},
+			wantError:   "deadline exceeded",
+		},
+		{
+			name:          "budget",
+			paths:         []string{"/slow", "/slow"},
+			args:          map[string]any{"timeout": 10, "budget": 1, "concurrency": 1},
+			private:       true,
+			wantUnchecked: 2,
+		},
+		{
+			name:        "private addresses refused by default",
+			paths:       []string{"/ok"},
+			args:        map[string]any{},
+			wantChecked: 1,
+			wantBroken:  []string{"/ok"},
+			wantError:   errDisallowedAddress.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bookmarks []linkding.Bookmark
+			for i, path := range tt.paths {
+				bookmarks = append(bookmarks, linkding.Bookmark{ID: i + 1, URL: target.URL + path})
+			}
+
+			opts := []Option{}
+			if tt.private {
+				opts = append(opts, WithPrivateFetches())
+			}
+
+			start := time.Now()
+
+			result := callTool(t, newTestServer(t, newFakeLinkding(t, bookmarks...), opts...), "check_links", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			// Neither the slow link nor the budget may hold the check up
+			if elapsed := time.Since(start); elapsed > 1500*time.Millisecond {
+				t.Errorf("check took %s", elapsed)
+			}
+
+			checkResult := structured[LinkCheckResult](t, result)
+
+			var broken []string
+			for _, status := range checkResult.Broken {
+				broken = append(broken, strings.TrimPrefix(status.URL, target.URL))
+
+				if !strings.Contains(status.Error, tt.wantError) {
+					t.Errorf("%s error = %q, want %q", status.URL, status.Error, tt.wantError)
+				}
+			}
+
+			if checkResult.Checked != tt.wantChecked || checkResult.Unchecked != tt.wantUnchecked || !slices.Equal(broken, tt.wantBroken) {
+				t.Errorf("checked %d, unchecked %d, broken %v; want %d, %d, %v",
+					checkResult.Checked, checkResult.Unchecked, broken, tt.wantChecked, tt.wantUnchecked, tt.wantBroken)
+			}
+		})
+	}
+}
+
+func TestCheckLinksConcurrency(t *testing.T) {
+	tests := []struct {
+		args map[string]any
+		want int32
+	}{
+		{args: map[string]any{}, want: defaultLinkCheckConcurrency},
+		{args: map[string]any{"concurrency": 2}, want: 2},
+		{args: map[string]any{"concurrency": 100}, want: maxLinkCheckConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.args), func(t *testing.T) {
+			var inFlight, peak atomic.Int32
+
+			target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+
+				for {
+					if current := peak.Load(); n <= current || peak.CompareAndSwap(current, n) {
+						break
+					}
+				}
+
+				time.Sleep(25 * time.Millisecond)
+			}))
+			t.Cleanup(target.Close)
+
+			var bookmarks []linkding.Bookmark
+			for i := range 3 * maxLinkCheckConcurrency {
+				bookmarks = append(bookmarks, linkding.Bookmark{URL: fmt.Sprintf("%s/%d", target.URL, i)})
+			}
+
+			result := callTool(t, newTestServer(t, newFakeLinkding(t, bookmarks...), WithPrivateFetches()), "check_links", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			if checked := structured[LinkCheckResult](t, result).Checked; checked != len(bookmarks) {
+				t.Errorf("checked %d links, want %d", checked, len(bookmarks))
+			}
+
+			if got := peak.Load(); got != tt.want {
+				t.Errorf("peak concurrency = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package server
+
+// marker identifies a status marker prefixed to tool output
+type marker int
+
+const (
+	markSuccess marker = iota
+	markFailure
+	markWarning
+	markDeleted
+	markArchived
+	markUnarchived
+	markLink
+	markNote
+	markEnriched
+	markTime
+	markBackup
+	markRestore
+)
+
+// markers maps each marker to its emoji and the plain ASCII used with WithoutEmoji
+var markers = map[marker]struct{ emoji, ascii string }{
+	markSuccess:    {"✅", "[OK]"},
+	markFailure:    {"❌", "[FAIL]"},
+	markWarning:    {"⚠️", "[WARN]"},
+	markDeleted:    {"🗑️", "[DELETED]"},
+	markArchived:   {"📦", "[ARCHIVED]"},
+	markUnarchived: {"📤", "[UNARCHIVED]"},
+	markLink:       {"🔗", "[LINK]"},
+	markNote:       {"📝", "[NOTE]"},
+	markEnriched:   {"✨", "[ENRICHED]"},
+	markTime:       {"⏱️", "[TIME]"},
+	markBackup:     {"💾", "[BACKUP]"},
+	markRestore:    {"♻️", "[RESTORED]"},
+}
+
+// mark returns the text of a marker, honoring WithoutEmoji
+func (s *MCPServer) mark(m marker) string {
+	if s.noEmoji {
+		return markers[m].ascii
+	}
+
+	return markers[m].emoji
+}
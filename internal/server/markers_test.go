@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+// hasEmoji reports whether text contains an emoji: a pictographic symbol or
+// the variation selector turning a character into its emoji form
+func hasEmoji(text string) bool {
+	return strings.ContainsFunc(text, func(r rune) bool {
+		return unicode.Is(unicode.So, r) || r == '️'
+	})
+}
+
+func TestWithoutEmoji(t *testing.T) {
+	calls := []struct {
+		tool string
+		args map[string]any
+	}{
+		{tool: "create_bookmark", args: map[string]any{"url": "https://new.example"}},
+		{tool: "update_bookmark", args: map[string]any{"id": 1, "title": "Renamed"}},
+		{tool: "archive_bookmark", args: map[string]any{"id": 1}},
+		{tool: "unarchive_bookmark", args: map[string]any{"id": 1}},
+		{tool: "append_note", args: map[string]any{"id": 1, "note": "x"}},
+		{tool: "get_share_link", args: map[string]any{"id": 1, "share": true}},
+		{tool: "archive_query", args: map[string]any{"query": "#go"}},
+		{tool: "delete_bookmarks", args: map[string]any{"ids": []int{2}, "confirm": true}},
+		{tool: "delete_bookmark", args: map[string]any{"id": 1, "confirm": true}},
+		{tool: "diagnose", args: map[string]any{}},
+	}
+
+	for _, noEmoji := range []bool{false, true} {
+		for _, call := range calls {
+			t.Run(fmt.Sprintf("%s no emoji %v", call.tool, noEmoji), func(t *testing.T) {
+				fake := newFakeLinkding(t,
+					linkding.Bookmark{ID: 1, URL: "https://a.example", Title: "A", TagNames: []string{"go"}},
+					linkding.Bookmark{ID: 2, URL: "https://b.example", Title: "B"},
+				)
+
+				var opts []Option
+				if noEmoji {
+					opts = append(opts, WithoutEmoji())
+				}
+
+				result := callTool(t, newTestServer(t, fake, opts...), call.tool, call.args)
+				if result.IsError {
+					t.Fatal(resultText(result))
+				}
+
+				// Without the option the output must use emoji, or this test
+				// wouldn't prove anything
+				if got := resultText(result); hasEmoji(got) == noEmoji {
+					t.Errorf("emoji in output = %v with WithoutEmoji = %v: %q", !noEmoji, noEmoji, got)
+				}
+			})
+		}
+	}
+}
+
+func TestMarkersComplete(t *testing.T) {
+	for m := markSuccess; m <= markRestore; m++ {
+		texts, ok := markers[m]
+		if !ok || !hasEmoji(texts.emoji) || hasEmoji(texts.ascii) || texts.ascii == "" {
+			t.Errorf("marker %d = %+v, want an emoji and a plain ASCII replacement", m, texts)
+		}
+	}
+}
@@ -2,108 +2,329 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/chickenzord/linkding-mcp/internal/version"
 	"github.com/chickenzord/linkding-mcp/pkg/linkding"
 	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// httpShutdownTimeout bounds how long RunHTTP waits for active requests when shutting down
+const httpShutdownTimeout = 10 * time.Second
+
 // MCPServer wraps the MCP SDK server
 type MCPServer struct {
-	linkdingClient *linkding.Client
-	mcpServer      *mcpsdk.Server
+	linkdingClient        BookmarkService
+	mcpServer             *mcpsdk.Server
+	defaultQuery          string
+	protocolVersion       string
+	instanceName          string
+	publicOnly            bool
+	maxBulkItems          int
+	bulkRetryBudget       int
+	toolTimeouts          map[string]time.Duration
+	defaultToolTimeout    time.Duration
+	bookmarkTemplate      *template.Template
+	backupDir             string
+	tools                 []*mcpsdk.Tool
+	noEmoji               bool
+	tokenProvider         func() string
+	acceptLanguage        string
+	tagVocabulary         []string
+	enforceVocabulary     bool
+	latencyLogger         *slog.Logger
+	latencies             *latencyRecorder
+	maxConcurrentRequests int
+	allowPrivateFetches   bool
+	pageClient            *http.Client
 }
 
+// RunHTTP serves MCP over the streamable HTTP transport.
+//
+// The handler factory is called once per new session and returns the same
+// server every time. This is safe: the SDK server is designed to host many
+// concurrent sessions (each gets its own ServerSession with isolated state),
+// and the tool handlers only read immutable configuration and share the
+// goroutine-safe Linkding client.
+//
+// Request contexts derive from ctx, and in-flight tool calls are cancelled
+// together with it (see cancelWithServeContext). Once ctx is done the server shuts down gracefully, waiting up
+// to httpShutdownTimeout for active requests.
+//
+// With WithMaxConcurrentRequests, requests beyond the limit are rejected with
+// 503 Service Unavailable.
 func (s *MCPServer) RunHTTP(ctx context.Context, bindAddress string) error {
-	httpHandler := mcpsdk.NewStreamableHTTPHandler(func(r *http.Request) *mcpsdk.Server {
+	listener, err := net.Listen("tcp", bindAddress)
+	if err != nil {
+		return err
+	}
+
+	return s.serveHTTP(ctx, listener)
+}
+
+// serveHTTP serves MCP over listener until ctx is done, as described for RunHTTP
+func (s *MCPServer) serveHTTP(ctx context.Context, listener net.Listener) error {
+	defer s.logLatencies()
+
+	httpServer := &http.Server{
+		Handler: s.httpHandler(),
+		BaseContext: func(net.Listener) context.Context {
+			return context.WithValue(ctx, serveContextKey{}, ctx)
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	shutdownErr := make(chan error, 1)
+
+	go func() {
+		<-ctx.Done()
+
+		// ctx is already done, so the shutdown deadline needs a fresh context
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), httpShutdownTimeout)
+		defer cancel()
+
+		shutdownErr <- httpServer.Shutdown(shutdownCtx)
+	}()
+
+	if err := httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return <-shutdownErr
+}
+
+// httpHandler returns the streamable HTTP handler served by RunHTTP
+func (s *MCPServer) httpHandler() http.Handler {
+	var handler http.Handler = mcpsdk.NewStreamableHTTPHandler(func(r *http.Request) *mcpsdk.Server {
 		return s.mcpServer
 	}, nil)
 
-	return http.ListenAndServe(bindAddress, httpHandler)
+	if s.maxConcurrentRequests > 0 {
+		handler = limitConcurrency(handler, s.maxConcurrentRequests)
+	}
+
+	return handler
 }
 
+// Warmup performs a lightweight request against Linkding to prime the
+// connection pool and validate the configured credentials.
+func (s *MCPServer) Warmup(ctx context.Context) error {
+	if s.publicOnly {
+		_, err := s.linkdingClient.Health(ctx)
+
+		return err
+	}
+
+	return s.linkdingClient.Ping(ctx)
+}
+
+// PublicOnly reports whether the server runs without an API token,
+// exposing only read-only tools for publicly shared bookmarks.
+func (s *MCPServer) PublicOnly() bool {
+	return s.publicOnly
+}
+
+// RunStdio serves MCP over stdin and stdout until the client closes stdin or
+// ctx is done. Both are a normal shutdown and return nil, like RunHTTP does
+// after shutting down gracefully.
 func (s *MCPServer) RunStdio(ctx context.Context) error {
-	return s.mcpServer.Run(ctx, &mcpsdk.StdioTransport{})
+	defer s.logLatencies()
+
+	err := s.mcpServer.Run(ctx, &mcpsdk.StdioTransport{})
+
+	// The SDK ends the session without an error when stdin is closed; io.EOF
+	// is still treated as a clean exit in case the transport surfaces it
+	if errors.Is(err, io.EOF) || (ctx.Err() != nil && errors.Is(err, ctx.Err())) {
+		return nil
+	}
+
+	return err
+}
+
+// textResult builds a successful tool result with a single text content
+func textResult(text string) *mcpsdk.CallToolResult {
+	return &mcpsdk.CallToolResult{
+		Content: []mcpsdk.Content{
+			&mcpsdk.TextContent{
+				Text: text,
+			},
+		},
+	}
+}
+
+// errorResult builds a failed tool result with a formatted text content
+func errorResult(format string, args ...any) *mcpsdk.CallToolResult {
+	return &mcpsdk.CallToolResult{
+		Content: []mcpsdk.Content{
+			&mcpsdk.TextContent{
+				Text: fmt.Sprintf(format, args...),
+			},
+		},
+		IsError: true,
+	}
 }
 
-func (s *MCPServer) handleSearchBookmarks(ctx context.Context, req *mcpsdk.CallToolRequest, args SearchBookmarksArgs) (*mcpsdk.CallToolResult, any, error) {
+func (s *MCPServer) handleSearchBookmarks(ctx context.Context, req *mcpsdk.CallToolRequest, args SearchBookmarksArgs) (*mcpsdk.CallToolResult, SearchBookmarksResult, error) {
 	limit := args.Limit
 	if limit == 0 {
 		limit = 20
 	}
 
-	bookmarks, err := s.linkdingClient.GetBookmarks(ctx, limit, 0, args.Query)
+	if args.Offset < 0 {
+		return errorResult("Offset must not be negative"), SearchBookmarksResult{}, nil
+	}
+
+	if err := validateOutputFields(args.Fields); err != nil {
+		return errorResult("Invalid fields: %v", err), SearchBookmarksResult{}, nil
+	}
+
+	opts := listOptions(args.Unread, args.Shared)
+
+	if args.Sort != "" {
+		if !slices.Contains(linkding.SortOrders, linkding.SortOrder(args.Sort)) {
+			return errorResult("Unknown sort order %q (supported: %v)", args.Sort, linkding.SortOrders), SearchBookmarksResult{}, nil
+		}
+
+		opts = append(opts, linkding.WithSort(linkding.SortOrder(args.Sort)))
+	}
+
+	bookmarks, err := s.linkdingClient.GetBookmarks(ctx, limit, args.Offset, s.searchQuery(args.Query), opts...)
 	if err != nil {
-		return &mcpsdk.CallToolResult{
-			Content: []mcpsdk.Content{
-				&mcpsdk.TextContent{
-					Text: fmt.Sprintf("Failed to search bookmarks: %v", err),
-				},
-			},
-			IsError: true,
-		}, nil, nil
+		return errorResult("Failed to search bookmarks: %v", err), SearchBookmarksResult{}, nil
 	}
 
-	result := fmt.Sprintf("Found %d bookmarks:\n\n", len(bookmarks.Results))
+	searchResult := SearchBookmarksResult{
+		Total:     bookmarks.Count,
+		Returned:  len(bookmarks.Results),
+		Truncated: bookmarks.Count > args.Offset+len(bookmarks.Results),
+		Bookmarks: summarizeBookmarks(bookmarks.Results).Bookmarks,
+	}
+
+	var result string
+
+	switch {
+	case args.Offset > 0 && searchResult.Returned > 0:
+		result = fmt.Sprintf("Showing %d-%d of %s:\n\n", args.Offset+1, args.Offset+searchResult.Returned, pluralize(searchResult.Total, "matching bookmark"))
+	case searchResult.Truncated:
+		result = fmt.Sprintf("Showing %d of %s:\n\n", searchResult.Returned, pluralize(searchResult.Total, "matching bookmark"))
+	default:
+		result = fmt.Sprintf("Found %s:\n\n", pluralize(searchResult.Returned, "bookmark"))
+	}
+
+	if searchResult.Truncated && searchResult.Returned > 0 {
+		searchResult.NextOffset = args.Offset + searchResult.Returned
+	}
+
+	// Sorting a single page only matters when there are other pages
+	searchResult.PageSorted = bookmarks.SortedLocally && (args.Offset > 0 || searchResult.Truncated)
+
 	for _, bookmark := range bookmarks.Results {
-		result += fmt.Sprintf("• **%s**\n  URL: %s\n", bookmark.Title, bookmark.URL)
+		result += s.formatBookmark(bookmark, args.Fields)
+	}
 
-		if bookmark.Description != "" {
-			result += fmt.Sprintf("  Description: %s\n", bookmark.Description)
-		}
+	if searchResult.PageSorted {
+		result += "\nLinkding ignored the sort order, so only the bookmarks on this page were sorted.\n"
+	}
 
-		if len(bookmark.TagNames) > 0 {
-			result += fmt.Sprintf("  Tags: %v\n", bookmark.TagNames)
-		}
+	if searchResult.NextOffset > 0 {
+		result += fmt.Sprintf("\nMore results available, pass offset %d to get the next page.\n", searchResult.NextOffset)
+	}
+
+	return textResult(result), searchResult, nil
+}
 
-		result += "\n"
+func (s *MCPServer) handleListURLs(ctx context.Context, req *mcpsdk.CallToolRequest, args ListURLsArgs) (*mcpsdk.CallToolResult, ListURLsResult, error) {
+	limit := args.Limit
+	if limit == 0 {
+		limit = 100
 	}
 
-	return &mcpsdk.CallToolResult{
-		Content: []mcpsdk.Content{
-			&mcpsdk.TextContent{
-				Text: result,
-			},
-		},
-	}, nil, nil
+	bookmarks, err := s.linkdingClient.GetBookmarks(ctx, limit, 0, s.searchQuery(args.Query), listOptions(args.Unread, args.Shared)...)
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), ListURLsResult{}, nil
+	}
+
+	if len(bookmarks.Results) == 0 {
+		return textResult("No bookmarks match the query"), ListURLsResult{URLs: []string{}}, nil
+	}
+
+	urlsResult := ListURLsResult{URLs: make([]string, len(bookmarks.Results))}
+	for i, bookmark := range bookmarks.Results {
+		urlsResult.URLs[i] = bookmark.URL
+	}
+
+	// Nothing but the URLs, one per line, to keep the output as small as possible
+	return textResult(strings.Join(urlsResult.URLs, "\n")), urlsResult, nil
+}
+
+// listOptions translates optional unread/shared filters into server-side list options
+func listOptions(unread, shared *bool) []linkding.ListOption {
+	var opts []linkding.ListOption
+
+	if unread != nil {
+		opts = append(opts, linkding.WithUnread(*unread))
+	}
+
+	if shared != nil {
+		opts = append(opts, linkding.WithShared(*shared))
+	}
+
+	return opts
+}
+
+// searchQuery combines the configured default query with the user's query.
+// Linkding ANDs all terms, so both must match.
+func (s *MCPServer) searchQuery(query string) string {
+	return strings.TrimSpace(s.defaultQuery + " " + query)
 }
 
 func (s *MCPServer) handleCreateBookmark(ctx context.Context, req *mcpsdk.CallToolRequest, args CreateBookmarkArgs) (*mcpsdk.CallToolResult, BookmarkResult, error) {
 	if args.URL == "" {
-		return &mcpsdk.CallToolResult{
-			Content: []mcpsdk.Content{
-				&mcpsdk.TextContent{
-					Text: "URL is required",
-				},
-			},
-			IsError: true,
-		}, BookmarkResult{}, nil
+		return errorResult("URL is required"), BookmarkResult{}, nil
 	}
 
 	createReq := linkding.CreateBookmarkRequest{
-		URL:         args.URL,
-		Title:       args.Title,
-		Description: args.Description,
-		TagNames:    args.Tags,
+		URL:             args.URL,
+		Title:           args.Title,
+		Description:     args.Description,
+		TagNames:        args.Tags,
+		DisableScraping: args.DisableScraping,
+		FaviconURL:      args.FaviconURL,
+		PreviewImageURL: args.PreviewImageURL,
 	}
 
-	bookmark, err := s.linkdingClient.CreateBookmark(ctx, createReq)
+	if disallowed := s.checkVocabulary(args.Tags); disallowed != nil {
+		return disallowed, BookmarkResult{}, nil
+	}
+
+	bookmark, created, err := s.linkdingClient.SaveBookmark(ctx, createReq)
 	if err != nil {
-		return &mcpsdk.CallToolResult{
-			Content: []mcpsdk.Content{
-				&mcpsdk.TextContent{
-					Text: fmt.Sprintf("Failed to create bookmark: %v", err),
-				},
-			},
-			IsError: true,
-		}, BookmarkResult{}, nil
+		if existing := s.duplicateOf(ctx, args.URL, err); existing != nil {
+			return alreadyBookmarked(existing)
+		}
+
+		return errorResult("Failed to create bookmark: %v", err), BookmarkResult{}, nil
 	}
 
-	result := fmt.Sprintf("✅ Bookmark created successfully!\n\n• **%s**\n  URL: %s\n  ID: %d",
-		bookmark.Title, bookmark.URL, bookmark.ID)
+	// Linkding silently updates the existing bookmark when a URL is added
+	// twice, answering 200 instead of 201
+	message := "Bookmark created successfully"
+	if !created {
+		message = fmt.Sprintf("Bookmark already existed (ID %d) and was updated", bookmark.ID)
+	}
+
+	result := fmt.Sprintf("%s %s!\n\n• **%s**\n  URL: %s\n  ID: %d",
+		s.mark(markSuccess), message, bookmark.Title, bookmark.URL, bookmark.ID)
 
 	if bookmark.Description != "" {
 		result += fmt.Sprintf("\n  Description: %s", bookmark.Description)
@@ -120,66 +341,135 @@ func (s *MCPServer) handleCreateBookmark(ctx context.Context, req *mcpsdk.CallTo
 		Description: bookmark.Description,
 		Tags:        bookmark.TagNames,
 		Success:     true,
-		Message:     "Bookmark created successfully",
+		Message:     message,
 	}
 
-	return &mcpsdk.CallToolResult{
-		Content: []mcpsdk.Content{
-			&mcpsdk.TextContent{
-				Text: result,
-			},
-		},
-	}, bookmarkResult, nil
+	return textResult(result), bookmarkResult, nil
 }
 
-func (s *MCPServer) handleGetTags(ctx context.Context, req *mcpsdk.CallToolRequest, args GetTagsArgs) (*mcpsdk.CallToolResult, any, error) {
+// alreadyBookmarked reports that a URL passed to create_bookmark is already bookmarked
+func alreadyBookmarked(bookmark *linkding.Bookmark) (*mcpsdk.CallToolResult, BookmarkResult, error) {
+	result := fmt.Sprintf("URL is already bookmarked (ID %d), nothing was created\n\n• **%s**\n  URL: %s\n\nUse the update tools to change the existing bookmark.",
+		bookmark.ID, bookmark.Title, bookmark.URL)
+
+	return textResult(result), BookmarkResult{
+		ID:          bookmark.ID,
+		URL:         bookmark.URL,
+		Title:       bookmark.Title,
+		Description: bookmark.Description,
+		Tags:        bookmark.TagNames,
+		Success:     false,
+		Message:     fmt.Sprintf("already bookmarked (ID %d)", bookmark.ID),
+	}, nil
+}
+
+// duplicateOf returns the existing bookmark when a create failed because the URL
+// is already bookmarked, as reported by a 400 or 409 response with a url error
+func (s *MCPServer) duplicateOf(ctx context.Context, rawURL string, err error) *linkding.Bookmark {
+	var apiErr *linkding.APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+
+	if apiErr.StatusCode != http.StatusConflict && (apiErr.StatusCode != http.StatusBadRequest || len(apiErr.FieldErrors["url"]) == 0) {
+		return nil
+	}
+
+	check, checkErr := s.linkdingClient.CheckBookmark(ctx, rawURL)
+	if checkErr != nil || check.Bookmark == nil {
+		return nil
+	}
+
+	return check.Bookmark
+}
+
+func (s *MCPServer) handleGetTags(ctx context.Context, req *mcpsdk.CallToolRequest, args GetTagsArgs) (*mcpsdk.CallToolResult, TagsResult, error) {
 	limit := args.Limit
 	if limit == 0 {
 		limit = 50
 	}
 
-	tags, err := s.linkdingClient.GetTags(ctx, limit, 0)
-	if err != nil {
-		return &mcpsdk.CallToolResult{
-			Content: []mcpsdk.Content{
-				&mcpsdk.TextContent{
-					Text: fmt.Sprintf("Failed to get tags: %v", err),
-				},
-			},
-			IsError: true,
-		}, nil, nil
+	var tags []linkding.Tag
+
+	if args.All {
+		allTags, err := s.linkdingClient.GetAllTags(ctx)
+		if err != nil {
+			return errorResult("Failed to get tags: %v", err), TagsResult{}, nil
+		}
+
+		tags = allTags
+	} else {
+		page, err := s.linkdingClient.GetTags(ctx, limit, 0)
+		if err != nil {
+			return errorResult("Failed to get tags: %v", err), TagsResult{}, nil
+		}
+
+		tags = page.Results
 	}
 
-	if len(tags.Results) == 0 {
-		return &mcpsdk.CallToolResult{
-			Content: []mcpsdk.Content{
-				&mcpsdk.TextContent{
-					Text: "No tags found",
-				},
-			},
-		}, nil, nil
+	if len(tags) == 0 {
+		return textResult("No tags found"), TagsResult{}, nil
 	}
 
-	result := fmt.Sprintf("Found %d tags:\n\n", len(tags.Results))
-	for _, tag := range tags.Results {
-		result += fmt.Sprintf("• %s (ID: %s)\n", tag.Name, strconv.Itoa(tag.ID))
+	var counts map[string]int
+
+	if args.WithCounts {
+		var err error
+
+		counts, err = s.countTagUsage(ctx)
+		if err != nil {
+			return errorResult("Failed to count tag usage: %v", err), TagsResult{}, nil
+		}
 	}
 
-	return &mcpsdk.CallToolResult{
-		Content: []mcpsdk.Content{
-			&mcpsdk.TextContent{
-				Text: result,
-			},
-		},
-	}, nil, nil
+	tagsResult := TagsResult{Tags: make([]TagResult, 0, len(tags))}
+
+	result := fmt.Sprintf("Found %s:\n\n", pluralize(len(tags), "tag"))
+	for _, tag := range tags {
+		tagResult := TagResult{ID: tag.ID, Name: tag.Name}
+
+		if counts != nil {
+			count := counts[strings.ToLower(tag.Name)]
+			tagResult.Count = &count
+			result += fmt.Sprintf("• %s (ID: %s, %s)\n", tag.Name, strconv.Itoa(tag.ID), pluralize(count, "bookmark"))
+		} else {
+			result += fmt.Sprintf("• %s (ID: %s)\n", tag.Name, strconv.Itoa(tag.ID))
+		}
+
+		tagsResult.Tags = append(tagsResult.Tags, tagResult)
+	}
+
+	return textResult(result), tagsResult, nil
 }
 
 // NewMCP creates a new MCP server using the official MCP Go SDK
-func NewMCP(linkdingURL, apiToken string) *MCPServer {
+func NewMCP(linkdingURL, apiToken string, opts ...Option) *MCPServer {
 	s := &MCPServer{
-		linkdingClient: linkding.NewClient(linkdingURL, apiToken),
+		maxBulkItems:       defaultMaxBulkItems,
+		bulkRetryBudget:    defaultBulkRetryBudget,
+		toolTimeouts:       defaultToolTimeouts(),
+		defaultToolTimeout: defaultToolTimeout,
 	}
 
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.linkdingClient == nil {
+		var clientOpts []linkding.Option
+		if s.tokenProvider != nil {
+			clientOpts = append(clientOpts, linkding.WithTokenProvider(s.tokenProvider))
+		}
+
+		if s.acceptLanguage != "" {
+			clientOpts = append(clientOpts, linkding.WithAcceptLanguage(s.acceptLanguage))
+		}
+
+		s.linkdingClient = linkding.NewClient(linkdingURL, apiToken, clientOpts...)
+	}
+
+	s.pageClient = newPageClient(s.allowPrivateFetches)
+
 	// Create MCP server with implementation info
 	versionInfo := version.Get()
 	mcpServer := mcpsdk.NewServer(&mcpsdk.Implementation{
@@ -188,25 +478,300 @@ func NewMCP(linkdingURL, apiToken string) *MCPServer {
 		Title:   "Linkding MCP Server",
 	}, nil)
 
+	mcpServer.AddReceivingMiddleware(toolTimeouts(s.toolTimeouts, s.defaultToolTimeout))
+	mcpServer.AddReceivingMiddleware(cancelWithServeContext)
+
+	if s.latencyLogger != nil {
+		s.latencies = newLatencyRecorder()
+		mcpServer.AddReceivingMiddleware(recordLatencies(s.latencies))
+	}
+
+	if s.protocolVersion != "" {
+		mcpServer.AddReceivingMiddleware(pinProtocolVersion(s.protocolVersion))
+	}
+
+	if s.instanceName != "" {
+		mcpServer.AddReceivingMiddleware(prefixInstanceName(s.instanceName))
+	}
+
+	// The SDK advertises the tools, resources and prompts capabilities in the
+	// initialize result based on what is registered here, so only features
+	// actually served are declared (e.g. no resources in public mode, and no
+	// prompts until some are added).
+	if apiToken == "" && s.tokenProvider == nil {
+		// Without a token only the public shared feed can be read
+		s.publicOnly = true
+		s.addPublicTools(mcpServer)
+	} else {
+		s.addTools(mcpServer)
+		s.addResources(mcpServer)
+
+		if s.backupDir != "" {
+			s.addFileTools(mcpServer)
+		}
+
+		if len(s.tagVocabulary) > 0 {
+			// Add validate_tags tool
+			addTool(s, mcpServer, &mcpsdk.Tool{
+				Name:        "validate_tags",
+				Description: "Check proposed tags against the allowed tag vocabulary and suggest the closest allowed tags for disallowed ones",
+			}, s.handleValidateTags)
+		}
+	}
+
+	// Add list_capabilities tool, describing every tool registered above and itself
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "list_capabilities",
+		Description: "List the tools this server offers with a summary of their parameters",
+	}, s.handleListCapabilities)
+
+	s.mcpServer = mcpServer
+
+	return s
+}
+
+// addTools registers the tools available with an API token
+func (s *MCPServer) addTools(mcpServer *mcpsdk.Server) {
 	// Add search_bookmarks tool
-	mcpsdk.AddTool(mcpServer, &mcpsdk.Tool{
+	addTool(s, mcpServer, &mcpsdk.Tool{
 		Name:        "search_bookmarks",
 		Description: "Search bookmarks in Linkding",
 	}, s.handleSearchBookmarks)
 
+	// Add list_urls tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "list_urls",
+		Description: "List only the URLs of bookmarks matching a search, one per line. The most token-efficient way to get matching links",
+	}, s.handleListURLs)
+
+	// Add advanced_search tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "advanced_search",
+		Description: "Search bookmarks with matching scoped to specific fields (title, description, notes, url), reporting which fields matched",
+	}, s.handleAdvancedSearch)
+
+	// Add bookmarks_by_date_range tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "bookmarks_by_date_range",
+		Description: "List bookmarks added within a date range, including archived ones, sorted chronologically",
+	}, s.handleBookmarksByDateRange)
+
+	// Add tag_trends tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "tag_trends",
+		Description: "Show how tag usage evolved over recent months, as monthly bookmark counts per tag",
+	}, s.handleTagTrends)
+
+	// Add daily_digest tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "daily_digest",
+		Description: "Render a markdown digest of the bookmarks added in the last hours (24 by default), grouped by tag, ready to paste into a note or email",
+	}, s.handleDailyDigest)
+
+	// Add suggest_archive_candidates tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "suggest_archive_candidates",
+		Description: "Suggest read bookmarks older than an age threshold that could be archived to declutter the library, oldest first",
+	}, s.handleSuggestArchiveCandidates)
+
+	// Add sharing_report tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "sharing_report",
+		Description: "Summarize how many bookmarks are shared publicly versus private, overall and per tag, to review the public footprint of the library",
+	}, s.handleSharingReport)
+
+	// Add export_reading_list tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "export_reading_list",
+		Description: "Export unread bookmarks added within a date range as a markdown checkbox list with links and tags, suitable for a to-do note",
+	}, s.handleExportReadingList)
+
+	// Add list_domains tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "list_domains",
+		Description: "Count bookmarks per website domain, most bookmarked first, to see where bookmarks come from",
+	}, s.handleListDomains)
+
+	// Add search_by_domain tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "search_by_domain",
+		Description: "Find all bookmarks on a website domain, including its subdomains",
+	}, s.handleSearchByDomain)
+
+	// Add find_similar_titles tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "find_similar_titles",
+		Description: "Group bookmarks with near-identical titles but different URLs, e.g. the same article saved from several sites, so they can be consolidated",
+	}, s.handleFindSimilarTitles)
+
 	// Add create_bookmark tool
-	mcpsdk.AddTool(mcpServer, &mcpsdk.Tool{
+	addTool(s, mcpServer, &mcpsdk.Tool{
 		Name:        "create_bookmark",
 		Description: "Create a new bookmark in Linkding",
 	}, s.handleCreateBookmark)
 
+	// Add import_csv tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "import_csv",
+		Description: "Import bookmarks from CSV content with url, title and tags columns (e.g. a Pocket or Instapaper export), skipping URLs that are already bookmarked",
+	}, s.handleImportCSV)
+
+	// Add update_bookmark tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "update_bookmark",
+		Description: "Update a bookmark's URL, title, description, notes or tags. Omitted fields keep their current value; tags replace the current tags",
+	}, s.handleUpdateBookmark)
+
 	// Add get_tags tool
-	mcpsdk.AddTool(mcpServer, &mcpsdk.Tool{
+	addTool(s, mcpServer, &mcpsdk.Tool{
 		Name:        "get_tags",
 		Description: "Get all available tags from Linkding",
 	}, s.handleGetTags)
 
-	s.mcpServer = mcpServer
+	// Add find_orphaned_tags tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "find_orphaned_tags",
+		Description: "Report tags that aren't attached to any bookmark, including archived ones. Report only: Linkding's API doesn't support deleting tags",
+	}, s.handleFindOrphanedTags)
+
+	// Add export_tags tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "export_tags",
+		Description: "Export all tags as a sorted markdown list, nested as a tree when tags use parent/child names",
+	}, s.handleExportTags)
+
+	// Add sync_tags tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "sync_tags",
+		Description: "Reconcile Linkding tags with a canonical tag list: creates missing tags and reports the ones that exist and the extras not in the list",
+	}, s.handleSyncTags)
+
+	// Add update_bookmarks tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "update_bookmarks",
+		Description: "Apply the same change (title, description, notes, read/shared state, tags) to many bookmarks at once",
+	}, s.handleUpdateBookmarks)
+
+	// Add mark_query_read tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "mark_query_read",
+		Description: "Mark every unread bookmark matching a search query as read",
+	}, s.handleMarkQueryRead)
+
+	// Add archive_query tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "archive_query",
+		Description: "Archive every bookmark matching a search query",
+	}, s.handleArchiveQuery)
+
+	// Add search_and_archive tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "search_and_archive",
+		Description: "Search bookmarks and archive all matches. Returns a preview of the matches unless confirm is true",
+	}, s.handleSearchAndArchive)
+
+	// Add search_and_delete tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "search_and_delete",
+		Description: "Search bookmarks and permanently delete all matches. Returns a preview of the matches unless confirm is true, then requires user confirmation",
+	}, s.handleSearchAndDelete)
+
+	// Add delete_bookmarks tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "delete_bookmarks",
+		Description: "Permanently delete one or more bookmarks from Linkding. Requires user confirmation",
+	}, s.handleDeleteBookmarks)
+
+	// Add archive_bookmark tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "archive_bookmark",
+		Description: "Archive a single bookmark by ID, hiding it from regular searches",
+	}, s.handleArchiveBookmark)
+
+	// Add unarchive_bookmark tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "unarchive_bookmark",
+		Description: "Restore an archived bookmark by ID to the regular bookmarks",
+	}, s.handleUnarchiveBookmark)
+
+	// Add delete_bookmark tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "delete_bookmark",
+		Description: "Permanently delete a single bookmark by ID. Requires user confirmation",
+	}, s.handleDeleteBookmark)
+
+	// Add delete_bookmark_by_url tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "delete_bookmark_by_url",
+		Description: "Permanently delete the bookmark of a URL, without looking up its ID first. Requires user confirmation",
+	}, s.handleDeleteBookmarkByURL)
+
+	// Add get_share_link tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "get_share_link",
+		Description: "Get the public share link of a shared bookmark, optionally sharing it first",
+	}, s.handleGetShareLink)
+
+	// Add enrich_bookmark tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "enrich_bookmark",
+		Description: "Fill in a bookmark's empty title and description from the live page metadata. Fields that are already set are left untouched",
+	}, s.handleEnrichBookmark)
+
+	// Add apply_tag_rules tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "apply_tag_rules",
+		Description: "Tag bookmarks automatically using rules, e.g. every bookmark on github.com gets \"dev\" or every title containing \"recipe\" gets \"cooking\". Only adds tags, never removes them. Use preview to see the changes first",
+	}, s.handleApplyTagRules)
+
+	// Add clean_urls tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "clean_urls",
+		Description: "Find bookmarks whose URLs contain tracking parameters (utm_*, fbclid, gclid, ...) and, with confirm, update them to the clean URLs",
+	}, s.handleCleanURLs)
+
+	// Add find_missing_favicons tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "find_missing_favicons",
+		Description: "Find bookmarks without a favicon, optionally asking Linkding to load them again",
+	}, s.handleFindMissingFavicons)
+
+	// Add append_note tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "append_note",
+		Description: "Append a timestamped markdown entry to a bookmark's notes, keeping the existing notes",
+	}, s.handleAppendNote)
+
+	// Add consolidate_bookmark_text tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "consolidate_bookmark_text",
+		Description: "Find redundant content between a bookmark's description and notes (e.g. duplicated text after an import) and optionally remove it from the notes",
+	}, s.handleConsolidateBookmarkText)
+
+	// Add estimate_reading_time tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "estimate_reading_time",
+		Description: "Give a rough estimate of how many minutes a bookmarked article takes to read, from the word count of the live page at 200 words per minute. Useful to prioritize a reading queue",
+	}, s.handleEstimateReadingTime)
+
+	// Add check_links tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "check_links",
+		Description: "Check whether bookmarked URLs are still reachable and report the broken ones. Bounded by a per-link timeout and a total time budget",
+	}, s.handleCheckLinks)
+
+	// Add diagnose tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "diagnose",
+		Description: "Check the Linkding connection end to end: reachability, API token, version and available features",
+	}, s.handleDiagnose)
+}
 
-	return s
+// addPublicTools registers the read-only tools available without an API token
+func (s *MCPServer) addPublicTools(mcpServer *mcpsdk.Server) {
+	// Add search_shared_bookmarks tool
+	addTool(s, mcpServer, &mcpsdk.Tool{
+		Name:        "search_shared_bookmarks",
+		Description: "Search the publicly shared bookmarks of Linkding (read-only)",
+	}, s.handleSearchSharedBookmarks)
 }
@@ -0,0 +1,346 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestWarmup(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		fail     int
+		wantPath string
+		wantErr  bool
+	}{
+		{name: "pings the API with a token", token: "secret", wantPath: "/api/"},
+		{name: "checks health in public mode", wantPath: "/health"},
+		{name: "reports invalid credentials", token: "wrong", fail: http.StatusUnauthorized, wantPath: "/api/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t)
+			fake.fail = func(r *http.Request) int { return tt.fail }
+
+			err := NewMCP(fake.URL, tt.token).Warmup(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Warmup() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			requests := fake.received(http.MethodGet, "/")
+			if len(requests) == 0 || requests[0].Path != tt.wantPath {
+				t.Fatalf("requests = %v, want GET %s", requests, tt.wantPath)
+			}
+
+			if got, want := requests[0].Header.Get("Authorization"), tokenHeader(tt.token); got != want {
+				t.Errorf("Authorization = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// tokenHeader returns the Authorization header sent for token, if any
+func tokenHeader(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	return "Token " + token
+}
+
+func TestHTTPConcurrentSessions(t *testing.T) {
+	const sessions = 8
+
+	fake := newFakeLinkding(t)
+	s := newTestServer(t, fake)
+
+	srv := httptest.NewServer(s.httpHandler())
+	t.Cleanup(srv.Close)
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids = map[string]bool{}
+	)
+
+	for i := range sessions {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+
+			session, err := client.Connect(context.Background(), &mcpsdk.StreamableClientTransport{Endpoint: srv.URL}, nil)
+			if err != nil {
+				t.Errorf("session %d: connect: %v", i, err)
+
+				return
+			}
+
+			defer func() { _ = session.Close() }()
+
+			mu.Lock()
+			ids[session.ID()] = true
+			mu.Unlock()
+
+			calls := []mcpsdk.CallToolParams{
+				{Name: "create_bookmark", Arguments: map[string]any{"url": fmt.Sprintf("https://example.com/%d", i)}},
+				{Name: "search_bookmarks", Arguments: map[string]any{"query": "example"}},
+				{Name: "get_tags", Arguments: map[string]any{}},
+			}
+
+			for _, params := range calls {
+				result, err := session.CallTool(context.Background(), &params)
+				if err != nil {
+					t.Errorf("session %d: call %s: %v", i, params.Name, err)
+				} else if result.IsError {
+					t.Errorf("session %d: %s failed: %s", i, params.Name, resultText(result))
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(ids) != sessions {
+		t.Errorf("got %d distinct sessions, want %d", len(ids), sessions)
+	}
+
+	if fake.count() != sessions {
+		t.Errorf("created %d bookmarks, want %d", fake.count(), sessions)
+	}
+}
+
+func TestWithClient(t *testing.T) {
+	tests := []struct {
+		name      string
+		serverURL string
+		token     string
+		client    func(fakeURL string) BookmarkService
+		wantAuth  string
+	}{
+		{name: "client built from the URL and token", token: "direct", wantAuth: "Token direct"},
+		{
+			name:      "injected client replaces URL and token",
+			serverURL: "http://unused.invalid",
+			token:     "unused",
+			client: func(fakeURL string) BookmarkService {
+				return linkding.NewClient(fakeURL, "injected", linkding.WithRetries(0))
+			},
+			wantAuth: "Token injected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{URL: "https://a.example"})
+
+			serverURL, opts := fake.URL, []Option{}
+			if tt.client != nil {
+				serverURL, opts = tt.serverURL, append(opts, WithClient(tt.client(fake.URL)))
+			}
+
+			result := callTool(t, NewMCP(serverURL, tt.token, opts...), "search_bookmarks", map[string]any{})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			requests := fake.received(http.MethodGet, "/api/bookmarks/")
+			if len(requests) != 1 || requests[0].Header.Get("Authorization") != tt.wantAuth {
+				t.Errorf("requests = %v, want one with Authorization %q", requests, tt.wantAuth)
+			}
+		})
+	}
+}
+
+func TestInitializeCapabilities(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		wantResources bool
+	}{
+		{name: "with a token", token: "test-token", wantResources: true},
+		{name: "public mode", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t)
+			client := linkding.NewClient(fake.URL, tt.token, linkding.WithRetries(0))
+
+			capabilities := connect(t, NewMCP(fake.URL, tt.token, WithClient(client)), nil).InitializeResult().Capabilities
+
+			if capabilities.Tools == nil {
+				t.Error("tools capability not advertised")
+			}
+
+			if got := capabilities.Resources != nil; got != tt.wantResources {
+				t.Errorf("resources advertised = %v, want %v", got, tt.wantResources)
+			}
+
+			// No prompts are registered, so none are advertised
+			if capabilities.Prompts != nil {
+				t.Errorf("prompts advertised: %+v", capabilities.Prompts)
+			}
+		})
+	}
+}
+
+// blockingService blocks GetBookmark until its context is done and reports
+// the context error on done
+type blockingService struct {
+	BookmarkService
+
+	started chan struct{}
+	done    chan error
+}
+
+func (s *blockingService) GetBookmark(ctx context.Context, _ int) (*linkding.Bookmark, error) {
+	close(s.started)
+	<-ctx.Done()
+	s.done <- ctx.Err()
+
+	return nil, ctx.Err()
+}
+
+func TestRunHTTPCancellation(t *testing.T) {
+	service := &blockingService{started: make(chan struct{}), done: make(chan error, 1)}
+	// No tool deadline, so only the cancellation of RunHTTP's context ends the call
+	s := NewMCP("http://linkding.invalid", "test-token", WithClient(service), WithToolTimeout("estimate_reading_time", 0))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	served := make(chan error, 1)
+
+	go func() { served <- s.serveHTTP(ctx, listener) }()
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+
+	// The server goes away during the test, so don't try to reconnect
+	transport := &mcpsdk.StreamableClientTransport{Endpoint: "http://" + listener.Addr().String(), MaxRetries: -1}
+
+	session, err := client.Connect(context.Background(), transport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = session.Close() }()
+
+	go func() {
+		_, _ = session.CallTool(context.Background(), &mcpsdk.CallToolParams{Name: "estimate_reading_time", Arguments: map[string]any{"id": 1}})
+	}()
+
+	select {
+	case <-service.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool call didn't reach the service")
+	}
+
+	cancel()
+
+	select {
+	case err := <-service.done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("handler context ended with %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler didn't observe the cancellation")
+	}
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("serveHTTP() = %v", err)
+		}
+	case <-time.After(httpShutdownTimeout + time.Second):
+		t.Fatal("server didn't shut down")
+	}
+}
+
+func TestRunStdio(t *testing.T) {
+	tests := []struct {
+		name string
+		// stop ends the session, by closing stdin or cancelling the context
+		stop func(stdin *os.File, cancel context.CancelFunc)
+	}{
+		{name: "stdin closed", stop: func(stdin *os.File, _ context.CancelFunc) { _ = stdin.Close() }},
+		{name: "context cancelled", stop: func(_ *os.File, cancel context.CancelFunc) { cancel() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdinReader, stdinWriter, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stdoutReader, stdoutWriter, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The stdio transport always uses the process's stdin and stdout
+			stdin, stdout := os.Stdin, os.Stdout
+			os.Stdin, os.Stdout = stdinReader, stdoutWriter
+
+			t.Cleanup(func() {
+				os.Stdin, os.Stdout = stdin, stdout
+
+				for _, f := range []*os.File{stdinReader, stdinWriter, stdoutReader, stdoutWriter} {
+					_ = f.Close()
+				}
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s := newTestServer(t, newFakeLinkding(t))
+
+			done := make(chan error, 1)
+
+			go func() { done <- s.RunStdio(ctx) }()
+
+			initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test-client","version":"v0.0.1"}}}` + "\n"
+			if _, err := stdinWriter.WriteString(initialize); err != nil {
+				t.Fatal(err)
+			}
+
+			response, err := bufio.NewReader(stdoutReader).ReadString('\n')
+			if err != nil || !strings.Contains(response, `"protocolVersion"`) {
+				t.Fatalf("initialize response = %q, %v", response, err)
+			}
+
+			tt.stop(stdinWriter, cancel)
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("RunStdio() = %v, want nil", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("RunStdio didn't return")
+			}
+		})
+	}
+}
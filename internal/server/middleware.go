@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SupportedProtocolVersions lists the MCP protocol versions implemented by
+// the MCP SDK in use, newest first. The SDK doesn't export its list, so this
+// mirrors it and must be updated together with the SDK.
+var SupportedProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// ValidateProtocolVersion returns an error if version isn't one of
+// SupportedProtocolVersions and therefore can't be pinned with WithProtocolVersion.
+func ValidateProtocolVersion(version string) error {
+	if slices.Contains(SupportedProtocolVersions, version) {
+		return nil
+	}
+
+	return fmt.Errorf("unsupported MCP protocol version %q (supported: %s)", version, strings.Join(SupportedProtocolVersions, ", "))
+}
+
+// pinProtocolVersion overrides the protocol version advertised in initialize
+// results, instead of the version negotiated by the SDK. Only the advertised
+// version changes: the SDK keeps speaking the protocol as it implements it.
+func pinProtocolVersion(version string) mcpsdk.Middleware {
+	return func(next mcpsdk.MethodHandler) mcpsdk.MethodHandler {
+		return func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+			result, err := next(ctx, method, req)
+
+			if initResult, ok := result.(*mcpsdk.InitializeResult); ok && err == nil {
+				initResult.ProtocolVersion = version
+			}
+
+			return result, err
+		}
+	}
+}
+
+// prefixInstanceName prepends "[name] " to the text output of every tool call,
+// so agents talking to several servers can tell which instance answered
+func prefixInstanceName(name string) mcpsdk.Middleware {
+	return func(next mcpsdk.MethodHandler) mcpsdk.MethodHandler {
+		return func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+			result, err := next(ctx, method, req)
+
+			if toolResult, ok := result.(*mcpsdk.CallToolResult); ok && err == nil && len(toolResult.Content) > 0 {
+				if text, ok := toolResult.Content[0].(*mcpsdk.TextContent); ok {
+					text.Text = "[" + name + "] " + text.Text
+				}
+			}
+
+			return result, err
+		}
+	}
+}
+
+// serveContextKey is the context key under which serveHTTP stores its context
+type serveContextKey struct{}
+
+// cancelWithServeContext cancels requests when the context passed to RunHTTP
+// is done. The SDK detaches request handling from the HTTP request contexts,
+// keeping only their values, so that cancellation would otherwise never reach
+// the tool handlers.
+func cancelWithServeContext(next mcpsdk.MethodHandler) mcpsdk.MethodHandler {
+	return func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+		serveCtx, ok := ctx.Value(serveContextKey{}).(context.Context)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stop := context.AfterFunc(serveCtx, cancel)
+		defer stop()
+
+		return next(ctx, method, req)
+	}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestPinProtocolVersion(t *testing.T) {
+	for _, version := range SupportedProtocolVersions {
+		t.Run(version, func(t *testing.T) {
+			if err := ValidateProtocolVersion(version); err != nil {
+				t.Fatal(err)
+			}
+
+			// The SDK client refuses versions it doesn't support, which keeps
+			// SupportedProtocolVersions in sync with the SDK
+			s := newTestServer(t, newFakeLinkding(t), WithProtocolVersion(version))
+			if got := connect(t, s, nil).InitializeResult().ProtocolVersion; got != version {
+				t.Errorf("advertised %q, want %q", got, version)
+			}
+		})
+	}
+}
+
+func TestValidateProtocolVersion(t *testing.T) {
+	for _, version := range []string{"", "2023-01-01", "2025-06-18 ", "latest"} {
+		if err := ValidateProtocolVersion(version); err == nil {
+			t.Errorf("ValidateProtocolVersion(%q) succeeded, want an error", version)
+		}
+	}
+
+	// An unsupported version can't be pinned: clients reject it
+	s := newTestServer(t, newFakeLinkding(t), WithProtocolVersion("2023-01-01"))
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+
+	serverSession, err := s.mcpServer.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = serverSession.Close() }()
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+	if session, err := client.Connect(context.Background(), clientTransport, nil); err == nil {
+		_ = session.Close()
+
+		t.Error("client accepted an unsupported protocol version")
+	}
+}
+
+func TestPrefixInstanceName(t *testing.T) {
+	fake := newFakeLinkding(t, linkding.Bookmark{URL: "https://a.example", Title: "A"})
+
+	tests := []struct {
+		name     string
+		instance string
+		tool     string
+		args     map[string]any
+		want     string
+	}{
+		{name: "prefixed", instance: "home", tool: "search_bookmarks", args: map[string]any{}, want: "[home] Found 1 bookmark"},
+		{name: "errors are prefixed too", instance: "work", tool: "archive_bookmark", args: map[string]any{"id": 99}, want: "[work] "},
+		{name: "no name configured", tool: "search_bookmarks", args: map[string]any{}, want: "Found 1 bookmark"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake, WithInstanceName(tt.instance)), tt.tool, tt.args)
+			if got := resultText(result); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("output %q doesn't start with %q", got, tt.want)
+			}
+		})
+	}
+}
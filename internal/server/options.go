@@ -0,0 +1,167 @@
+package server
+
+import (
+	"log/slog"
+	"text/template"
+	"time"
+)
+
+// Option configures optional behavior of an MCPServer.
+type Option func(*MCPServer)
+
+// WithDefaultQuery sets a baseline Linkding query combined with every search.
+// Linkding ANDs search terms, so the default narrows each search further
+// (e.g. "-#noisy" hides a noisy tag everywhere).
+func WithDefaultQuery(query string) Option {
+	return func(s *MCPServer) {
+		s.defaultQuery = query
+	}
+}
+
+// WithProtocolVersion pins the MCP protocol version advertised to clients
+// (e.g. "2024-11-05") for compatibility with clients expecting a specific version.
+// When unset, the SDK negotiates the version, preferring its latest.
+//
+// Only the version in the initialize result changes, not the messages the
+// server sends, so version should be one of SupportedProtocolVersions (see
+// ValidateProtocolVersion).
+func WithProtocolVersion(version string) Option {
+	return func(s *MCPServer) {
+		s.protocolVersion = version
+	}
+}
+
+// WithInstanceName sets a friendly name prefixed to every tool output, e.g. "[home] Found 5 bookmarks".
+func WithInstanceName(name string) Option {
+	return func(s *MCPServer) {
+		s.instanceName = name
+	}
+}
+
+// WithMaxBulkItems caps how many bookmarks a single bulk tool call may process.
+// Larger requests are rejected, asking the agent to split them. Defaults to 500.
+func WithMaxBulkItems(n int) Option {
+	return func(s *MCPServer) {
+		if n > 0 {
+			s.maxBulkItems = n
+		}
+	}
+}
+
+// WithBulkRetryBudget caps the total number of retries across all Linkding
+// requests of a single bulk tool call, regardless of how many items fail
+// transiently. Zero disables retries in bulk tools. Defaults to 20.
+func WithBulkRetryBudget(n int) Option {
+	return func(s *MCPServer) {
+		if n >= 0 {
+			s.bulkRetryBudget = n
+		}
+	}
+}
+
+// WithToolTimeout overrides the deadline of a single tool, e.g. to give
+// archive_query more time on large libraries. A zero duration removes the
+// deadline, leaving only the Linkding client's per-request timeout.
+func WithToolTimeout(tool string, timeout time.Duration) Option {
+	return func(s *MCPServer) {
+		s.toolTimeouts[tool] = timeout
+	}
+}
+
+// WithDefaultToolTimeout sets the deadline of tools without one of their own
+// (see WithToolTimeout). Defaults to one minute; zero removes it, leaving
+// those tools only bounded by the Linkding client's per-request timeout.
+func WithDefaultToolTimeout(timeout time.Duration) Option {
+	return func(s *MCPServer) {
+		s.defaultToolTimeout = timeout
+	}
+}
+
+// WithBookmarkTemplate customizes how search_bookmarks renders each bookmark.
+// The template is executed with a BookmarkSummary, so it can use fields like
+// .Title, .URL, .Description and .Tags. A nil template keeps the built-in format.
+func WithBookmarkTemplate(tmpl *template.Template) Option {
+	return func(s *MCPServer) {
+		s.bookmarkTemplate = tmpl
+	}
+}
+
+// WithBackupDir enables the backup and restore tools, confining the files
+// they read and write to dir. The tools aren't registered without it.
+func WithBackupDir(dir string) Option {
+	return func(s *MCPServer) {
+		s.backupDir = dir
+	}
+}
+
+// WithoutEmoji replaces the emoji markers in tool output (e.g. ✅, 🗑️) with
+// plain ASCII ones like [OK] and [DELETED], for clients that render emoji poorly.
+func WithoutEmoji() Option {
+	return func(s *MCPServer) {
+		s.noEmoji = true
+	}
+}
+
+// WithPrivateFetches allows the tools fetching bookmarked pages to reach
+// private, loopback and link-local addresses, e.g. for bookmarks of intranet
+// pages. They are refused by default so an agent can't probe the network.
+func WithPrivateFetches() Option {
+	return func(s *MCPServer) {
+		s.allowPrivateFetches = true
+	}
+}
+
+// WithTokenProvider makes the Linkding client ask provider for the current
+// API token on every request, so a rotated token is picked up without a
+// restart. The server runs with full access even if the initial token is
+// empty. It has no effect together with WithClient.
+func WithTokenProvider(provider func() string) Option {
+	return func(s *MCPServer) {
+		s.tokenProvider = provider
+	}
+}
+
+// WithAcceptLanguage makes the Linkding client send language as the
+// Accept-Language header when creating bookmarks and checking URLs (see
+// linkding.WithAcceptLanguage). It has no effect together with WithClient.
+func WithAcceptLanguage(language string) Option {
+	return func(s *MCPServer) {
+		s.acceptLanguage = language
+	}
+}
+
+// WithTagVocabulary restricts tags to the given vocabulary and enables the
+// validate_tags tool. When enforce is true, create_bookmark and
+// update_bookmarks reject tags outside the vocabulary.
+func WithTagVocabulary(tags []string, enforce bool) Option {
+	return func(s *MCPServer) {
+		s.tagVocabulary = tags
+		s.enforceVocabulary = enforce
+	}
+}
+
+// WithLatencyLogging records how long each tool call takes and logs the
+// p50, p95 and maximum latency per tool to logger when the server stops.
+func WithLatencyLogging(logger *slog.Logger) Option {
+	return func(s *MCPServer) {
+		s.latencyLogger = logger
+	}
+}
+
+// WithMaxConcurrentRequests limits how many HTTP requests RunHTTP serves at
+// once, protecting small hosts from many simultaneous agents. Requests
+// beyond the limit get 503 Service Unavailable. Zero or less means no limit.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(s *MCPServer) {
+		s.maxConcurrentRequests = n
+	}
+}
+
+// WithClient makes the server use the given BookmarkService instead of
+// creating a Linkding client from the URL and token, e.g. a *linkding.Client
+// configured with custom linkding.Option values, or a fake in tests.
+func WithClient(client BookmarkService) Option {
+	return func(s *MCPServer) {
+		s.linkdingClient = client
+	}
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// progressInterval is the minimum time between two progress notifications
+const progressInterval = 500 * time.Millisecond
+
+// progressReporter sends throttled progress notifications for a tool call.
+// Workers report processed items with increment; a single collector
+// goroutine counts them and sends the notifications, so the reported
+// progress only ever increases. It is a no-op when the client didn't
+// request progress via a progress token.
+type progressReporter struct {
+	session *mcpsdk.ServerSession
+	token   any
+	total   int
+
+	processed chan struct{}
+	stopped   chan struct{}
+}
+
+// newProgressReporter creates a reporter for a tool call processing total
+// items. Call stop once all items are processed.
+func newProgressReporter(ctx context.Context, req *mcpsdk.CallToolRequest, total int) *progressReporter {
+	p := &progressReporter{total: total}
+
+	if req == nil || req.Session == nil || req.Params == nil || req.Params.GetProgressToken() == nil {
+		return p
+	}
+
+	p.session = req.Session
+	p.token = req.Params.GetProgressToken()
+	// Buffered for every item, so workers never wait for a notification to be sent
+	p.processed = make(chan struct{}, total)
+	p.stopped = make(chan struct{})
+
+	go p.collect(ctx)
+
+	return p
+}
+
+// increment records one processed item
+func (p *progressReporter) increment() {
+	if p.processed != nil {
+		p.processed <- struct{}{}
+	}
+}
+
+// stop waits until the progress of every recorded item has been handled.
+// increment must not be called afterwards.
+func (p *progressReporter) stop() {
+	if p.processed != nil {
+		close(p.processed)
+		<-p.stopped
+	}
+}
+
+// collect counts the processed items, notifying the client if the interval
+// has elapsed or all items are done
+func (p *progressReporter) collect(ctx context.Context) {
+	defer close(p.stopped)
+
+	var (
+		done     int
+		lastSent time.Time
+	)
+
+	for range p.processed {
+		done++
+
+		if done < p.total && time.Since(lastSent) < progressInterval {
+			continue
+		}
+
+		lastSent = time.Now()
+
+		// Progress is best-effort; a failed notification must not fail the tool.
+		_ = p.session.NotifyProgress(ctx, &mcpsdk.ProgressNotificationParams{
+			ProgressToken: p.token,
+			Progress:      float64(done),
+			Total:         float64(p.total),
+			Message:       fmt.Sprintf("Processed %d of %d", done, p.total),
+		})
+	}
+}
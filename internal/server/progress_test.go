@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestProgressNotifications(t *testing.T) {
+	tests := []struct {
+		name  string
+		items int
+		delay time.Duration
+		token bool
+		// wantMin is the minimum number of notifications; every run sends
+		// fewer notifications than items, since they are throttled
+		wantMin int
+	}{
+		{name: "no progress token", items: 10, delay: 10 * time.Millisecond},
+		{name: "fast run sends the first and last", items: 20, delay: 10 * time.Millisecond, token: true, wantMin: 2},
+		// Three rounds of bulkConcurrency items, each round longer than half
+		// the interval, so at least one notification falls in between
+		{name: "slow run sends intermediate progress", items: 3 * bulkConcurrency, delay: 300 * time.Millisecond, token: true, wantMin: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				bookmarks []linkding.Bookmark
+				ids       []int
+			)
+
+			for i := range tt.items {
+				bookmarks = append(bookmarks, linkding.Bookmark{ID: i + 1, URL: fmt.Sprintf("https://example.com/%d", i)})
+				ids = append(ids, i+1)
+			}
+
+			fake := newFakeLinkding(t, bookmarks...)
+			fake.fail = func(r *http.Request) int {
+				if r.Method == http.MethodPatch {
+					time.Sleep(tt.delay)
+				}
+
+				return 0
+			}
+
+			var (
+				mu       sync.Mutex
+				progress []float64
+			)
+
+			session := connect(t, newTestServer(t, fake), &mcpsdk.ClientOptions{
+				ProgressNotificationHandler: func(_ context.Context, req *mcpsdk.ProgressNotificationClientRequest) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					progress = append(progress, req.Params.Progress)
+				},
+			})
+
+			// SetProgressToken only works on existing metadata
+			params := &mcpsdk.CallToolParams{Meta: mcpsdk.Meta{}, Name: "update_bookmarks", Arguments: map[string]any{"ids": ids, "title": "x"}}
+			if tt.token {
+				params.SetProgressToken("bulk")
+			}
+
+			if _, err := session.CallTool(context.Background(), params); err != nil {
+				t.Fatal(err)
+			}
+
+			// Notifications are handled asynchronously by the client; without
+			// a token, give stray ones a moment to arrive
+			deadline := time.Now().Add(time.Second)
+			if !tt.token {
+				deadline = time.Now().Add(100 * time.Millisecond)
+			}
+
+			for {
+				mu.Lock()
+				received := len(progress) > 0 && progress[len(progress)-1] == float64(tt.items)
+				mu.Unlock()
+
+				if received || time.Now().After(deadline) {
+					break
+				}
+
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !tt.token {
+				if len(progress) > 0 {
+					t.Errorf("got progress %v without a progress token", progress)
+				}
+
+				return
+			}
+
+			if len(progress) < tt.wantMin || len(progress) >= tt.items {
+				t.Errorf("got %d notifications %v, want between %d and %d", len(progress), progress, tt.wantMin, tt.items-1)
+			}
+
+			if len(progress) > 0 && (progress[0] != 1 || progress[len(progress)-1] != float64(tt.items)) {
+				t.Errorf("progress = %v, want it to run from 1 to %d", progress, tt.items)
+			}
+
+			if !slices.IsSorted(progress) {
+				t.Errorf("progress went backwards: %v", progress)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// wordsPerMinute is the average adult reading speed used for estimates
+	wordsPerMinute = 200
+	// maxPageFetchSize caps how much of a page is downloaded for text extraction
+	maxPageFetchSize = 2 << 20
+	// pageFetchTimeout bounds fetching a single page, independent of the Linkding client
+	pageFetchTimeout = 10 * time.Second
+)
+
+var (
+	nonContentPattern  = regexp.MustCompile(`(?is)<(script|style|noscript|head|nav|header|footer|aside)\b[^>]*>.*?</(script|style|noscript|head|nav|header|footer|aside)>`)
+	mainContentPattern = regexp.MustCompile(`(?is)<(article|main)\b[^>]*>(.*)</(article|main)>`)
+	tagPattern         = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+func (s *MCPServer) handleEstimateReadingTime(ctx context.Context, req *mcpsdk.CallToolRequest, args EstimateReadingTimeArgs) (*mcpsdk.CallToolResult, ReadingTimeResult, error) {
+	if args.ID == 0 {
+		return errorResult("Bookmark ID is required"), ReadingTimeResult{}, nil
+	}
+
+	bookmark, err := s.linkdingClient.GetBookmark(ctx, args.ID)
+	if err != nil {
+		if notFound := bookmarkNotFound(args.ID, err); notFound != nil {
+			return notFound, ReadingTimeResult{}, nil
+		}
+
+		return errorResult("Failed to get bookmark: %v", err), ReadingTimeResult{}, nil
+	}
+
+	readingResult := ReadingTimeResult{
+		ID:     bookmark.ID,
+		URL:    bookmark.URL,
+		Source: "page",
+	}
+
+	var note string
+
+	text, err := s.fetchPageText(ctx, bookmark.URL)
+	if err != nil || len(strings.Fields(text)) == 0 {
+		// Fall back to the description, which at least hints at the length of short pages
+		readingResult.Source = "description"
+		text = bookmark.Description
+
+		if err != nil {
+			note = fmt.Sprintf("\n\nThe page could not be fetched (%v), so the estimate is based on the description only.", err)
+		} else {
+			note = "\n\nNo readable text was found on the page, so the estimate is based on the description only."
+		}
+	}
+
+	readingResult.Words = len(strings.Fields(text))
+	readingResult.Minutes = readingMinutes(readingResult.Words)
+
+	result := fmt.Sprintf("%s **%s** takes roughly %s to read (%s at %d words per minute, a rough estimate)%s",
+		s.mark(markTime), bookmark.Title, pluralize(readingResult.Minutes, "minute"), pluralize(readingResult.Words, "word"), wordsPerMinute, note)
+
+	return textResult(result), readingResult, nil
+}
+
+// readingMinutes converts a word count to whole reading minutes, rounding up
+func readingMinutes(words int) int {
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// fetchPageText downloads an HTML page and returns its visible text,
+// preferring the <article> or <main> element when the page has one
+func (s *MCPServer) fetchPageText(ctx context.Context, rawURL string) (string, error) {
+	if err := s.checkFetchURL(ctx, rawURL); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pageFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.pageClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("page returned status %d", resp.StatusCode)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" && !strings.Contains(contentType, "html") {
+		return "", fmt.Errorf("page is not HTML (%s)", contentType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageFetchSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read page: %w", err)
+	}
+
+	return extractText(string(body)), nil
+}
+
+// extractText strips markup and non-content elements from an HTML document
+func extractText(page string) string {
+	page = nonContentPattern.ReplaceAllString(page, " ")
+
+	if match := mainContentPattern.FindStringSubmatch(page); match != nil {
+		page = match[2]
+	}
+
+	return html.UnescapeString(tagPattern.ReplaceAllString(page, " "))
+}
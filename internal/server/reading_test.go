@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+// pageServer serves HTML fixtures by path, answering with 404 for unknown paths
+func pageServer(t *testing.T, pages map[string]string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/short", http.StatusFound)
+
+			return
+		}
+
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if strings.HasSuffix(r.URL.Path, ".json") {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+
+		_, _ = w.Write([]byte(page))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func words(n int) string {
+	return strings.TrimSpace(strings.Repeat("word ", n))
+}
+
+func TestEstimateReadingTime(t *testing.T) {
+	pages := pageServer(t, map[string]string{
+		"/short":     "<html><body><p>" + words(50) + "</p></body></html>",
+		"/long":      "<html><head><title>" + words(30) + "</title></head><body><p>" + words(1000) + "</p></body></html>",
+		"/article":   "<html><body><nav>" + words(400) + "</nav><article>" + words(250) + "</article><footer>" + words(300) + "</footer></body></html>",
+		"/scripts":   "<html><body><script>var x = '" + words(500) + "';</script><p>" + words(10) + "</p></body></html>",
+		"/empty":     "<html><body><img src=x></body></html>",
+		"/data.json": `{"text": "` + words(900) + `"}`,
+	})
+
+	tests := []struct {
+		name        string
+		path        string
+		description string
+		private     bool
+		wantWords   int
+		wantMinutes int
+		wantSource  string
+		wantText    string
+	}{
+		{name: "short page", path: "/short", private: true, wantWords: 50, wantMinutes: 1, wantSource: "page"},
+		{name: "long page", path: "/long", private: true, wantWords: 1000, wantMinutes: 5, wantSource: "page"},
+		{name: "main content preferred", path: "/article", private: true, wantWords: 250, wantMinutes: 2, wantSource: "page"},
+		{name: "scripts ignored", path: "/scripts", private: true, wantWords: 10, wantMinutes: 1, wantSource: "page"},
+		{name: "redirect followed", path: "/redirect", private: true, wantWords: 50, wantMinutes: 1, wantSource: "page"},
+		{
+			name: "no text falls back to the description", path: "/empty", description: words(20), private: true,
+			wantWords: 20, wantMinutes: 1, wantSource: "description", wantText: "No readable text",
+		},
+		{
+			name: "missing page falls back to the description", path: "/missing", description: words(420), private: true,
+			wantWords: 420, wantMinutes: 3, wantSource: "description", wantText: "status 404",
+		},
+		{
+			name: "non-HTML page falls back to the description", path: "/data.json", private: true,
+			wantSource: "description", wantText: "not HTML",
+		},
+		{
+			name: "loopback refused without the opt-in", path: "/long", description: words(5),
+			wantWords: 5, wantMinutes: 1, wantSource: "description", wantText: "refusing to fetch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: pages.URL + tt.path, Title: "Page", Description: tt.description})
+
+			var opts []Option
+			if tt.private {
+				opts = append(opts, WithPrivateFetches())
+			}
+
+			result := callTool(t, newTestServer(t, fake, opts...), "estimate_reading_time", map[string]any{"id": 1})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			got := structured[ReadingTimeResult](t, result)
+			if got.Words != tt.wantWords || got.Minutes != tt.wantMinutes || got.Source != tt.wantSource {
+				t.Errorf("got %d words, %d minutes from %s, want %d, %d from %s",
+					got.Words, got.Minutes, got.Source, tt.wantWords, tt.wantMinutes, tt.wantSource)
+			}
+
+			text := resultText(result)
+			if !strings.Contains(text, "rough estimate") || !strings.Contains(text, tt.wantText) {
+				t.Errorf("output %q doesn't contain %q and the rough estimate label", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestPageClientRefusesPrivateAddresses(t *testing.T) {
+	pages := pageServer(t, map[string]string{"/": "<p>hi</p>"})
+
+	tests := []struct {
+		name    string
+		private bool
+		wantErr error
+	}{
+		{name: "refused by default", wantErr: errDisallowedAddress},
+		{name: "allowed when opted in", private: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Bypass checkFetchURL: the client itself refuses the connection,
+			// which also covers redirects to private addresses
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, pages.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := newPageClient(tt.private).Do(req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
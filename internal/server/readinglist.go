@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// markdownEscaper escapes characters that would break a markdown link label
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+
+func (s *MCPServer) handleExportReadingList(ctx context.Context, req *mcpsdk.CallToolRequest, args ExportReadingListArgs) (*mcpsdk.CallToolResult, ReadingListResult, error) {
+	from, err := parseTimeArg(args.From, false)
+	if err != nil {
+		return errorResult("Invalid from: %v", err), ReadingListResult{}, nil
+	}
+
+	to, err := parseTimeArg(args.To, true)
+	if err != nil {
+		return errorResult("Invalid to: %v", err), ReadingListResult{}, nil
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return errorResult("The to date must not be before the from date"), ReadingListResult{}, nil
+	}
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query), linkding.WithUnread(true))
+	if err != nil {
+		return errorResult("Failed to get bookmarks: %v", err), ReadingListResult{}, nil
+	}
+
+	// Older Linkding versions ignore the unread filter, so check it here as well
+	unread := filterBookmarks(bookmarks, func(b linkding.Bookmark) bool {
+		return b.Unread && (from.IsZero() || !b.DateAdded.Before(from)) && (to.IsZero() || !b.DateAdded.After(to))
+	})
+
+	sort.SliceStable(unread, func(i, j int) bool {
+		return unread[i].DateAdded.Before(unread[j].DateAdded)
+	})
+
+	markdown := renderReadingList(unread, describeRange(from, to))
+
+	return textResult(markdown), ReadingListResult{Total: len(unread), Markdown: markdown}, nil
+}
+
+// renderReadingList renders bookmarks as a markdown checkbox list, one
+// unchecked item per bookmark with its link and tags
+func renderReadingList(bookmarks []linkding.Bookmark, period string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Reading list\n\n%s added %s.\n\n", pluralize(len(bookmarks), "unread bookmark"), period)
+
+	for _, bookmark := range bookmarks {
+		title := bookmark.Title
+		if title == "" {
+			title = bookmark.URL
+		}
+
+		fmt.Fprintf(&b, "- [ ] [%s](%s)", markdownEscaper.Replace(title), bookmark.URL)
+
+		for _, tag := range bookmark.TagNames {
+			b.WriteString(" #" + tag)
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
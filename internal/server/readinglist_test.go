@@ -0,0 +1,75 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestExportReadingList(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC) }
+
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{URL: "https://a.example", Title: "Alpha [draft]", TagNames: []string{"go", "web"}, Unread: true, DateAdded: day(1)},
+		linkding.Bookmark{URL: "https://b.example", Title: "Read already", Unread: false, DateAdded: day(2)},
+		linkding.Bookmark{URL: "https://c.example", Unread: true, DateAdded: day(10)},
+		linkding.Bookmark{URL: "https://d.example", Title: "Delta", TagNames: []string{"rust"}, Unread: true, DateAdded: day(20)},
+	)
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		want      string
+		wantTotal int
+		wantError bool
+	}{
+		{
+			name: "unread only, oldest first",
+			args: map[string]any{},
+			want: "# Reading list\n\n3 unread bookmarks added at any time.\n\n" +
+				"- [ ] [Alpha \\[draft\\]](https://a.example) #go #web\n" +
+				"- [ ] [https://c.example](https://c.example)\n" +
+				"- [ ] [Delta](https://d.example) #rust\n",
+			wantTotal: 3,
+		},
+		{
+			name: "date range",
+			args: map[string]any{"from": "2024-03-02", "to": "2024-03-10"},
+			want: "# Reading list\n\n1 unread bookmark added between 2024-03-02T00:00:00Z and 2024-03-10T23:59:59Z.\n\n" +
+				"- [ ] [https://c.example](https://c.example)\n",
+			wantTotal: 1,
+		},
+		{
+			name: "query",
+			args: map[string]any{"query": "#rust"},
+			want: "# Reading list\n\n1 unread bookmark added at any time.\n\n" +
+				"- [ ] [Delta](https://d.example) #rust\n",
+			wantTotal: 1,
+		},
+		{name: "reversed range", args: map[string]any{"from": "2024-03-10", "to": "2024-03-01"}, wantError: true},
+		{name: "invalid date", args: map[string]any{"to": "soon"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "export_reading_list", tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			readingList := structured[ReadingListResult](t, result)
+			if readingList.Markdown != tt.want || readingList.Total != tt.wantTotal {
+				t.Errorf("got %d bookmarks:\n%s\nwant %d:\n%s", readingList.Total, readingList.Markdown, tt.wantTotal, tt.want)
+			}
+
+			if resultText(result) != tt.want {
+				t.Errorf("output differs from the markdown: %q", resultText(result))
+			}
+		})
+	}
+}
@@ -0,0 +1,427 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *MCPServer) handleBookmarksByDateRange(ctx context.Context, req *mcpsdk.CallToolRequest, args DateRangeArgs) (*mcpsdk.CallToolResult, BookmarkListResult, error) {
+	from, err := parseTimeArg(args.From, false)
+	if err != nil {
+		return errorResult("Invalid from: %v", err), BookmarkListResult{}, nil
+	}
+
+	to, err := parseTimeArg(args.To, true)
+	if err != nil {
+		return errorResult("Invalid to: %v", err), BookmarkListResult{}, nil
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return errorResult("The to date must not be before the from date"), BookmarkListResult{}, nil
+	}
+
+	limit := args.Limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// Bookmarks archived since they were added still count as added in the range
+	bookmarks, err := s.getEntireLibrary(ctx)
+	if err != nil {
+		return errorResult("Failed to get bookmarks: %v", err), BookmarkListResult{}, nil
+	}
+
+	inRange := filterBookmarks(bookmarks, func(b linkding.Bookmark) bool {
+		return (from.IsZero() || !b.DateAdded.Before(from)) && (to.IsZero() || !b.DateAdded.After(to))
+	})
+
+	sort.SliceStable(inRange, func(i, j int) bool {
+		return inRange[i].DateAdded.Before(inRange[j].DateAdded)
+	})
+
+	listResult := summarizeBookmarks(inRange)
+	if len(listResult.Bookmarks) > limit {
+		listResult.Bookmarks = listResult.Bookmarks[:limit]
+	}
+
+	result := fmt.Sprintf("Found %s added %s", pluralize(listResult.Total, "bookmark"), describeRange(from, to))
+	if len(listResult.Bookmarks) < listResult.Total {
+		result += fmt.Sprintf(", showing the first %d", len(listResult.Bookmarks))
+	}
+
+	result += ":\n\n"
+
+	for _, bookmark := range inRange[:len(listResult.Bookmarks)] {
+		result += fmt.Sprintf("• %s **%s**", bookmark.DateAdded.Format(time.DateOnly), bookmark.Title)
+		if bookmark.IsArchived {
+			result += " (archived)"
+		}
+
+		result += fmt.Sprintf("\n  URL: %s\n  ID: %d\n\n", bookmark.URL, bookmark.ID)
+	}
+
+	if len(listResult.Bookmarks) < listResult.Total {
+		result += fmt.Sprintf("%d more not listed; narrow the range or raise the limit to see them.\n", listResult.Total-len(listResult.Bookmarks))
+	}
+
+	return textResult(result), listResult, nil
+}
+
+// getEntireLibrary fetches every bookmark, including archived ones
+func (s *MCPServer) getEntireLibrary(ctx context.Context) ([]linkding.Bookmark, error) {
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := s.linkdingClient.GetAllArchivedBookmarks(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(bookmarks, archived...), nil
+}
+
+// parseTimeArg parses an RFC 3339 timestamp or a YYYY-MM-DD date.
+// A bare date used as a range end covers the whole day.
+// An empty value yields the zero time, meaning unbounded.
+func parseTimeArg(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 timestamp or YYYY-MM-DD date, got %q", value)
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return t, nil
+}
+
+// describeRange renders a possibly unbounded time range for tool output
+func describeRange(from, to time.Time) string {
+	switch {
+	case from.IsZero() && to.IsZero():
+		return "at any time"
+	case from.IsZero():
+		return "until " + to.Format(time.RFC3339)
+	case to.IsZero():
+		return "since " + from.Format(time.RFC3339)
+	default:
+		return fmt.Sprintf("between %s and %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+}
+
+// filterBookmarks returns the bookmarks for which keep returns true
+func filterBookmarks(bookmarks []linkding.Bookmark, keep func(linkding.Bookmark) bool) []linkding.Bookmark {
+	filtered := make([]linkding.Bookmark, 0)
+
+	for _, bookmark := range bookmarks {
+		if keep(bookmark) {
+			filtered = append(filtered, bookmark)
+		}
+	}
+
+	return filtered
+}
+
+// summarizeBookmarks converts bookmarks to the compact listing representation
+func summarizeBookmarks(bookmarks []linkding.Bookmark) BookmarkListResult {
+	listResult := BookmarkListResult{
+		Total:     len(bookmarks),
+		Bookmarks: make([]BookmarkSummary, len(bookmarks)),
+	}
+
+	for i, bookmark := range bookmarks {
+		listResult.Bookmarks[i] = BookmarkSummary{
+			ID:          bookmark.ID,
+			URL:         bookmark.URL,
+			Title:       bookmark.Title,
+			Description: bookmark.Description,
+			Tags:        bookmark.TagNames,
+			DateAdded:   bookmark.DateAdded.Format(time.RFC3339),
+			Archived:    bookmark.IsArchived,
+		}
+	}
+
+	return listResult
+}
+
+const (
+	defaultTrendMonths = 12
+	maxTrendMonths     = 36
+	defaultTrendTags   = 10
+	maxTrendTags       = 50
+)
+
+func (s *MCPServer) handleTagTrends(ctx context.Context, req *mcpsdk.CallToolRequest, args TagTrendsArgs) (*mcpsdk.CallToolResult, TagTrendsResult, error) {
+	months := clamp(args.Months, defaultTrendMonths, maxTrendMonths)
+	topTags := clamp(args.Tags, defaultTrendTags, maxTrendTags)
+
+	bookmarks, err := s.getEntireLibrary(ctx)
+	if err != nil {
+		return errorResult("Failed to get bookmarks: %v", err), TagTrendsResult{}, nil
+	}
+
+	trends := computeTagTrends(bookmarks, time.Now(), months, topTags)
+
+	if len(trends.Series) == 0 {
+		return textResult(fmt.Sprintf("No tagged bookmarks in the last %s", pluralize(months, "month"))), trends, nil
+	}
+
+	result := fmt.Sprintf("Tag usage over the last %s (bookmarks added per month):\n\n", pluralize(months, "month"))
+	result += "| Tag | " + strings.Join(trends.Months, " | ") + " | Total |\n"
+	result += "|---" + strings.Repeat("|---", len(trends.Months)) + "|---|\n"
+
+	for _, series := range trends.Series {
+		counts := make([]string, len(series.Counts))
+		for i, count := range series.Counts {
+			counts[i] = strconv.Itoa(count)
+		}
+
+		result += fmt.Sprintf("| %s | %s | %d |\n", series.Tag, strings.Join(counts, " | "), series.Total)
+	}
+
+	return textResult(result), trends, nil
+}
+
+// computeTagTrends buckets bookmarks by month added and tag, covering the
+// given number of months up to now and keeping the most used tags
+func computeTagTrends(bookmarks []linkding.Bookmark, now time.Time, months, topTags int) TagTrendsResult {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(months - 1), 0)
+
+	trends := TagTrendsResult{Months: make([]string, months)}
+	for i := range months {
+		trends.Months[i] = start.AddDate(0, i, 0).Format("2006-01")
+	}
+
+	seriesByTag := map[string]*TagSeries{}
+
+	for _, bookmark := range bookmarks {
+		added := bookmark.DateAdded.UTC()
+		if added.Before(start) {
+			continue
+		}
+
+		month := (added.Year()-start.Year())*12 + int(added.Month()) - int(start.Month())
+		if month >= months {
+			continue
+		}
+
+		for _, tag := range bookmark.TagNames {
+			key := strings.ToLower(tag)
+
+			series, ok := seriesByTag[key]
+			if !ok {
+				series = &TagSeries{Tag: tag, Counts: make([]int, months)}
+				seriesByTag[key] = series
+			}
+
+			series.Counts[month]++
+			series.Total++
+		}
+	}
+
+	trends.Series = make([]TagSeries, 0, len(seriesByTag))
+	for _, series := range seriesByTag {
+		trends.Series = append(trends.Series, *series)
+	}
+
+	sort.Slice(trends.Series, func(i, j int) bool {
+		if trends.Series[i].Total != trends.Series[j].Total {
+			return trends.Series[i].Total > trends.Series[j].Total
+		}
+
+		return trends.Series[i].Tag < trends.Series[j].Tag
+	})
+
+	if len(trends.Series) > topTags {
+		trends.Series = trends.Series[:topTags]
+	}
+
+	return trends
+}
+
+const (
+	defaultArchiveAgeDays    = 90
+	maxArchiveAgeDays        = 3650
+	defaultArchiveCandidates = 50
+	maxArchiveCandidates     = 500
+)
+
+func (s *MCPServer) handleSuggestArchiveCandidates(ctx context.Context, req *mcpsdk.CallToolRequest, args ArchiveCandidatesArgs) (*mcpsdk.CallToolResult, ArchiveCandidatesResult, error) {
+	days := clamp(args.Days, defaultArchiveAgeDays, maxArchiveAgeDays)
+	limit := clamp(args.Limit, defaultArchiveCandidates, maxArchiveCandidates)
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query), linkding.WithUnread(false))
+	if err != nil {
+		return errorResult("Failed to get bookmarks: %v", err), ArchiveCandidatesResult{}, nil
+	}
+
+	candidates := archiveCandidates(bookmarks, time.Now().AddDate(0, 0, -days))
+
+	candidatesResult := ArchiveCandidatesResult{Total: len(candidates), OlderThanDays: days}
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	candidatesResult.Candidates = summarizeBookmarks(candidates).Bookmarks
+
+	if candidatesResult.Total == 0 {
+		return textResult(fmt.Sprintf("No read bookmarks older than %s", pluralize(days, "day"))), candidatesResult, nil
+	}
+
+	result := fmt.Sprintf("Found %s older than %s that could be archived",
+		pluralize(candidatesResult.Total, "read bookmark"), pluralize(days, "day"))
+	if candidatesResult.Total > len(candidates) {
+		result += fmt.Sprintf(", showing the oldest %d", len(candidates))
+	}
+
+	result += ":\n\n"
+
+	for _, bookmark := range candidates {
+		result += fmt.Sprintf("• %s **%s**\n  URL: %s\n  ID: %d\n\n",
+			bookmark.DateAdded.Format(time.DateOnly), bookmark.Title, bookmark.URL, bookmark.ID)
+	}
+
+	result += "Archive them with archive_bookmark, or several at once with search_and_archive."
+
+	return textResult(result), candidatesResult, nil
+}
+
+// archiveCandidates returns the read bookmarks added before cutoff, oldest
+// first. Unread ones are skipped even if the server ignored the unread filter.
+func archiveCandidates(bookmarks []linkding.Bookmark, cutoff time.Time) []linkding.Bookmark {
+	candidates := filterBookmarks(bookmarks, func(b linkding.Bookmark) bool {
+		return !b.Unread && !b.IsArchived && b.DateAdded.Before(cutoff)
+	})
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].DateAdded.Before(candidates[j].DateAdded)
+	})
+
+	return candidates
+}
+
+const (
+	defaultSharingTags = 20
+	maxSharingTags     = 100
+)
+
+func (s *MCPServer) handleSharingReport(ctx context.Context, req *mcpsdk.CallToolRequest, args SharingReportArgs) (*mcpsdk.CallToolResult, SharingReportResult, error) {
+	topTags := clamp(args.Tags, defaultSharingTags, maxSharingTags)
+
+	bookmarks, err := s.getEntireLibrary(ctx)
+	if err != nil {
+		return errorResult("Failed to get bookmarks: %v", err), SharingReportResult{}, nil
+	}
+
+	report := computeSharingReport(bookmarks, topTags)
+
+	if report.Total == 0 {
+		return textResult("No bookmarks in the library"), report, nil
+	}
+
+	result := fmt.Sprintf("%d of %s shared publicly, %d private.\n\n",
+		report.Shared, pluralize(report.Total, "bookmark"), report.Private)
+
+	if len(report.Tags) > 0 {
+		result += "| Tag | Shared | Private |\n|---|---|---|\n"
+		for _, tag := range report.Tags {
+			result += fmt.Sprintf("| %s | %d | %d |\n", tag.Tag, tag.Shared, tag.Private)
+		}
+	}
+
+	if report.Untagged.Shared+report.Untagged.Private > 0 {
+		result += fmt.Sprintf("\nUntagged: %d shared, %d private\n", report.Untagged.Shared, report.Untagged.Private)
+	}
+
+	return textResult(result), report, nil
+}
+
+// computeSharingReport counts shared and private bookmarks overall and per
+// tag, keeping the tags with the most shared bookmarks
+func computeSharingReport(bookmarks []linkding.Bookmark, topTags int) SharingReportResult {
+	report := SharingReportResult{Total: len(bookmarks)}
+	sharingByTag := map[string]*TagSharing{}
+
+	count := func(sharing *TagSharing, shared bool) {
+		if shared {
+			sharing.Shared++
+		} else {
+			sharing.Private++
+		}
+	}
+
+	for _, bookmark := range bookmarks {
+		if bookmark.Shared {
+			report.Shared++
+		} else {
+			report.Private++
+		}
+
+		if len(bookmark.TagNames) == 0 {
+			count(&report.Untagged, bookmark.Shared)
+		}
+
+		for _, tag := range bookmark.TagNames {
+			key := strings.ToLower(tag)
+
+			sharing, ok := sharingByTag[key]
+			if !ok {
+				sharing = &TagSharing{Tag: tag}
+				sharingByTag[key] = sharing
+			}
+
+			count(sharing, bookmark.Shared)
+		}
+	}
+
+	report.Tags = make([]TagSharing, 0, len(sharingByTag))
+	for _, sharing := range sharingByTag {
+		report.Tags = append(report.Tags, *sharing)
+	}
+
+	sort.Slice(report.Tags, func(i, j int) bool {
+		a, b := report.Tags[i], report.Tags[j]
+		if a.Shared != b.Shared {
+			return a.Shared > b.Shared
+		}
+
+		if a.Shared+a.Private != b.Shared+b.Private {
+			return a.Shared+a.Private > b.Shared+b.Private
+		}
+
+		return a.Tag < b.Tag
+	})
+
+	if len(report.Tags) > topTags {
+		report.Tags = report.Tags[:topTags]
+	}
+
+	return report
+}
+
+// clamp returns value, or def when it is unset, capped at maxValue
+func clamp(value, def, maxValue int) int {
+	if value <= 0 {
+		return def
+	}
+
+	return min(value, maxValue)
+}
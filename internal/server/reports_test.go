@@ -0,0 +1,339 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestBookmarksByDateRange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC) }
+
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{ID: 1, URL: "https://a.example", DateAdded: day(1)},
+		linkding.Bookmark{ID: 2, URL: "https://b.example", DateAdded: day(10), IsArchived: true},
+		linkding.Bookmark{ID: 3, URL: "https://c.example", DateAdded: day(5)},
+		linkding.Bookmark{ID: 4, URL: "https://d.example", DateAdded: day(20)},
+	)
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantIDs   []int
+		wantTotal int
+		wantText  string
+		wantError bool
+	}{
+		{name: "whole library oldest first", args: map[string]any{}, wantIDs: []int{1, 3, 2, 4}, wantTotal: 4},
+		{name: "archived bookmarks included", args: map[string]any{"from": "2024-03-05", "to": "2024-03-10"}, wantIDs: []int{3, 2}, wantTotal: 2, wantText: "(archived)"},
+		{name: "bare end date covers the day", args: map[string]any{"to": "2024-03-01"}, wantIDs: []int{1}, wantTotal: 1},
+		{name: "timestamps", args: map[string]any{"from": "2024-03-10T12:00:00Z"}, wantIDs: []int{2, 4}, wantTotal: 2},
+		{name: "listing capped by limit", args: map[string]any{"limit": 2}, wantIDs: []int{1, 3}, wantTotal: 4, wantText: "2 more not listed"},
+		{name: "reversed range", args: map[string]any{"from": "2024-03-10", "to": "2024-03-01"}, wantError: true},
+		{name: "invalid date", args: map[string]any{"from": "March 1st"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "bookmarks_by_date_range", tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			listResult := structured[BookmarkListResult](t, result)
+
+			var ids []int
+			for _, bookmark := range listResult.Bookmarks {
+				ids = append(ids, bookmark.ID)
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) || listResult.Total != tt.wantTotal {
+				t.Errorf("got IDs %v of %d, want %v of %d", ids, listResult.Total, tt.wantIDs, tt.wantTotal)
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't contain %q", resultText(result), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestBookmarksByDateRangeDefaultLimit(t *testing.T) {
+	var bookmarks []linkding.Bookmark
+	for i := range 120 {
+		bookmarks = append(bookmarks, linkding.Bookmark{URL: fmt.Sprintf("https://example.com/%d", i)})
+	}
+
+	result := callTool(t, newTestServer(t, newFakeLinkding(t, bookmarks...)), "bookmarks_by_date_range", map[string]any{})
+
+	listResult := structured[BookmarkListResult](t, result)
+	if listResult.Total != 120 || len(listResult.Bookmarks) != 50 {
+		t.Errorf("listed %d of %d, want 50 of 120", len(listResult.Bookmarks), listResult.Total)
+	}
+}
+
+func TestComputeTagTrends(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	month := func(m time.Month) time.Time { return time.Date(2024, m, 10, 0, 0, 0, 0, time.UTC) }
+
+	bookmarks := []linkding.Bookmark{
+		{DateAdded: month(1), TagNames: []string{"go"}},
+		{DateAdded: month(3), TagNames: []string{"go", "web"}},
+		{DateAdded: month(3), TagNames: []string{"Go"}},
+		{DateAdded: month(5), TagNames: []string{"rust"}},
+		{DateAdded: month(6), TagNames: []string{"go", "rust"}},
+		{DateAdded: month(6)},
+		// Outside the range: too old, and in the future
+		{DateAdded: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), TagNames: []string{"old"}},
+		{DateAdded: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), TagNames: []string{"future"}},
+	}
+
+	tests := []struct {
+		name       string
+		months     int
+		tags       int
+		wantMonths []string
+		wantSeries []TagSeries
+	}{
+		{
+			name:       "first half of the year",
+			months:     6,
+			tags:       10,
+			wantMonths: []string{"2024-01", "2024-02", "2024-03", "2024-04", "2024-05", "2024-06"},
+			wantSeries: []TagSeries{
+				{Tag: "go", Total: 4, Counts: []int{1, 0, 2, 0, 0, 1}},
+				{Tag: "rust", Total: 2, Counts: []int{0, 0, 0, 0, 1, 1}},
+				{Tag: "web", Total: 1, Counts: []int{0, 0, 1, 0, 0, 0}},
+			},
+		},
+		{
+			name:       "tags capped to the most used",
+			months:     6,
+			tags:       1,
+			wantMonths: []string{"2024-01", "2024-02", "2024-03", "2024-04", "2024-05", "2024-06"},
+			wantSeries: []TagSeries{{Tag: "go", Total: 4, Counts: []int{1, 0, 2, 0, 0, 1}}},
+		},
+		{
+			name:       "recent months only",
+			months:     2,
+			tags:       10,
+			wantMonths: []string{"2024-05", "2024-06"},
+			wantSeries: []TagSeries{
+				{Tag: "rust", Total: 2, Counts: []int{1, 1}},
+				{Tag: "go", Total: 1, Counts: []int{0, 1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trends := computeTagTrends(bookmarks, now, tt.months, tt.tags)
+
+			if !slices.Equal(trends.Months, tt.wantMonths) {
+				t.Errorf("months = %v, want %v", trends.Months, tt.wantMonths)
+			}
+
+			if len(trends.Series) != len(tt.wantSeries) {
+				t.Fatalf("series = %+v, want %+v", trends.Series, tt.wantSeries)
+			}
+
+			for i, want := range tt.wantSeries {
+				got := trends.Series[i]
+				if got.Tag != want.Tag || got.Total != want.Total || !slices.Equal(got.Counts, want.Counts) {
+					t.Errorf("series %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTagTrendsLimits(t *testing.T) {
+	tests := []struct {
+		args       map[string]any
+		wantMonths int
+	}{
+		{args: map[string]any{}, wantMonths: defaultTrendMonths},
+		{args: map[string]any{"months": 3}, wantMonths: 3},
+		{args: map[string]any{"months": 500}, wantMonths: maxTrendMonths},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.args), func(t *testing.T) {
+			fake := newFakeLinkding(t, linkding.Bookmark{URL: "https://a.example", DateAdded: time.Now(), TagNames: []string{"go"}})
+
+			result := callTool(t, newTestServer(t, fake), "tag_trends", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			if trends := structured[TagTrendsResult](t, result); len(trends.Months) != tt.wantMonths || len(trends.Series) != 1 {
+				t.Errorf("got %d months and %d series, want %d and 1", len(trends.Months), len(trends.Series), tt.wantMonths)
+			}
+		})
+	}
+}
+
+func TestSuggestArchiveCandidates(t *testing.T) {
+	daysAgo := func(d int) time.Time { return time.Now().AddDate(0, 0, -d) }
+
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{ID: 1, URL: "https://a.example", DateAdded: daysAgo(200)},
+		linkding.Bookmark{ID: 2, URL: "https://b.example", DateAdded: daysAgo(400), Unread: true},
+		linkding.Bookmark{ID: 3, URL: "https://c.example", DateAdded: daysAgo(120), TagNames: []string{"go"}},
+		linkding.Bookmark{ID: 4, URL: "https://d.example", DateAdded: daysAgo(30)},
+		linkding.Bookmark{ID: 5, URL: "https://e.example", DateAdded: daysAgo(300), IsArchived: true},
+		linkding.Bookmark{ID: 6, URL: "https://f.example", DateAdded: daysAgo(95)},
+	)
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantIDs   []int
+		wantTotal int
+		wantText  string
+	}{
+		{name: "default threshold oldest first", args: map[string]any{}, wantIDs: []int{1, 3, 6}, wantTotal: 3, wantText: "older than 90 days"},
+		{name: "custom threshold", args: map[string]any{"days": 150}, wantIDs: []int{1}, wantTotal: 1},
+		{name: "short threshold", args: map[string]any{"days": 7}, wantIDs: []int{1, 3, 6, 4}, wantTotal: 4},
+		{name: "capped by limit", args: map[string]any{"limit": 2}, wantIDs: []int{1, 3}, wantTotal: 3, wantText: "showing the oldest 2"},
+		{name: "narrowed by query", args: map[string]any{"query": "#go"}, wantIDs: []int{3}, wantTotal: 1},
+		{name: "no candidates", args: map[string]any{"days": 1000}, wantText: "No read bookmarks older than 1000 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "suggest_archive_candidates", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			candidatesResult := structured[ArchiveCandidatesResult](t, result)
+
+			var ids []int
+			for _, bookmark := range candidatesResult.Candidates {
+				ids = append(ids, bookmark.ID)
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) || candidatesResult.Total != tt.wantTotal {
+				t.Errorf("got IDs %v of %d, want %v of %d", ids, candidatesResult.Total, tt.wantIDs, tt.wantTotal)
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't contain %q", resultText(result), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestArchiveCandidatesSkipsUnread(t *testing.T) {
+	cutoff := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := cutoff.AddDate(0, -1, 0)
+
+	candidates := archiveCandidates([]linkding.Bookmark{
+		{ID: 1, DateAdded: old, Unread: true},
+		{ID: 2, DateAdded: old, IsArchived: true},
+		{ID: 3, DateAdded: cutoff},
+		{ID: 4, DateAdded: old},
+	}, cutoff)
+
+	if len(candidates) != 1 || candidates[0].ID != 4 {
+		t.Errorf("candidates = %+v, want only bookmark 4", candidates)
+	}
+}
+
+func TestComputeSharingReport(t *testing.T) {
+	bookmarks := []linkding.Bookmark{
+		{Shared: true, TagNames: []string{"go", "web"}},
+		{Shared: true, TagNames: []string{"Go"}},
+		{TagNames: []string{"go"}},
+		{TagNames: []string{"personal"}},
+		{TagNames: []string{"personal", "web"}},
+		{Shared: true},
+		{},
+		{},
+	}
+
+	tests := []struct {
+		name     string
+		topTags  int
+		wantTags []TagSharing
+	}{
+		{
+			name:    "all tags, most shared first",
+			topTags: 10,
+			wantTags: []TagSharing{
+				{Tag: "go", Shared: 2, Private: 1},
+				{Tag: "web", Shared: 1, Private: 1},
+				{Tag: "personal", Private: 2},
+			},
+		},
+		{name: "capped", topTags: 1, wantTags: []TagSharing{{Tag: "go", Shared: 2, Private: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := computeSharingReport(bookmarks, tt.topTags)
+
+			if report.Total != 8 || report.Shared != 3 || report.Private != 5 {
+				t.Errorf("total %d, shared %d, private %d; want 8, 3, 5", report.Total, report.Shared, report.Private)
+			}
+
+			if report.Untagged != (TagSharing{Shared: 1, Private: 2}) {
+				t.Errorf("untagged = %+v, want 1 shared and 2 private", report.Untagged)
+			}
+
+			if !slices.Equal(report.Tags, tt.wantTags) {
+				t.Errorf("tags = %+v, want %+v", report.Tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestSharingReport(t *testing.T) {
+	tests := []struct {
+		name      string
+		bookmarks []linkding.Bookmark
+		wantTotal int
+		wantText  []string
+	}{
+		{
+			name: "mixed library including archived bookmarks",
+			bookmarks: []linkding.Bookmark{
+				{ID: 1, URL: "https://a.example", Shared: true, TagNames: []string{"go"}},
+				{ID: 2, URL: "https://b.example", TagNames: []string{"go"}},
+				{ID: 3, URL: "https://c.example", Shared: true, IsArchived: true},
+				{ID: 4, URL: "https://d.example"},
+			},
+			wantTotal: 4,
+			wantText:  []string{"2 of 4 bookmarks shared publicly, 2 private", "| go | 1 | 1 |", "Untagged: 1 shared, 1 private"},
+		},
+		{name: "empty library", wantText: []string{"No bookmarks in the library"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, newFakeLinkding(t, tt.bookmarks...)), "sharing_report", map[string]any{})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			if report := structured[SharingReportResult](t, result); report.Total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", report.Total, tt.wantTotal)
+			}
+
+			for _, want := range tt.wantText {
+				if !strings.Contains(resultText(result), want) {
+					t.Errorf("output %q doesn't contain %q", resultText(result), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	bookmarkURIPrefix = "linkding://bookmarks/"
+	notesURISuffix    = "/notes"
+)
+
+// addResources registers the MCP resources and resource templates
+func (s *MCPServer) addResources(mcpServer *mcpsdk.Server) {
+	mcpServer.AddResourceTemplate(&mcpsdk.ResourceTemplate{
+		Name:        "bookmark_notes",
+		Title:       "Bookmark notes",
+		Description: "Raw markdown notes of a bookmark",
+		MIMEType:    "text/markdown",
+		URITemplate: bookmarkURIPrefix + "{id}" + notesURISuffix,
+	}, s.handleReadBookmarkNotes)
+}
+
+func (s *MCPServer) handleReadBookmarkNotes(ctx context.Context, req *mcpsdk.ReadResourceRequest) (*mcpsdk.ReadResourceResult, error) {
+	uri := req.Params.URI
+
+	id, err := parseBookmarkURI(uri, notesURISuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := s.linkdingClient.GetBookmark(ctx, id)
+	if err != nil {
+		if errors.Is(err, linkding.ErrNotFound) {
+			return nil, mcpsdk.ResourceNotFoundError(uri)
+		}
+
+		return nil, fmt.Errorf("failed to get bookmark %d: %w", id, err)
+	}
+
+	return &mcpsdk.ReadResourceResult{
+		Contents: []*mcpsdk.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: "text/markdown",
+				Text:     bookmark.Notes,
+			},
+		},
+	}, nil
+}
+
+// parseBookmarkURI extracts the bookmark ID from URIs like linkding://bookmarks/{id}/notes
+func parseBookmarkURI(uri, suffix string) (int, error) {
+	idPart, ok := strings.CutPrefix(uri, bookmarkURIPrefix)
+	if ok {
+		idPart, ok = strings.CutSuffix(idPart, suffix)
+	}
+
+	id, err := strconv.Atoi(idPart)
+	if !ok || err != nil || id <= 0 {
+		return 0, mcpsdk.ResourceNotFoundError(uri)
+	}
+
+	return id, nil
+}
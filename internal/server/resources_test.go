@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestReadBookmarkNotes(t *testing.T) {
+	fake := newFakeLinkding(t, linkding.Bookmark{ID: 3, URL: "https://a.example", Notes: "# Notes\n\n- first\n- second"})
+	session := connect(t, newTestServer(t, fake), nil)
+
+	tests := []struct {
+		uri      string
+		wantText string
+		wantErr  bool
+	}{
+		{uri: "linkding://bookmarks/3/notes", wantText: "# Notes\n\n- first\n- second"},
+		{uri: "linkding://bookmarks/4/notes", wantErr: true},
+		{uri: "linkding://bookmarks/abc/notes", wantErr: true},
+		{uri: "linkding://bookmarks/3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			result, err := session.ReadResource(context.Background(), &mcpsdk.ReadResourceParams{URI: tt.uri})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadResource() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(result.Contents) != 1 || result.Contents[0].Text != tt.wantText || result.Contents[0].MIMEType != "text/markdown" {
+				t.Errorf("contents = %+v, want the raw markdown notes", result.Contents)
+			}
+		})
+	}
+}
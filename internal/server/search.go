@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// searchableFields maps advanced_search field names to their bookmark values
+var searchableFields = map[string]func(linkding.Bookmark) string{
+	"title":       func(b linkding.Bookmark) string { return b.Title },
+	"description": func(b linkding.Bookmark) string { return b.Description },
+	"notes":       func(b linkding.Bookmark) string { return b.Notes },
+	"url":         func(b linkding.Bookmark) string { return b.URL },
+}
+
+var defaultSearchFields = []string{"title", "description", "notes"}
+
+func (s *MCPServer) handleAdvancedSearch(ctx context.Context, req *mcpsdk.CallToolRequest, args AdvancedSearchArgs) (*mcpsdk.CallToolResult, AdvancedSearchResult, error) {
+	fields := args.Fields
+	if len(fields) == 0 {
+		fields = defaultSearchFields
+	}
+
+	for _, field := range fields {
+		if _, ok := searchableFields[field]; !ok {
+			return errorResult("Unknown search field %q (supported: title, description, notes, url)", field), AdvancedSearchResult{}, nil
+		}
+	}
+
+	terms := textTerms(args.Query)
+	if len(terms) == 0 {
+		return errorResult("Query must contain at least one search term"), AdvancedSearchResult{}, nil
+	}
+
+	limit := args.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	// Linkding narrows the candidates, then matching is scoped to the requested fields.
+	query := withSearchOperators(args.Query, args.ExcludeTags, args.OnlyUntagged)
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(query))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), AdvancedSearchResult{}, nil
+	}
+
+	matches := make([]SearchMatch, 0)
+
+	for _, bookmark := range bookmarks {
+		matched := matchFields(bookmark, fields, terms)
+		if len(matched) == 0 {
+			continue
+		}
+
+		matches = append(matches, SearchMatch{
+			ID:            bookmark.ID,
+			URL:           bookmark.URL,
+			Title:         bookmark.Title,
+			Description:   bookmark.Description,
+			Tags:          bookmark.TagNames,
+			MatchedFields: matched,
+		})
+	}
+
+	// Bookmarks matching in more of the requested fields rank first.
+	sort.SliceStable(matches, func(i, j int) bool {
+		return len(matches[i].MatchedFields) > len(matches[j].MatchedFields)
+	})
+
+	searchResult := AdvancedSearchResult{Total: len(matches), Results: matches}
+	if len(matches) > limit {
+		searchResult.Results = matches[:limit]
+	}
+
+	result := fmt.Sprintf("Found %s matching in %s:\n\n", pluralize(len(matches), "bookmark"), strings.Join(fields, ", "))
+	for _, match := range searchResult.Results {
+		result += fmt.Sprintf("• **%s**\n  URL: %s\n  ID: %d\n  Matched: %s\n\n",
+			match.Title, match.URL, match.ID, strings.Join(match.MatchedFields, ", "))
+	}
+
+	return textResult(result), searchResult, nil
+}
+
+// withSearchOperators appends Linkding search operators to a query:
+// "-#tag" for every excluded tag and "!untagged" to keep only untagged bookmarks
+func withSearchOperators(query string, excludeTags []string, onlyUntagged bool) string {
+	parts := []string{query}
+
+	for _, tag := range excludeTags {
+		if tag = strings.TrimPrefix(strings.TrimSpace(tag), "#"); tag != "" {
+			parts = append(parts, "-#"+tag)
+		}
+	}
+
+	if onlyUntagged {
+		parts = append(parts, "!untagged")
+	}
+
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// textTerms returns the lowercased free-text terms of a Linkding query,
+// skipping tag (#tag) and operator (!untagged, -term) tokens
+func textTerms(query string) []string {
+	var terms []string
+
+	for _, token := range strings.Fields(query) {
+		if strings.HasPrefix(token, "#") || strings.HasPrefix(token, "!") || strings.HasPrefix(token, "-") {
+			continue
+		}
+
+		terms = append(terms, strings.ToLower(token))
+	}
+
+	return terms
+}
+
+// matchFields returns the fields of a bookmark containing every term
+func matchFields(bookmark linkding.Bookmark, fields, terms []string) []string {
+	var matched []string
+
+	for _, field := range fields {
+		value := strings.ToLower(searchableFields[field](bookmark))
+
+		if !slices.ContainsFunc(terms, func(term string) bool { return !strings.Contains(value, term) }) {
+			matched = append(matched, field)
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxPreviewItems caps how many matches a search_and_* preview lists
+const maxPreviewItems = 20
+
+func (s *MCPServer) handleSearchAndArchive(ctx context.Context, req *mcpsdk.CallToolRequest, args SearchActionArgs) (*mcpsdk.CallToolResult, SearchActionResult, error) {
+	toolResult, actionResult, err := s.searchAndAct(ctx, req, args, "archive", s.mark(markArchived)+" Archived", s.linkdingClient.ArchiveBookmark)
+	if actionResult.Result != nil {
+		recordState(actionResult.Result, ptr(true), false)
+	}
+
+	return toolResult, actionResult, err
+}
+
+func (s *MCPServer) handleSearchAndDelete(ctx context.Context, req *mcpsdk.CallToolRequest, args SearchActionArgs) (*mcpsdk.CallToolResult, SearchActionResult, error) {
+	toolResult, actionResult, err := s.searchAndAct(ctx, req, args, "delete", s.mark(markDeleted)+" Deleted", s.linkdingClient.DeleteBookmark)
+	if actionResult.Result != nil {
+		recordState(actionResult.Result, nil, true)
+	}
+
+	return toolResult, actionResult, err
+}
+
+// searchAndAct runs a search and applies fn to every match once the caller
+// confirms. Without confirm: true it only returns a preview of the matches,
+// so the agent (and user) can check what would be affected first.
+// Deletion additionally goes through confirmDestructive.
+func (s *MCPServer) searchAndAct(ctx context.Context, req *mcpsdk.CallToolRequest, args SearchActionArgs, action, verb string, fn func(ctx context.Context, id int) error) (*mcpsdk.CallToolResult, SearchActionResult, error) {
+	if args.Query == "" {
+		return errorResult("Query is required"), SearchActionResult{}, nil
+	}
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), SearchActionResult{}, nil
+	}
+
+	actionResult := SearchActionResult{Matches: summarizeBookmarks(bookmarks)}
+
+	if len(bookmarks) == 0 {
+		return textResult("No bookmarks match the query"), actionResult, nil
+	}
+
+	if tooMany := s.checkBulkLimit(len(bookmarks)); tooMany != nil {
+		return tooMany, SearchActionResult{}, nil
+	}
+
+	if !args.Confirm {
+		return textResult(formatPreview(action, actionResult.Matches)), actionResult, nil
+	}
+
+	if action == "delete" {
+		if blocked := confirmDestructive(ctx, req, true, describeDeletion(bookmarkIDs(bookmarks))); blocked != nil {
+			return blocked, SearchActionResult{}, nil
+		}
+	}
+
+	bulkResult := s.runBulk(ctx, req, bookmarkIDs(bookmarks), fn)
+	actionResult.Confirmed = true
+	actionResult.Result = &bulkResult
+
+	return textResult(s.formatBulkResult(verb, bulkResult)), actionResult, nil
+}
+
+// formatPreview lists the bookmarks an unconfirmed search_and_* call would affect
+func formatPreview(action string, matches BookmarkListResult) string {
+	result := fmt.Sprintf("This would %s %s:\n\n", action, pluralize(matches.Total, "bookmark"))
+
+	for i, bookmark := range matches.Bookmarks {
+		if i == maxPreviewItems {
+			result += fmt.Sprintf("…and %d more\n", matches.Total-maxPreviewItems)
+
+			break
+		}
+
+		result += fmt.Sprintf("• **%s** (ID %d)\n  URL: %s\n", bookmark.Title, bookmark.ID, bookmark.URL)
+	}
+
+	return result + "\nNothing was changed. Call the tool again with confirm: true to proceed."
+}
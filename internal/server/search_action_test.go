@@ -0,0 +1,76 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestSearchAndAct(t *testing.T) {
+	tests := []struct {
+		name        string
+		tool        string
+		args        map[string]any
+		wantText    string
+		wantMatches int
+		wantChanged []int
+		wantError   bool
+	}{
+		{name: "archive preview", tool: "search_and_archive", args: map[string]any{"query": "#old"}, wantText: "This would archive 2 bookmarks", wantMatches: 2},
+		{name: "archive confirmed", tool: "search_and_archive", args: map[string]any{"query": "#old", "confirm": true}, wantText: "Archived", wantMatches: 2, wantChanged: []int{1, 3}},
+		{name: "delete preview", tool: "search_and_delete", args: map[string]any{"query": "#old"}, wantText: "This would delete 2 bookmarks", wantMatches: 2},
+		{name: "delete confirmed", tool: "search_and_delete", args: map[string]any{"query": "#old", "confirm": true}, wantText: "Deleted", wantMatches: 2, wantChanged: []int{1, 3}},
+		{name: "no matches", tool: "search_and_delete", args: map[string]any{"query": "#missing", "confirm": true}, wantText: "No bookmarks match"},
+		{name: "missing query", tool: "search_and_archive", args: map[string]any{"confirm": true}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{ID: 1, URL: "https://a.example", TagNames: []string{"old"}},
+				linkding.Bookmark{ID: 2, URL: "https://b.example", TagNames: []string{"new"}},
+				linkding.Bookmark{ID: 3, URL: "https://c.example", TagNames: []string{"old"}},
+			)
+
+			result := callTool(t, newTestServer(t, fake), tt.tool, tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't contain %q", resultText(result), tt.wantText)
+			}
+
+			actionResult := structured[SearchActionResult](t, result)
+			if actionResult.Matches.Total != tt.wantMatches || actionResult.Confirmed != (tt.wantChanged != nil) {
+				t.Errorf("got %d matches, confirmed %v; want %d, confirmed %v", actionResult.Matches.Total, actionResult.Confirmed, tt.wantMatches, tt.wantChanged != nil)
+			}
+
+			var changed []int
+
+			for _, id := range []int{1, 2, 3} {
+				bookmark, found := fake.bookmark(id)
+				if !found || bookmark.IsArchived {
+					changed = append(changed, id)
+				}
+			}
+
+			if !slices.Equal(changed, tt.wantChanged) {
+				t.Errorf("changed bookmarks %v, want %v", changed, tt.wantChanged)
+			}
+
+			if tt.wantChanged == nil {
+				if writes := len(fake.received(http.MethodPost, "/api/bookmarks/")) + len(fake.received(http.MethodDelete, "/api/bookmarks/")); writes > 0 {
+					t.Errorf("made %d changes without confirmation", writes)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestAdvancedSearch(t *testing.T) {
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{ID: 1, URL: "https://a.example/golang", Title: "Golang tips", Description: "golang and more", TagNames: []string{"go"}},
+		linkding.Bookmark{ID: 2, URL: "https://b.example", Title: "Cooking", Notes: "golang meetup notes"},
+		linkding.Bookmark{ID: 3, URL: "https://c.example/golang", Title: "Other", TagNames: []string{"noisy"}},
+	)
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		wantIDs     []int
+		wantMatched map[int][]string
+		wantQuery   string
+		wantError   bool
+	}{
+		{
+			name:        "default fields ranked by matched fields",
+			args:        map[string]any{"query": "golang"},
+			wantIDs:     []int{1, 2},
+			wantMatched: map[int][]string{1: {"title", "description"}, 2: {"notes"}},
+			wantQuery:   "golang",
+		},
+		{
+			name:        "url only",
+			args:        map[string]any{"query": "golang", "fields": []string{"url"}},
+			wantIDs:     []int{3, 1},
+			wantMatched: map[int][]string{1: {"url"}, 3: {"url"}},
+		},
+		{
+			name:      "excluded tags and untagged become operators",
+			args:      map[string]any{"query": "golang", "fields": []string{"url", "notes"}, "exclude_tags": []string{"#noisy"}, "only_untagged": true},
+			wantIDs:   []int{2},
+			wantQuery: "golang -#noisy !untagged",
+		},
+		{
+			name:    "every term must match",
+			args:    map[string]any{"query": "golang meetup"},
+			wantIDs: []int{2},
+		},
+		{
+			name:      "unknown field",
+			args:      map[string]any{"query": "golang", "fields": []string{"tags"}},
+			wantError: true,
+		},
+		{
+			name:      "only operators",
+			args:      map[string]any{"query": "#go -x"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(fake.received("GET", "/api/bookmarks/"))
+			result := callTool(t, newTestServer(t, fake), "advanced_search", tt.args)
+
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			searchResult := structured[AdvancedSearchResult](t, result)
+
+			var ids []int
+			for _, match := range searchResult.Results {
+				ids = append(ids, match.ID)
+
+				if want, ok := tt.wantMatched[match.ID]; ok && !slices.Equal(match.MatchedFields, want) {
+					t.Errorf("ID %d matched %v, want %v", match.ID, match.MatchedFields, want)
+				}
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("IDs = %v, want %v", ids, tt.wantIDs)
+			}
+
+			if tt.wantQuery != "" {
+				requests := fake.received("GET", "/api/bookmarks/")[before:]
+				if len(requests) == 0 || requests[0].Query.Get("q") != tt.wantQuery {
+					t.Errorf("requests = %v, want query %q", requests, tt.wantQuery)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultQuery string
+		tool         string
+		args         map[string]any
+		wantQuery    string
+	}{
+		{name: "combined with the query", defaultQuery: "-#noisy", tool: "search_bookmarks", args: map[string]any{"query": "golang"}, wantQuery: "-#noisy golang"},
+		{name: "used alone without a query", defaultQuery: "-#noisy", tool: "search_bookmarks", args: map[string]any{}, wantQuery: "-#noisy"},
+		{name: "applied to other searches", defaultQuery: "!unread", tool: "list_urls", args: map[string]any{"query": "#go"}, wantQuery: "!unread #go"},
+		{name: "no default", tool: "search_bookmarks", args: map[string]any{"query": "golang"}, wantQuery: "golang"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t)
+
+			result := callTool(t, newTestServer(t, fake, WithDefaultQuery(tt.defaultQuery)), tt.tool, tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			requests := fake.received("GET", "/api/bookmarks/")
+			if len(requests) != 1 || requests[0].Query.Get("q") != tt.wantQuery {
+				t.Errorf("requests = %v, want query %q", requests, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestWithSearchOperators(t *testing.T) {
+	tests := []struct {
+		query        string
+		excludeTags  []string
+		onlyUntagged bool
+		want         string
+	}{
+		{query: "golang", want: "golang"},
+		{query: "golang", excludeTags: []string{"noisy", "#old"}, want: "golang -#noisy -#old"},
+		{query: "golang", excludeTags: []string{" ", "#", " spaced "}, want: "golang -#spaced"},
+		{query: "golang", onlyUntagged: true, want: "golang !untagged"},
+		{query: "", excludeTags: []string{"a"}, onlyUntagged: true, want: "-#a !untagged"},
+	}
+
+	for _, tt := range tests {
+		if got := withSearchOperators(tt.query, tt.excludeTags, tt.onlyUntagged); got != tt.want {
+			t.Errorf("withSearchOperators(%q, %q, %v) = %q, want %q", tt.query, tt.excludeTags, tt.onlyUntagged, got, tt.want)
+		}
+	}
+}
+
+func TestListURLs(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{URL: "https://a.example", Title: "Alpha", TagNames: []string{"go"}, Unread: true, DateAdded: day(1)},
+		linkding.Bookmark{URL: "https://b.example", Title: "Beta", TagNames: []string{"go"}, DateAdded: day(2)},
+		linkding.Bookmark{URL: "https://c.example", Title: "Gamma", TagNames: []string{"rust"}, DateAdded: day(3)},
+	)
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		wantURLs []string
+		wantText string
+	}{
+		{name: "everything, newest first", args: map[string]any{}, wantURLs: []string{"https://c.example", "https://b.example", "https://a.example"}},
+		{name: "query", args: map[string]any{"query": "#go"}, wantURLs: []string{"https://b.example", "https://a.example"}},
+		{name: "unread filter", args: map[string]any{"unread": true}, wantURLs: []string{"https://a.example"}},
+		{name: "limit", args: map[string]any{"limit": 1}, wantURLs: []string{"https://c.example"}},
+		{name: "no matches", args: map[string]any{"query": "#zig"}, wantURLs: []string{}, wantText: "No bookmarks match the query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "list_urls", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			if got := structured[ListURLsResult](t, result).URLs; !slices.Equal(got, tt.wantURLs) {
+				t.Errorf("URLs = %v, want %v", got, tt.wantURLs)
+			}
+
+			// Only the URLs, without titles, tags or formatting
+			wantText := tt.wantText
+			if wantText == "" {
+				wantText = strings.Join(tt.wantURLs, "\n")
+			}
+
+			if got := resultText(result); got != wantText {
+				t.Errorf("output = %q, want %q", got, wantText)
+			}
+		})
+	}
+}
+
+func TestSearchBookmarksSort(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	// The fake returns the newest bookmarks first, whatever the sort parameter
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{URL: "https://a.example", Title: "Beta", DateAdded: day(1)},
+		linkding.Bookmark{URL: "https://b.example", Title: "Alpha", DateAdded: day(2)},
+		linkding.Bookmark{URL: "https://c.example", Title: "Gamma", DateAdded: day(3)},
+	)
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		wantTitles     []string
+		wantPageSorted bool
+	}{
+		{name: "honored", args: map[string]any{"sort": "added_desc", "limit": 2}, wantTitles: []string{"Gamma", "Alpha"}},
+		{name: "ignored, sorted over everything", args: map[string]any{"sort": "title_asc"}, wantTitles: []string{"Alpha", "Beta", "Gamma"}},
+		{name: "ignored, only this page sorted", args: map[string]any{"sort": "title_asc", "limit": 2}, wantTitles: []string{"Alpha", "Gamma"}, wantPageSorted: true},
+		{name: "ignored on a later page", args: map[string]any{"sort": "added_asc", "offset": 1}, wantTitles: []string{"Beta", "Alpha"}, wantPageSorted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "search_bookmarks", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			searchResult := structured[SearchBookmarksResult](t, result)
+
+			var titles []string
+			for _, bookmark := range searchResult.Bookmarks {
+				titles = append(titles, bookmark.Title)
+			}
+
+			if !slices.Equal(titles, tt.wantTitles) || searchResult.PageSorted != tt.wantPageSorted {
+				t.Errorf("got %v (page sorted %v), want %v (page sorted %v)", titles, searchResult.PageSorted, tt.wantTitles, tt.wantPageSorted)
+			}
+
+			if noted := strings.Contains(resultText(result), "only the bookmarks on this page were sorted"); noted != tt.wantPageSorted {
+				t.Errorf("output %q mentions the page sort: %v, want %v", resultText(result), noted, tt.wantPageSorted)
+			}
+		})
+	}
+}
+
+func TestSearchBookmarksUnknownSort(t *testing.T) {
+	result := callTool(t, newTestServer(t, newFakeLinkding(t)), "search_bookmarks", map[string]any{"sort": "random"})
+	if !result.IsError || !strings.Contains(resultText(result), "Unknown sort order") {
+		t.Errorf("result = %q, want an unknown sort order error", resultText(result))
+	}
+}
+
+func TestSearchBookmarksTotal(t *testing.T) {
+	var bookmarks []linkding.Bookmark
+	for i := range 45 {
+		bookmarks = append(bookmarks, linkding.Bookmark{URL: fmt.Sprintf("https://example.com/%d", i), TagNames: []string{fmt.Sprintf("tag%d", i%3)}})
+	}
+
+	fake := newFakeLinkding(t, bookmarks...)
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		wantTotal     int
+		wantReturned  int
+		wantTruncated bool
+		wantText      string
+	}{
+		{name: "default limit", args: map[string]any{}, wantTotal: 45, wantReturned: 20, wantTruncated: true, wantText: "Showing 20 of 45 matching bookmarks"},
+		{name: "limit above the matches", args: map[string]any{"limit": 50}, wantTotal: 45, wantReturned: 45, wantText: "Found 45 bookmarks"},
+		{name: "filtered matches", args: map[string]any{"query": "#tag0", "limit": 10}, wantTotal: 15, wantReturned: 10, wantTruncated: true, wantText: "Showing 10 of 15 matching bookmarks"},
+		{name: "no matches", args: map[string]any{"query": "#missing"}, wantText: "Found 0 bookmarks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "search_bookmarks", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			searchResult := structured[SearchBookmarksResult](t, result)
+			if searchResult.Total != tt.wantTotal || searchResult.Returned != tt.wantReturned ||
+				len(searchResult.Bookmarks) != tt.wantReturned || searchResult.Truncated != tt.wantTruncated {
+				t.Errorf("result = %d of %d (%d listed, truncated %v), want %d of %d (truncated %v)",
+					searchResult.Returned, searchResult.Total, len(searchResult.Bookmarks), searchResult.Truncated,
+					tt.wantReturned, tt.wantTotal, tt.wantTruncated)
+			}
+
+			if !strings.HasPrefix(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't start with %q", resultText(result), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestSearchBookmarksOffset(t *testing.T) {
+	var bookmarks []linkding.Bookmark
+	for i := range 45 {
+		bookmarks = append(bookmarks, linkding.Bookmark{URL: fmt.Sprintf("https://example.com/%d", i)})
+	}
+
+	fake := newFakeLinkding(t, bookmarks...)
+
+	t.Run("following next_offset", func(t *testing.T) {
+		seen := map[int]bool{}
+		offset := 0
+
+		var headers []string
+
+		for range 10 {
+			result := callTool(t, newTestServer(t, fake), "search_bookmarks", map[string]any{"offset": offset})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			headers = append(headers, strings.SplitN(resultText(result), ":", 2)[0])
+
+			searchResult := structured[SearchBookmarksResult](t, result)
+			for _, bookmark := range searchResult.Bookmarks {
+				seen[bookmark.ID] = true
+			}
+
+			if searchResult.NextOffset == 0 {
+				break
+			}
+
+			offset = searchResult.NextOffset
+		}
+
+		if want := []string{"Showing 20 of 45 matching bookmarks", "Showing 21-40 of 45 matching bookmarks", "Showing 41-45 of 45 matching bookmarks"}; !slices.Equal(headers, want) {
+			t.Errorf("pages = %q, want %q", headers, want)
+		}
+
+		if len(seen) != len(bookmarks) {
+			t.Errorf("paged through %d distinct bookmarks, want %d", len(seen), len(bookmarks))
+		}
+	})
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		wantReturned   int
+		wantNextOffset int
+		wantText       string
+		wantError      bool
+	}{
+		{name: "next page hint", args: map[string]any{"offset": 10, "limit": 10}, wantReturned: 10, wantNextOffset: 20, wantText: "pass offset 20"},
+		{name: "last page", args: map[string]any{"offset": 40, "limit": 10}, wantReturned: 5, wantText: "Showing 41-45 of 45"},
+		{name: "past the end", args: map[string]any{"offset": 100}, wantText: "Found 0 bookmarks"},
+		{name: "negative offset", args: map[string]any{"offset": -1}, wantText: "Offset must not be negative", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "search_bookmarks", tt.args)
+			if result.IsError != tt.wantError || !strings.Contains(resultText(result), tt.wantText) {
+				t.Fatalf("result = %q (error %v), want %q (error %v)", resultText(result), result.IsError, tt.wantText, tt.wantError)
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			if searchResult := structured[SearchBookmarksResult](t, result); searchResult.Returned != tt.wantReturned || searchResult.NextOffset != tt.wantNextOffset {
+				t.Errorf("returned %d with next offset %d, want %d and %d", searchResult.Returned, searchResult.NextOffset, tt.wantReturned, tt.wantNextOffset)
+			}
+
+			if strings.Contains(resultText(result), "pass offset") != (tt.wantNextOffset > 0) {
+				t.Errorf("output %q, want a next page hint %v", resultText(result), tt.wantNextOffset > 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+// BookmarkService is the set of Linkding operations the tool handlers depend on.
+// *linkding.Client is the default implementation; alternative backends or
+// fakes can be injected with WithClient.
+type BookmarkService interface {
+	Ping(ctx context.Context) error
+	Health(ctx context.Context) (*linkding.HealthInfo, error)
+	SupportsEndpoint(ctx context.Context, endpoint string) (bool, error)
+
+	GetBookmarks(ctx context.Context, limit, offset int, query string, opts ...linkding.ListOption) (*linkding.BookmarkResponse, error)
+	GetAllBookmarks(ctx context.Context, query string, opts ...linkding.ListOption) ([]linkding.Bookmark, error)
+	GetAllArchivedBookmarks(ctx context.Context, query string, opts ...linkding.ListOption) ([]linkding.Bookmark, error)
+	GetBookmark(ctx context.Context, id int) (*linkding.Bookmark, error)
+	CheckBookmark(ctx context.Context, rawURL string) (*linkding.CheckResult, error)
+	CreateBookmark(ctx context.Context, req linkding.CreateBookmarkRequest) (*linkding.Bookmark, error)
+	SaveBookmark(ctx context.Context, req linkding.CreateBookmarkRequest) (*linkding.Bookmark, bool, error)
+	UpdateBookmark(ctx context.Context, id int, req linkding.CreateBookmarkRequest) (*linkding.Bookmark, error)
+	PatchBookmark(ctx context.Context, id int, fields map[string]any) (*linkding.Bookmark, error)
+	DeleteBookmark(ctx context.Context, id int) error
+	ArchiveBookmark(ctx context.Context, id int) error
+	UnarchiveBookmark(ctx context.Context, id int) error
+
+	GetTags(ctx context.Context, limit, offset int) (*linkding.TagResponse, error)
+	GetAllTags(ctx context.Context) ([]linkding.Tag, error)
+	FindTag(ctx context.Context, name string) (*linkding.Tag, error)
+	CreateTag(ctx context.Context, name string) (*linkding.Tag, error)
+
+	GetSharedBookmarks(ctx context.Context, query string) ([]linkding.Bookmark, error)
+	SharedBookmarkURL(bookmark linkding.Bookmark) string
+	SharedFeedURL() string
+}
+
+var _ BookmarkService = (*linkding.Client)(nil)
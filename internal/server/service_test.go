@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+// stubService is a BookmarkService backed by a slice, for handlers that only
+// list, get and archive bookmarks. The embedded interface is nil, so calling
+// any other method panics.
+type stubService struct {
+	BookmarkService
+
+	bookmarks []linkding.Bookmark
+	archived  []int
+}
+
+func (s *stubService) GetBookmarks(_ context.Context, limit, offset int, query string, _ ...linkding.ListOption) (*linkding.BookmarkResponse, error) {
+	var matching []linkding.Bookmark
+
+	for _, bookmark := range s.bookmarks {
+		if strings.Contains(bookmark.Title, query) {
+			matching = append(matching, bookmark)
+		}
+	}
+
+	page := matching[min(offset, len(matching)):]
+	if limit > 0 {
+		page = page[:min(limit, len(page))]
+	}
+
+	return &linkding.BookmarkResponse{Count: len(matching), Results: page}, nil
+}
+
+func (s *stubService) GetBookmark(_ context.Context, id int) (*linkding.Bookmark, error) {
+	for _, bookmark := range s.bookmarks {
+		if bookmark.ID == id {
+			return &bookmark, nil
+		}
+	}
+
+	return nil, errors.New("not found")
+}
+
+func (s *stubService) ArchiveBookmark(_ context.Context, id int) error {
+	if _, err := s.GetBookmark(context.Background(), id); err != nil {
+		return err
+	}
+
+	s.archived = append(s.archived, id)
+
+	return nil
+}
+
+func TestBookmarkServiceStub(t *testing.T) {
+	tests := []struct {
+		name         string
+		tool         string
+		args         map[string]any
+		wantText     string
+		wantError    bool
+		wantArchived []int
+	}{
+		{name: "search", tool: "search_bookmarks", args: map[string]any{"query": "Go"}, wantText: "Found 2 bookmarks"},
+		{name: "archive", tool: "archive_bookmark", args: map[string]any{"id": 2}, wantText: "Go testing", wantArchived: []int{2}},
+		{name: "archive unknown", tool: "archive_bookmark", args: map[string]any{"id": 9}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubService{bookmarks: []linkding.Bookmark{
+				{ID: 1, URL: "https://a.example", Title: "Go tips"},
+				{ID: 2, URL: "https://b.example", Title: "Go testing"},
+				{ID: 3, URL: "https://c.example", Title: "Cooking"},
+			}}
+
+			result := callTool(t, NewMCP("http://unused.invalid", "token", WithClient(stub)), tt.tool, tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't contain %q", resultText(result), tt.wantText)
+			}
+
+			if !slices.Equal(stub.archived, tt.wantArchived) {
+				t.Errorf("archived %v, want %v", stub.archived, tt.wantArchived)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *MCPServer) handleGetShareLink(ctx context.Context, req *mcpsdk.CallToolRequest, args GetShareLinkArgs) (*mcpsdk.CallToolResult, ShareLinkResult, error) {
+	if args.ID == 0 {
+		return errorResult("Bookmark ID is required"), ShareLinkResult{}, nil
+	}
+
+	bookmark, err := s.linkdingClient.GetBookmark(ctx, args.ID)
+	if err != nil {
+		if notFound := bookmarkNotFound(args.ID, err); notFound != nil {
+			return notFound, ShareLinkResult{}, nil
+		}
+
+		return errorResult("Failed to get bookmark: %v", err), ShareLinkResult{}, nil
+	}
+
+	if !bookmark.Shared && args.Share {
+		bookmark, err = s.linkdingClient.PatchBookmark(ctx, args.ID, map[string]any{"shared": true})
+		if err != nil {
+			return errorResult("Failed to share bookmark: %v", err), ShareLinkResult{}, nil
+		}
+	}
+
+	if !bookmark.Shared {
+		return textResult(fmt.Sprintf("Bookmark %d is not shared. Call get_share_link with share: true to share it.", bookmark.ID)),
+			ShareLinkResult{ID: bookmark.ID}, nil
+	}
+
+	shareResult := ShareLinkResult{
+		ID:       bookmark.ID,
+		Shared:   true,
+		ShareURL: s.linkdingClient.SharedBookmarkURL(*bookmark),
+		FeedURL:  s.linkdingClient.SharedFeedURL(),
+	}
+
+	result := fmt.Sprintf("%s Bookmark %d is shared\n\n• **%s**\n  Share link: %s\n  Shared feed: %s\n\nPublic access requires public sharing to be enabled in the Linkding settings.",
+		s.mark(markLink), bookmark.ID, bookmark.Title, shareResult.ShareURL, shareResult.FeedURL)
+
+	return textResult(result), shareResult, nil
+}
+
+func (s *MCPServer) handleSearchSharedBookmarks(ctx context.Context, req *mcpsdk.CallToolRequest, args SearchSharedBookmarksArgs) (*mcpsdk.CallToolResult, BookmarkListResult, error) {
+	limit := args.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	bookmarks, err := s.linkdingClient.GetSharedBookmarks(ctx, s.searchQuery(args.Query))
+	if err != nil {
+		return errorResult("Failed to read shared bookmarks (is public sharing enabled in Linkding?): %v", err), BookmarkListResult{}, nil
+	}
+
+	listResult := summarizeBookmarks(bookmarks)
+	if len(listResult.Bookmarks) > limit {
+		listResult.Bookmarks = listResult.Bookmarks[:limit]
+	}
+
+	result := fmt.Sprintf("Found %s:\n\n", pluralize(listResult.Total, "shared bookmark"))
+	for _, bookmark := range listResult.Bookmarks {
+		result += fmt.Sprintf("• **%s**\n  URL: %s\n", bookmark.Title, bookmark.URL)
+
+		if len(bookmark.Tags) > 0 {
+			result += fmt.Sprintf("  Tags: %v\n", bookmark.Tags)
+		}
+
+		result += "\n"
+	}
+
+	return textResult(result), listResult, nil
+}
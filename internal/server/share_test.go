@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGetShareLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		bookmark   linkding.Bookmark
+		share      bool
+		wantShared bool
+		wantPatch  bool
+	}{
+		{name: "shared bookmark", bookmark: linkding.Bookmark{ID: 1, URL: "https://a.example/?x=1&y=2", Shared: true}, wantShared: true},
+		{name: "private bookmark left private", bookmark: linkding.Bookmark{ID: 1, URL: "https://a.example"}},
+		{name: "private bookmark shared on request", bookmark: linkding.Bookmark{ID: 1, URL: "https://a.example"}, share: true, wantShared: true, wantPatch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, tt.bookmark)
+
+			result := callTool(t, newTestServer(t, fake), "get_share_link", map[string]any{"id": 1, "share": tt.share})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			shareResult := structured[ShareLinkResult](t, result)
+			if shareResult.Shared != tt.wantShared {
+				t.Fatalf("shared = %v, want %v", shareResult.Shared, tt.wantShared)
+			}
+
+			if patches := fake.received(http.MethodPatch, "/api/bookmarks/1/"); (len(patches) > 0) != tt.wantPatch {
+				t.Errorf("sent %d PATCH requests, want patch %v", len(patches), tt.wantPatch)
+			}
+
+			if !tt.wantShared {
+				if shareResult.ShareURL != "" {
+					t.Errorf("share URL %q for a private bookmark", shareResult.ShareURL)
+				}
+
+				return
+			}
+
+			if want := fake.URL + "/bookmarks/shared?q=" + url.QueryEscape(tt.bookmark.URL); shareResult.ShareURL != want {
+				t.Errorf("share URL = %q, want %q", shareResult.ShareURL, want)
+			}
+
+			if shareResult.FeedURL != fake.URL+"/feeds/shared" {
+				t.Errorf("feed URL = %q", shareResult.FeedURL)
+			}
+		})
+	}
+}
+
+func TestPublicOnlyMode(t *testing.T) {
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{URL: "https://a.example", Title: "Go tips", TagNames: []string{"go"}, Shared: true},
+		linkding.Bookmark{URL: "https://b.example", Title: "Go secrets"},
+		linkding.Bookmark{URL: "https://c.example", Title: "Cooking", Shared: true},
+	)
+
+	client := linkding.NewClient(fake.URL, "", linkding.WithRetries(0))
+	s := NewMCP(fake.URL, "", WithClient(client))
+
+	if !s.PublicOnly() {
+		t.Fatal("server without a token isn't public-only")
+	}
+
+	session := connect(t, s, nil)
+
+	tools, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+
+	slices.Sort(names)
+
+	// Only read-only tools are offered
+	if !slices.Equal(names, []string{"list_capabilities", "search_shared_bookmarks"}) {
+		t.Errorf("tools = %v, want only search_shared_bookmarks and list_capabilities", names)
+	}
+
+	tests := []struct {
+		query    string
+		wantURLs []string
+	}{
+		{query: "go", wantURLs: []string{"https://a.example"}},
+		{wantURLs: []string{"https://a.example", "https://c.example"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("query "+tt.query, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcpsdk.CallToolParams{Name: "search_shared_bookmarks", Arguments: map[string]any{"query": tt.query}})
+			if err != nil || result.IsError {
+				t.Fatalf("search failed: %v %s", err, resultText(result))
+			}
+
+			var urls []string
+			for _, bookmark := range structured[BookmarkListResult](t, result).Bookmarks {
+				urls = append(urls, bookmark.URL)
+			}
+
+			if !slices.Equal(urls, tt.wantURLs) {
+				t.Errorf("URLs = %v, want %v", urls, tt.wantURLs)
+			}
+		})
+	}
+
+	if _, err := session.CallTool(context.Background(), &mcpsdk.CallToolParams{Name: "delete_bookmark", Arguments: map[string]any{"id": 1}}); err == nil {
+		t.Error("delete_bookmark is callable without a token")
+	}
+
+	if err := s.Warmup(context.Background()); err != nil {
+		t.Errorf("Warmup() = %v", err)
+	}
+
+	for _, r := range fake.received(http.MethodGet, "/") {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("%s %s sent an Authorization header", r.Method, r.Path)
+		}
+
+		if r.Path != "/feeds/shared" && r.Path != "/health" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Path)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultTitleSimilarity = 80
+	minTitleSimilarity     = 50
+	defaultSimilarGroups   = 20
+	maxSimilarGroups       = 100
+	// maxTitleComparisons bounds the pairwise comparisons of one call. Titles
+	// are only compared within blocks sharing their first word, which keeps
+	// the count low on most libraries.
+	maxTitleComparisons = 500000
+)
+
+func (s *MCPServer) handleFindSimilarTitles(ctx context.Context, req *mcpsdk.CallToolRequest, args FindSimilarTitlesArgs) (*mcpsdk.CallToolResult, SimilarTitlesResult, error) {
+	threshold := clamp(args.Similarity, defaultTitleSimilarity, 100)
+	if threshold < minTitleSimilarity {
+		return errorResult("Similarity must be at least %d", minTitleSimilarity), SimilarTitlesResult{}, nil
+	}
+
+	limit := clamp(args.Limit, defaultSimilarGroups, maxSimilarGroups)
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), SimilarTitlesResult{}, nil
+	}
+
+	groups, complete := groupSimilarTitles(bookmarks, float64(threshold)/100)
+
+	similarResult := SimilarTitlesResult{Total: len(groups), Complete: complete, Groups: []BookmarkListResult{}}
+	for _, group := range groups[:min(len(groups), limit)] {
+		similarResult.Groups = append(similarResult.Groups, summarizeBookmarks(group))
+	}
+
+	if len(groups) == 0 {
+		result := fmt.Sprintf("No similar titles found among %s", pluralize(len(bookmarks), "bookmark"))
+		if !complete {
+			result += " (comparison limit reached, narrow the query to check the rest)"
+		}
+
+		return textResult(result), similarResult, nil
+	}
+
+	result := fmt.Sprintf("Found %s of bookmarks with similar titles but different URLs", pluralize(len(groups), "group"))
+	if len(groups) > limit {
+		result += fmt.Sprintf(", showing %d", limit)
+	}
+
+	result += ":\n\n"
+
+	for i, group := range similarResult.Groups {
+		result += fmt.Sprintf("Group %d:\n", i+1)
+
+		for _, bookmark := range group.Bookmarks {
+			result += fmt.Sprintf("• **%s**\n  URL: %s\n  ID: %d\n", bookmark.Title, bookmark.URL, bookmark.ID)
+		}
+
+		result += "\n"
+	}
+
+	if !complete {
+		result += s.mark(markWarning) + " Comparison limit reached; narrow the query to check the remaining bookmarks.\n"
+	}
+
+	return textResult(result), similarResult, nil
+}
+
+// groupSimilarTitles groups bookmarks whose normalized titles are at least
+// threshold similar while their URLs differ. Only titles sharing the first
+// word are compared. Groups are sorted by size, largest first. complete is
+// false when maxTitleComparisons was reached before all blocks were compared.
+func groupSimilarTitles(bookmarks []linkding.Bookmark, threshold float64) ([][]linkding.Bookmark, bool) {
+	words := make([][]string, len(bookmarks))
+	blocks := map[string][]int{}
+
+	for i, bookmark := range bookmarks {
+		words[i] = titleWords(bookmark.Title)
+		if len(words[i]) > 0 {
+			blocks[words[i][0]] = append(blocks[words[i][0]], i)
+		}
+	}
+
+	// parent implements a union-find over bookmark indexes
+	parent := make([]int, len(bookmarks))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	comparisons, complete := 0, true
+
+	// Compare blocks in a fixed order so truncated results are reproducible
+	keys := make([]string, 0, len(blocks))
+	for key := range blocks {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+compare:
+	for _, key := range keys {
+		block := blocks[key]
+
+		for a := 0; a < len(block); a++ {
+			for b := a + 1; b < len(block); b++ {
+				if comparisons >= maxTitleComparisons {
+					complete = false
+
+					break compare
+				}
+
+				comparisons++
+
+				i, j := block[a], block[b]
+				if normalizeURL(bookmarks[i].URL) == normalizeURL(bookmarks[j].URL) {
+					continue
+				}
+
+				if titleSimilarity(words[i], words[j]) >= threshold {
+					parent[find(i)] = find(j)
+				}
+			}
+		}
+	}
+
+	members := map[int][]linkding.Bookmark{}
+	for i, bookmark := range bookmarks {
+		root := find(i)
+		members[root] = append(members[root], bookmark)
+	}
+
+	var groups [][]linkding.Bookmark
+
+	for _, group := range members {
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i]) != len(groups[j]) {
+			return len(groups[i]) > len(groups[j])
+		}
+
+		return groups[i][0].ID < groups[j][0].ID
+	})
+
+	return groups, complete
+}
+
+// titleWords lowercases a title and splits it into words, dropping punctuation
+func titleWords(title string) []string {
+	return strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// titleSimilarity compares two titles as the better of their word overlap
+// (Jaccard index) and their character similarity (1 - edit distance relative
+// to the longer title), between 0 and 1
+func titleSimilarity(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, word := range a {
+		set[word] = true
+	}
+
+	union := len(set)
+	shared := 0
+
+	seen := make(map[string]bool, len(b))
+
+	for _, word := range b {
+		if seen[word] {
+			continue
+		}
+
+		seen[word] = true
+
+		if set[word] {
+			shared++
+		} else {
+			union++
+		}
+	}
+
+	overlap := float64(shared) / float64(union)
+
+	joinedA, joinedB := strings.Join(a, " "), strings.Join(b, " ")
+	longest := max(len([]rune(joinedA)), len([]rune(joinedB)))
+	characters := 1 - float64(editDistance(joinedA, joinedB))/float64(longest)
+
+	return max(overlap, characters)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		atLeast float64
+		below   float64
+	}{
+		{a: "Understanding Go Generics", b: "understanding go generics!", atLeast: 1},
+		{a: "Understanding Go Generics", b: "Understanding Generics Go", atLeast: 1},
+		{a: "Understanding Go Generics", b: "Understanding Go Generic", atLeast: 0.9, below: 1},
+		{a: "Understanding Go Generics", b: "Understanding Rust Lifetimes", below: defaultTitleSimilarity / 100.0},
+		{a: "Go", b: "Proxy", below: 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s vs %s", tt.a, tt.b), func(t *testing.T) {
+			got := titleSimilarity(titleWords(tt.a), titleWords(tt.b))
+			if got < tt.atLeast || (tt.below > 0 && got >= tt.below) {
+				t.Errorf("similarity = %.2f, want at least %.2f and below %.2f", got, tt.atLeast, tt.below)
+			}
+		})
+	}
+}
+
+func TestGroupSimilarTitles(t *testing.T) {
+	bookmarks := []linkding.Bookmark{
+		{ID: 1, URL: "https://blog.example/generics", Title: "Understanding Go Generics"},
+		{ID: 2, URL: "https://mirror.example/post/42", Title: "Understanding Go generics!"},
+		{ID: 3, URL: "https://news.example/item?id=7", Title: "Understanding Go Generic"},
+		{ID: 4, URL: "https://rust.example/lifetimes", Title: "Understanding Rust Lifetimes"},
+		// Same URL as bookmark 5, so not reported as a near-duplicate
+		{ID: 5, URL: "https://example.com/page/", Title: "Release Notes"},
+		{ID: 6, URL: "https://EXAMPLE.com/page", Title: "Release notes"},
+		// Similar, but blocked apart by their first word
+		{ID: 7, URL: "https://a.example", Title: "The Release Notes"},
+		{ID: 8, URL: "https://b.example", Title: "Weekly Digest 12"},
+		{ID: 9, URL: "https://c.example", Title: "Weekly Digest 13"},
+		{ID: 10, URL: "https://d.example"},
+		{ID: 11, URL: "https://e.example"},
+	}
+
+	groups, complete := groupSimilarTitles(bookmarks, 0.8)
+	if !complete {
+		t.Error("complete = false, want true")
+	}
+
+	var got [][]int
+
+	for _, group := range groups {
+		var ids []int
+		for _, bookmark := range group {
+			ids = append(ids, bookmark.ID)
+		}
+
+		slices.Sort(ids)
+		got = append(got, ids)
+	}
+
+	want := [][]int{{1, 2, 3}, {8, 9}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("groups = %v, want %v", got, want)
+	}
+}
+
+func TestFindSimilarTitles(t *testing.T) {
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{ID: 1, URL: "https://blog.example/generics", Title: "Understanding Go Generics", TagNames: []string{"go"}},
+		linkding.Bookmark{ID: 2, URL: "https://mirror.example/42", Title: "Understanding Go generics", TagNames: []string{"go"}},
+		linkding.Bookmark{ID: 3, URL: "https://b.example", Title: "Weekly Digest 12"},
+		linkding.Bookmark{ID: 4, URL: "https://c.example", Title: "Weekly Digest 13"},
+		linkding.Bookmark{ID: 5, URL: "https://d.example", Title: "Something else entirely"},
+	)
+
+	tests := []struct {
+		name       string
+		args       map[string]any
+		wantGroups int
+		wantTotal  int
+		wantText   string
+		wantError  bool
+	}{
+		{name: "default similarity", args: map[string]any{}, wantGroups: 2, wantTotal: 2, wantText: "Found 2 groups"},
+		{name: "strict similarity", args: map[string]any{"similarity": 100}, wantGroups: 1, wantTotal: 1},
+		{name: "limited groups", args: map[string]any{"limit": 1}, wantGroups: 1, wantTotal: 2, wantText: "showing 1"},
+		{name: "narrowed by query", args: map[string]any{"query": "#go"}, wantGroups: 1, wantTotal: 1},
+		{name: "nothing similar", args: map[string]any{"query": "else"}, wantText: "No similar titles found among 1 bookmark"},
+		{name: "similarity too low", args: map[string]any{"similarity": 20}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "find_similar_titles", tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			similarResult := structured[SimilarTitlesResult](t, result)
+			if len(similarResult.Groups) != tt.wantGroups || similarResult.Total != tt.wantTotal || !similarResult.Complete {
+				t.Errorf("got %d of %d groups (complete %v), want %d of %d", len(similarResult.Groups), similarResult.Total, similarResult.Complete, tt.wantGroups, tt.wantTotal)
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't contain %q", resultText(result), tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *MCPServer) handleApplyTagRules(ctx context.Context, req *mcpsdk.CallToolRequest, args ApplyTagRulesArgs) (*mcpsdk.CallToolResult, ApplyTagRulesResult, error) {
+	if len(args.Rules) == 0 {
+		return errorResult("At least one rule is required"), ApplyTagRulesResult{}, nil
+	}
+
+	var ruleTags []string
+
+	for i, rule := range args.Rules {
+		if strings.TrimSpace(rule.Domain) == "" && strings.TrimSpace(rule.TitleContains) == "" {
+			return errorResult("Rule %d has no condition, set domain or title_contains", i+1), ApplyTagRulesResult{}, nil
+		}
+
+		if len(rule.Tags) == 0 {
+			return errorResult("Rule %d has no tags to apply", i+1), ApplyTagRulesResult{}, nil
+		}
+
+		ruleTags = append(ruleTags, rule.Tags...)
+	}
+
+	if disallowed := s.checkVocabulary(ruleTags); disallowed != nil {
+		return disallowed, ApplyTagRulesResult{}, nil
+	}
+
+	bookmarks, err := s.linkdingClient.GetAllBookmarks(ctx, s.searchQuery(args.Query))
+	if err != nil {
+		return errorResult("Failed to search bookmarks: %v", err), ApplyTagRulesResult{}, nil
+	}
+
+	rulesResult := ApplyTagRulesResult{Checked: len(bookmarks), Assignments: evaluateTagRules(bookmarks, args.Rules)}
+
+	if len(rulesResult.Assignments) == 0 {
+		return textResult(fmt.Sprintf("No rule adds tags to any of %s", pluralize(len(bookmarks), "bookmark"))), rulesResult, nil
+	}
+
+	if args.Preview {
+		result := fmt.Sprintf("Preview: the rules would tag %s of %d (nothing was changed):\n\n",
+			pluralize(len(rulesResult.Assignments), "bookmark"), len(bookmarks))
+		result += formatTagAssignments(rulesResult.Assignments)
+
+		return textResult(result), rulesResult, nil
+	}
+
+	if tooMany := s.checkBulkLimit(len(rulesResult.Assignments)); tooMany != nil {
+		return tooMany, ApplyTagRulesResult{}, nil
+	}
+
+	ids := make([]int, len(rulesResult.Assignments))
+	tags := make(map[int][]string, len(rulesResult.Assignments))
+
+	for i, assignment := range rulesResult.Assignments {
+		ids[i] = assignment.ID
+		tags[assignment.ID] = assignment.Tags
+	}
+
+	// PATCH replaces tag_names wholesale, so send the merged tags
+	applied := s.runBulk(ctx, req, ids, func(ctx context.Context, id int) error {
+		_, err := s.linkdingClient.PatchBookmark(ctx, id, map[string]any{"tag_names": tags[id]})
+
+		return err
+	})
+
+	rulesResult.Applied = &applied
+
+	result := s.formatBulkResult("Tagged", applied) + "\n\n" + formatTagAssignments(rulesResult.Assignments)
+
+	return textResult(result), rulesResult, nil
+}
+
+// formatTagAssignments lists the tags added to each bookmark for tool output
+func formatTagAssignments(assignments []TagAssignment) string {
+	var result string
+
+	for _, assignment := range assignments {
+		result += fmt.Sprintf("• **%s** (ID %d): +%s\n", assignment.Title, assignment.ID, strings.Join(assignment.Added, ", +"))
+	}
+
+	return result
+}
+
+// evaluateTagRules returns, for every bookmark matched by at least one rule
+// that lacks some of the rule's tags, the tags to add and the resulting tags
+func evaluateTagRules(bookmarks []linkding.Bookmark, rules []TagRule) []TagAssignment {
+	assignments := make([]TagAssignment, 0)
+
+	for _, bookmark := range bookmarks {
+		var added []string
+
+		for _, rule := range rules {
+			if !matchesTagRule(bookmark, rule) {
+				continue
+			}
+
+			for _, tag := range rule.Tags {
+				if tag = strings.TrimSpace(tag); tag != "" && !containsTag(bookmark.TagNames, tag) && !containsTag(added, tag) {
+					added = append(added, tag)
+				}
+			}
+		}
+
+		if len(added) == 0 {
+			continue
+		}
+
+		assignments = append(assignments, TagAssignment{
+			ID:    bookmark.ID,
+			URL:   bookmark.URL,
+			Title: bookmark.Title,
+			Added: added,
+			Tags:  mergeTags(bookmark.TagNames, added, nil),
+		})
+	}
+
+	return assignments
+}
+
+// matchesTagRule reports whether a bookmark meets all conditions of a rule.
+// Title keywords match whole words, ignoring case and spacing.
+func matchesTagRule(bookmark linkding.Bookmark, rule TagRule) bool {
+	if domain := normalizeDomain(strings.TrimSpace(rule.Domain)); domain != "" && !matchesDomain(bookmarkHost(bookmark.URL), domain) {
+		return false
+	}
+
+	if keyword := normalizeText(rule.TitleContains); keyword != "" && !containsWords(normalizeText(bookmark.Title), keyword) {
+		return false
+	}
+
+	return true
+}
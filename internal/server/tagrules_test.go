@@ -0,0 +1,198 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func tagRuleFixtures() []linkding.Bookmark {
+	return []linkding.Bookmark{
+		{ID: 1, URL: "https://github.com/golang/go", Title: "The Go Programming Language", TagNames: []string{"go"}},
+		{ID: 2, URL: "https://gist.github.com/someone/123", Title: "Shell snippets"},
+		{ID: 3, URL: "https://notgithub.com/x", Title: "Kubernetes operators in Go"},
+		{ID: 4, URL: "https://blog.example/k8s", Title: "Running KUBERNETES at home", TagNames: []string{"homelab"}},
+		{ID: 5, URL: "https://news.example", Title: "Daily news"},
+	}
+}
+
+func TestEvaluateTagRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []TagRule
+		want  map[int][]string
+	}{
+		{
+			name:  "domain includes subdomains only",
+			rules: []TagRule{{Domain: "github.com", Tags: []string{"code"}}},
+			want:  map[int][]string{1: {"code"}, 2: {"code"}},
+		},
+		{
+			name:  "title words ignore case",
+			rules: []TagRule{{TitleContains: "kubernetes", Tags: []string{"k8s"}}},
+			want:  map[int][]string{3: {"k8s"}, 4: {"k8s"}},
+		},
+		{
+			name:  "both conditions must match",
+			rules: []TagRule{{Domain: "github.com", TitleContains: "go", Tags: []string{"golang"}}},
+			want:  map[int][]string{1: {"golang"}},
+		},
+		{
+			name:  "existing tags not added again",
+			rules: []TagRule{{TitleContains: "go", Tags: []string{"Go", "lang"}}},
+			want:  map[int][]string{1: {"lang"}, 3: {"Go", "lang"}},
+		},
+		{
+			name: "tags of several rules combined",
+			rules: []TagRule{
+				{Domain: "github.com", Tags: []string{"code"}},
+				{TitleContains: "go", Tags: []string{"code", "golang"}},
+			},
+			want: map[int][]string{1: {"code", "golang"}, 2: {"code"}, 3: {"code", "golang"}},
+		},
+		{
+			name:  "nothing matches",
+			rules: []TagRule{{Domain: "gitlab.com", Tags: []string{"code"}}},
+			want:  map[int][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[int][]string{}
+			for _, assignment := range evaluateTagRules(tagRuleFixtures(), tt.rules) {
+				got[assignment.ID] = assignment.Added
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("added %v, want %v", got, tt.want)
+			}
+
+			for id, want := range tt.want {
+				if !slices.Equal(got[id], want) {
+					t.Errorf("bookmark %d added %v, want %v", id, got[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyTagRules(t *testing.T) {
+	rules := []map[string]any{
+		{"domain": "github.com", "tags": []string{"code"}},
+		{"title_contains": "kubernetes", "tags": []string{"k8s"}},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		wantTags    map[int][]string
+		wantApplied int
+		wantText    string
+		wantError   string
+	}{
+		{
+			name:        "tags merged into existing ones",
+			args:        map[string]any{"rules": rules},
+			wantTags:    map[int][]string{1: {"go", "code"}, 2: {"code"}, 3: {"k8s"}, 4: {"homelab", "k8s"}},
+			wantApplied: 4,
+			wantText:    "+code",
+		},
+		{
+			name:     "preview changes nothing",
+			args:     map[string]any{"rules": rules, "preview": true},
+			wantTags: map[int][]string{1: {"go"}, 4: {"homelab"}},
+			wantText: "Preview: the rules would tag 4 bookmarks of 5",
+		},
+		{
+			name:        "narrowed by query",
+			args:        map[string]any{"rules": rules, "query": "#homelab"},
+			wantTags:    map[int][]string{1: {"go"}, 4: {"homelab", "k8s"}},
+			wantApplied: 1,
+		},
+		{name: "no rules", args: map[string]any{"rules": []map[string]any{}}, wantError: "At least one rule is required"},
+		{name: "rule without condition", args: map[string]any{"rules": []map[string]any{{"tags": []string{"x"}}}}, wantError: "Rule 1 has no condition"},
+		{name: "rule without tags", args: map[string]any{"rules": []map[string]any{{"domain": "github.com", "tags": []string{}}}}, wantError: "Rule 1 has no tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t, tagRuleFixtures()...)
+
+			result := callTool(t, newTestServer(t, fake), "apply_tag_rules", tt.args)
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(resultText(result), tt.wantError) {
+					t.Fatalf("result = %q, want error %q", resultText(result), tt.wantError)
+				}
+
+				return
+			}
+
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			rulesResult := structured[ApplyTagRulesResult](t, result)
+			if applied := rulesResult.Applied; (applied == nil) != (tt.wantApplied == 0) || (applied != nil && applied.Succeeded != tt.wantApplied) {
+				t.Errorf("applied = %+v, want %d succeeded", applied, tt.wantApplied)
+			}
+
+			for id, want := range tt.wantTags {
+				if bookmark, _ := fake.bookmark(id); !slices.Equal(bookmark.TagNames, want) {
+					t.Errorf("bookmark %d tags = %v, want %v", id, bookmark.TagNames, want)
+				}
+			}
+
+			if !strings.Contains(resultText(result), tt.wantText) {
+				t.Errorf("output %q doesn't contain %q", resultText(result), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestApplyTagRulesPreview(t *testing.T) {
+	tests := []struct {
+		preview     bool
+		wantPatches int
+	}{
+		{preview: true},
+		{preview: false, wantPatches: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("preview %v", tt.preview), func(t *testing.T) {
+			fake := newFakeLinkding(t, tagRuleFixtures()...)
+
+			result := callTool(t, newTestServer(t, fake), "apply_tag_rules", map[string]any{
+				"rules":   []map[string]any{{"domain": "github.com", "tags": []string{"code"}}},
+				"preview": tt.preview,
+			})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			// Preview and apply report the same evaluation
+			var ids []int
+			for _, assignment := range structured[ApplyTagRulesResult](t, result).Assignments {
+				ids = append(ids, assignment.ID)
+			}
+
+			if !slices.Equal(ids, []int{2, 1}) {
+				t.Errorf("assignments for %v, want bookmarks 1 and 2", ids)
+			}
+
+			var mutating int
+			for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+				mutating += len(fake.received(method, "/api/"))
+			}
+
+			if patches := len(fake.received(http.MethodPatch, "/api/bookmarks/")); mutating != tt.wantPatches || patches != tt.wantPatches {
+				t.Errorf("sent %d mutating requests (%d PATCH), want %d PATCH only", mutating, patches, tt.wantPatches)
+			}
+		})
+	}
+}
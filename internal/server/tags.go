@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// countTagUsage pages through all bookmarks, including archived ones, and
+// counts how many use each tag. Counts are keyed by lowercased tag name since
+// Linkding tags are case-insensitive. Linkding's tag endpoint doesn't expose
+// usage counts, so this is expensive on large libraries.
+func (s *MCPServer) countTagUsage(ctx context.Context) (map[string]int, error) {
+	bookmarks, err := s.getEntireLibrary(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+
+	for _, bookmark := range bookmarks {
+		for _, tag := range bookmark.TagNames {
+			counts[strings.ToLower(tag)]++
+		}
+	}
+
+	return counts, nil
+}
+
+func (s *MCPServer) handleFindOrphanedTags(ctx context.Context, req *mcpsdk.CallToolRequest, args FindOrphanedTagsArgs) (*mcpsdk.CallToolResult, TagsResult, error) {
+	tags, err := s.linkdingClient.GetAllTags(ctx)
+	if err != nil {
+		return errorResult("Failed to get tags: %v", err), TagsResult{}, nil
+	}
+
+	counts, err := s.countTagUsage(ctx)
+	if err != nil {
+		return errorResult("Failed to count tag usage: %v", err), TagsResult{}, nil
+	}
+
+	tagsResult := TagsResult{Tags: make([]TagResult, 0)}
+
+	for _, tag := range tags {
+		if counts[strings.ToLower(tag.Name)] == 0 {
+			tagsResult.Tags = append(tagsResult.Tags, TagResult{ID: tag.ID, Name: tag.Name})
+		}
+	}
+
+	if len(tagsResult.Tags) == 0 {
+		return textResult(fmt.Sprintf("No orphaned tags found among %s", pluralize(len(tags), "tag"))), tagsResult, nil
+	}
+
+	result := fmt.Sprintf("Found %s (not used by any bookmark):\n\n", pluralize(len(tagsResult.Tags), "orphaned tag"))
+	for _, tag := range tagsResult.Tags {
+		result += fmt.Sprintf("• %s (ID: %d)\n", tag.Name, tag.ID)
+	}
+
+	result += "\nThese are only reported: Linkding's API doesn't support deleting tags."
+
+	return textResult(result), tagsResult, nil
+}
+
+func (s *MCPServer) handleSyncTags(ctx context.Context, req *mcpsdk.CallToolRequest, args SyncTagsArgs) (*mcpsdk.CallToolResult, SyncTagsResult, error) {
+	if len(args.Tags) == 0 {
+		return errorResult("At least one tag is required"), SyncTagsResult{}, nil
+	}
+
+	tags, err := s.linkdingClient.GetAllTags(ctx)
+	if err != nil {
+		return errorResult("Failed to get tags: %v", err), SyncTagsResult{}, nil
+	}
+
+	remote := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		remote[strings.ToLower(tag.Name)] = tag.Name
+	}
+
+	syncResult := SyncTagsResult{
+		Existing: []string{},
+		Created:  []string{},
+		Extra:    []string{},
+	}
+	desired := map[string]bool{}
+
+	for _, name := range args.Tags {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" || desired[key] {
+			continue
+		}
+
+		desired[key] = true
+
+		if existing, ok := remote[key]; ok {
+			syncResult.Existing = append(syncResult.Existing, existing)
+
+			continue
+		}
+
+		// The tag list was just fetched, so create the tag right away; only a
+		// 400, most likely from a tag created concurrently, is worth a lookup
+		created, err := s.linkdingClient.CreateTag(ctx, strings.TrimSpace(name))
+
+		var apiErr *linkding.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			if existing, findErr := s.linkdingClient.FindTag(ctx, strings.TrimSpace(name)); findErr == nil && existing != nil {
+				syncResult.Existing = append(syncResult.Existing, existing.Name)
+
+				continue
+			}
+		}
+
+		if err != nil {
+			if syncResult.Failed == nil {
+				syncResult.Failed = map[string]string{}
+			}
+
+			syncResult.Failed[name] = err.Error()
+
+			continue
+		}
+
+		syncResult.Created = append(syncResult.Created, created.Name)
+	}
+
+	for key, name := range remote {
+		if !desired[key] {
+			syncResult.Extra = append(syncResult.Extra, name)
+		}
+	}
+
+	slices.Sort(syncResult.Extra)
+
+	return textResult(formatSyncTags(syncResult)), syncResult, nil
+}
+
+// formatSyncTags renders a tag reconciliation report
+func formatSyncTags(r SyncTagsResult) string {
+	result := fmt.Sprintf("Synced tags: %d existing, %d created, %d extra\n", len(r.Existing), len(r.Created), len(r.Extra))
+
+	if len(r.Created) > 0 {
+		result += fmt.Sprintf("\nCreated: %s\n", strings.Join(r.Created, ", "))
+	}
+
+	if len(r.Failed) > 0 {
+		result += "\nFailed to create:\n"
+
+		for _, name := range slices.Sorted(maps.Keys(r.Failed)) {
+			result += fmt.Sprintf("• %s: %s\n", name, r.Failed[name])
+		}
+	}
+
+	if len(r.Extra) > 0 {
+		result += fmt.Sprintf("\nNot in the canonical list: %s\n", strings.Join(r.Extra, ", "))
+	}
+
+	return result
+}
+
+// tagSeparator separates levels in hierarchical tag names like "dev/go"
+const tagSeparator = "/"
+
+func (s *MCPServer) handleExportTags(ctx context.Context, req *mcpsdk.CallToolRequest, args ExportTagsArgs) (*mcpsdk.CallToolResult, ExportTagsResult, error) {
+	tags, err := s.linkdingClient.GetAllTags(ctx)
+	if err != nil {
+		return errorResult("Failed to get tags: %v", err), ExportTagsResult{}, nil
+	}
+
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+
+	exportResult := ExportTagsResult{
+		Total:        len(names),
+		Hierarchical: slices.ContainsFunc(names, func(name string) bool { return strings.Contains(name, tagSeparator) }),
+	}
+
+	if exportResult.Hierarchical {
+		exportResult.Markdown = renderTagTree(names)
+	} else {
+		exportResult.Markdown = renderTagList(names)
+	}
+
+	return textResult(exportResult.Markdown), exportResult, nil
+}
+
+// renderTagList renders tags as a flat markdown list, sorted case-insensitively
+func renderTagList(names []string) string {
+	slices.SortFunc(names, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Tags\n\n%s\n\n", pluralize(len(names), "tag"))
+
+	for _, name := range names {
+		b.WriteString("- " + name + "\n")
+	}
+
+	return b.String()
+}
+
+// renderTagTree renders slash-separated tags as a nested markdown list, e.g.
+// "dev/go" becomes "go" below "dev". Parent levels are listed even when they
+// aren't tags themselves.
+func renderTagTree(names []string) string {
+	paths := make([][]string, len(names))
+	for i, name := range names {
+		paths[i] = strings.Split(name, tagSeparator)
+	}
+
+	// Sorting by level keeps children right after their parent
+	slices.SortFunc(paths, func(a, b []string) int {
+		return slices.CompareFunc(a, b, func(x, y string) int {
+			return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+		})
+	})
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Tags\n\n%s\n\n", pluralize(len(names), "tag"))
+
+	var previous []string
+
+	for _, path := range paths {
+		// Skip the levels shared with the previous tag, they are already listed
+		shared := 0
+		for shared < len(previous) && shared < len(path) && strings.EqualFold(previous[shared], path[shared]) {
+			shared++
+		}
+
+		for depth := shared; depth < len(path); depth++ {
+			b.WriteString(strings.Repeat("  ", depth) + "- " + path[depth] + "\n")
+		}
+
+		previous = path
+	}
+
+	return b.String()
+}
@@ -0,0 +1,359 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+)
+
+func TestGetTagsWithCounts(t *testing.T) {
+	fake := newFakeLinkding(t,
+		linkding.Bookmark{URL: "https://a.example", TagNames: []string{"go", "web"}},
+		linkding.Bookmark{URL: "https://b.example", TagNames: []string{"Go"}},
+		linkding.Bookmark{URL: "https://c.example", TagNames: []string{"go"}, IsArchived: true},
+		linkding.Bookmark{URL: "https://d.example", TagNames: []string{"unused"}, IsArchived: true},
+	)
+	// A tag no bookmark uses anymore
+	fake.ensureTag("stale")
+
+	tests := []struct {
+		name       string
+		args       map[string]any
+		wantCounts map[string]int
+	}{
+		{
+			name:       "counts include archived bookmarks and ignore case",
+			args:       map[string]any{"with_counts": true, "all": true},
+			wantCounts: map[string]int{"go": 3, "web": 1, "unused": 1, "stale": 0},
+		},
+		{
+			name: "without counts",
+			args: map[string]any{"all": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, newTestServer(t, fake), "get_tags", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			tagsResult := structured[TagsResult](t, result)
+			if len(tagsResult.Tags) != 4 {
+				t.Fatalf("got %d tags, want 4", len(tagsResult.Tags))
+			}
+
+			for _, tag := range tagsResult.Tags {
+				if tt.wantCounts == nil {
+					if tag.Count != nil {
+						t.Errorf("tag %s has a count without with_counts", tag.Name)
+					}
+
+					continue
+				}
+
+				if tag.Count == nil || *tag.Count != tt.wantCounts[tag.Name] {
+					t.Errorf("tag %s count = %v, want %d", tag.Name, tag.Count, tt.wantCounts[tag.Name])
+				}
+			}
+
+			if tt.wantCounts != nil && !strings.Contains(resultText(result), "go (ID: 1, 3 bookmarks)") {
+				t.Errorf("output doesn't show the count: %q", resultText(result))
+			}
+		})
+	}
+}
+
+func TestFindOrphanedTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		used     []string
+		archived []string
+		unused   []string
+		want     []string
+	}{
+		{name: "some unused", used: []string{"go", "web"}, unused: []string{"stale", "old"}, want: []string{"stale", "old"}},
+		{name: "used by an archived bookmark", used: []string{"go"}, archived: []string{"Legacy"}, unused: []string{"legacy"}},
+		{name: "none unused", used: []string{"go"}},
+		{name: "no bookmarks", unused: []string{"stale"}, want: []string{"stale"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bookmarks []linkding.Bookmark
+			if len(tt.used) > 0 {
+				bookmarks = append(bookmarks, linkding.Bookmark{URL: "https://a.example", TagNames: tt.used})
+			}
+
+			if len(tt.archived) > 0 {
+				bookmarks = append(bookmarks, linkding.Bookmark{URL: "https://b.example", TagNames: tt.archived, IsArchived: true})
+			}
+
+			fake := newFakeLinkding(t, bookmarks...)
+			for _, name := range tt.unused {
+				fake.ensureTag(name)
+			}
+
+			result := callTool(t, newTestServer(t, fake), "find_orphaned_tags", map[string]any{})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			var got []string
+			for _, tag := range structured[TagsResult](t, result).Tags {
+				got = append(got, tag.Name)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("orphaned tags = %v, want %v", got, tt.want)
+			}
+
+			if deletes := fake.received("DELETE", "/api/tags/"); len(deletes) > 0 {
+				t.Errorf("tags were deleted: %v", deletes)
+			}
+		})
+	}
+}
+
+func TestSyncTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		tags       []string
+		failCreate bool
+		// createdConcurrently makes tag creation fail with 400 after
+		// creating the tag, as if another client had just created it
+		createdConcurrently bool
+		wantExisting        []string
+		wantCreated         []string
+		wantExtra           []string
+		wantFailed          []string
+		wantError           bool
+	}{
+		{
+			name:         "partial overlap",
+			tags:         []string{"go", "Rust", "python"},
+			wantExisting: []string{"go", "rust"},
+			wantCreated:  []string{"python"},
+			wantExtra:    []string{"web"},
+		},
+		{
+			name:         "duplicates and blanks ignored",
+			tags:         []string{"go", "GO", " ", "zig", " zig "},
+			wantExisting: []string{"go"},
+			wantCreated:  []string{"zig"},
+			wantExtra:    []string{"rust", "web"},
+		},
+		{
+			name:         "everything exists",
+			tags:         []string{"web", "rust", "go"},
+			wantExisting: []string{"web", "rust", "go"},
+			wantCreated:  []string{},
+			wantExtra:    []string{},
+		},
+		{
+			name:         "creation failures reported",
+			tags:         []string{"go", "python"},
+			failCreate:   true,
+			wantExisting: []string{"go"},
+			wantCreated:  []string{},
+			wantExtra:    []string{"rust", "web"},
+			wantFailed:   []string{"python"},
+		},
+		{
+			name:                "created concurrently",
+			tags:                []string{"go", "python"},
+			createdConcurrently: true,
+			wantExisting:        []string{"go", "python"},
+			wantCreated:         []string{},
+			wantExtra:           []string{"rust", "web"},
+		},
+		{name: "no tags", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t,
+				linkding.Bookmark{URL: "https://a.example", TagNames: []string{"go", "web"}},
+				linkding.Bookmark{URL: "https://b.example", TagNames: []string{"rust"}},
+			)
+
+			if tt.failCreate {
+				fake.fail = func(r *http.Request) int {
+					if r.Method == http.MethodPost {
+						return http.StatusInternalServerError
+					}
+
+					return 0
+				}
+			}
+
+			if tt.createdConcurrently {
+				fake.fail = func(r *http.Request) int {
+					if r.Method != http.MethodPost {
+						return 0
+					}
+
+					fake.mu.Lock()
+					fake.ensureTag("python")
+					fake.mu.Unlock()
+
+					return http.StatusBadRequest
+				}
+			}
+
+			result := callTool(t, newTestServer(t, fake), "sync_tags", map[string]any{"tags": tt.tags})
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			syncResult := structured[SyncTagsResult](t, result)
+			if !slices.Equal(syncResult.Existing, tt.wantExisting) || !slices.Equal(syncResult.Created, tt.wantCreated) || !slices.Equal(syncResult.Extra, tt.wantExtra) {
+				t.Errorf("got existing %v, created %v, extra %v; want %v, %v, %v",
+					syncResult.Existing, syncResult.Created, syncResult.Extra, tt.wantExisting, tt.wantCreated, tt.wantExtra)
+			}
+
+			if failed := slices.Sorted(maps.Keys(syncResult.Failed)); !slices.Equal(failed, tt.wantFailed) {
+				t.Errorf("failed = %v, want %v", failed, tt.wantFailed)
+			}
+
+			if posts := len(fake.received(http.MethodPost, "/api/tags/")); !tt.failCreate && !tt.createdConcurrently && posts != len(tt.wantCreated) {
+				t.Errorf("sent %d tag creations, want %d", posts, len(tt.wantCreated))
+			}
+
+			// The tag list is fetched once, plus once more for each tag that
+			// failed with 400
+			wantLists := 1
+			if tt.createdConcurrently {
+				wantLists = 2
+			}
+
+			if lists := len(fake.received(http.MethodGet, "/api/tags/")); lists != wantLists {
+				t.Errorf("listed tags %d times, want %d", lists, wantLists)
+			}
+		})
+	}
+}
+
+func TestSyncTagsTwice(t *testing.T) {
+	fake := newFakeLinkding(t, linkding.Bookmark{URL: "https://a.example", TagNames: []string{"go"}})
+	s := newTestServer(t, fake)
+
+	for run, wantCreated := range [][]string{{"python"}, {}} {
+		result := callTool(t, s, "sync_tags", map[string]any{"tags": []string{"go", "python"}})
+		if result.IsError {
+			t.Fatal(resultText(result))
+		}
+
+		if got := structured[SyncTagsResult](t, result).Created; !slices.Equal(got, wantCreated) {
+			t.Errorf("run %d created %v, want %v", run+1, got, wantCreated)
+		}
+	}
+
+	if posts := len(fake.received(http.MethodPost, "/api/tags/")); posts != 1 {
+		t.Errorf("sent %d tag creations, want 1", posts)
+	}
+}
+
+func TestGetTagsAll(t *testing.T) {
+	fake := newFakeLinkding(t)
+	for i := range 250 {
+		fake.ensureTag(fmt.Sprintf("tag%03d", i))
+	}
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantTags     int
+		wantRequests int
+	}{
+		{name: "first page by default", args: map[string]any{}, wantTags: 50, wantRequests: 1},
+		{name: "limit", args: map[string]any{"limit": 120}, wantTags: 120, wantRequests: 1},
+		{name: "every page", args: map[string]any{"all": true}, wantTags: 250, wantRequests: 3},
+		{name: "limit ignored with all", args: map[string]any{"all": true, "limit": 10}, wantTags: 250, wantRequests: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(fake.received(http.MethodGet, "/api/tags"))
+
+			result := callTool(t, newTestServer(t, fake), "get_tags", tt.args)
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			tags := structured[TagsResult](t, result).Tags
+			if len(tags) != tt.wantTags || tags[len(tags)-1].Name != fmt.Sprintf("tag%03d", tt.wantTags-1) {
+				t.Errorf("got %d tags, want the first %d in order", len(tags), tt.wantTags)
+			}
+
+			if requests := len(fake.received(http.MethodGet, "/api/tags")) - before; requests != tt.wantRequests {
+				t.Errorf("sent %d tag requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestExportTags(t *testing.T) {
+	tests := []struct {
+		name             string
+		tags             []string
+		want             string
+		wantHierarchical bool
+	}{
+		{
+			name: "flat, sorted ignoring case",
+			tags: []string{"web", "Go", "rust"},
+			want: "# Tags\n\n3 tags\n\n- Go\n- rust\n- web\n",
+		},
+		{
+			name: "tree",
+			tags: []string{"dev/rust", "reading", "dev/go/testing", "dev", "Dev/go/generics"},
+			want: "# Tags\n\n5 tags\n\n" +
+				"- dev\n" +
+				"  - go\n" +
+				"    - generics\n" +
+				"    - testing\n" +
+				"  - rust\n" +
+				"- reading\n",
+			wantHierarchical: true,
+		},
+		{
+			name:             "parent levels listed even when they aren't tags",
+			tags:             []string{"lang/go", "lang/rust"},
+			want:             "# Tags\n\n2 tags\n\n- lang\n  - go\n  - rust\n",
+			wantHierarchical: true,
+		},
+		{
+			name: "no tags",
+			want: "# Tags\n\n0 tags\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t)
+			fake.ensureTags(tt.tags)
+
+			result := callTool(t, newTestServer(t, fake), "export_tags", map[string]any{})
+			if result.IsError {
+				t.Fatal(resultText(result))
+			}
+
+			exportResult := structured[ExportTagsResult](t, result)
+			if exportResult.Markdown != tt.want || exportResult.Hierarchical != tt.wantHierarchical || exportResult.Total != len(tt.tags) {
+				t.Errorf("got (hierarchical %v, %d tags):\n%s\nwant (hierarchical %v, %d tags):\n%s",
+					exportResult.Hierarchical, exportResult.Total, exportResult.Markdown, tt.wantHierarchical, len(tt.tags), tt.want)
+			}
+		})
+	}
+}
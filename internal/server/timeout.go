@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultToolTimeout bounds every tool without a deadline of its own
+	defaultToolTimeout = time.Minute
+	// bulkToolTimeout bounds tools that call Linkding once per bookmark
+	bulkToolTimeout = 5 * time.Minute
+	// libraryToolTimeout bounds tools that page through the whole library
+	libraryToolTimeout = 2 * time.Minute
+)
+
+// defaultToolTimeouts returns the built-in deadlines for tools that
+// legitimately run longer than defaultToolTimeout.
+func defaultToolTimeouts() map[string]time.Duration {
+	return map[string]time.Duration{
+		"update_bookmarks":           bulkToolTimeout,
+		"delete_bookmarks":           bulkToolTimeout,
+		"mark_query_read":            bulkToolTimeout,
+		"archive_query":              bulkToolTimeout,
+		"search_and_archive":         bulkToolTimeout,
+		"search_and_delete":          bulkToolTimeout,
+		"restore_library":            bulkToolTimeout,
+		"import_csv":                 bulkToolTimeout,
+		"apply_tag_rules":            bulkToolTimeout,
+		"clean_urls":                 bulkToolTimeout,
+		"find_missing_favicons":      bulkToolTimeout,
+		"check_links":                bulkToolTimeout,
+		"advanced_search":            libraryToolTimeout,
+		"bookmarks_by_date_range":    libraryToolTimeout,
+		"tag_trends":                 libraryToolTimeout,
+		"daily_digest":               libraryToolTimeout,
+		"export_reading_list":        libraryToolTimeout,
+		"suggest_archive_candidates": libraryToolTimeout,
+		"sharing_report":             libraryToolTimeout,
+		"list_domains":               libraryToolTimeout,
+		"search_by_domain":           libraryToolTimeout,
+		"find_similar_titles":        libraryToolTimeout,
+		"get_tags":                   libraryToolTimeout,
+		"find_orphaned_tags":         libraryToolTimeout,
+		"export_tags":                libraryToolTimeout,
+		"backup_library":             libraryToolTimeout,
+	}
+}
+
+// toolTimeouts sets a deadline on the context of every tool call, so no tool
+// can hang regardless of client behavior. Tools listed in timeouts get their
+// own deadline, where zero means none; all others get defaultTimeout, unless
+// it is zero too. The deadline bounds the whole call, while every Linkding
+// request within it is also bounded by the client's per-request timeout.
+func toolTimeouts(timeouts map[string]time.Duration, defaultTimeout time.Duration) mcpsdk.Middleware {
+	return func(next mcpsdk.MethodHandler) mcpsdk.MethodHandler {
+		return func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+			if params, ok := req.GetParams().(*mcpsdk.CallToolParamsRaw); ok {
+				timeout, configured := timeouts[params.Name]
+				if !configured {
+					timeout = defaultTimeout
+				}
+
+				if timeout > 0 {
+					var cancel context.CancelFunc
+
+					ctx, cancel = context.WithTimeout(ctx, timeout)
+					defer cancel()
+				}
+			}
+
+			return next(ctx, method, req)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestToolTimeouts(t *testing.T) {
+	timeouts := map[string]time.Duration{
+		"archive_query": bulkToolTimeout,
+		"tag_trends":    libraryToolTimeout,
+		"unbounded":     0,
+	}
+
+	tests := []struct {
+		tool           string
+		defaultTimeout time.Duration
+		want           time.Duration
+	}{
+		{tool: "archive_query", defaultTimeout: time.Minute, want: bulkToolTimeout},
+		{tool: "tag_trends", defaultTimeout: time.Minute, want: libraryToolTimeout},
+		{tool: "search_bookmarks", defaultTimeout: time.Minute, want: time.Minute},
+		{tool: "unbounded", defaultTimeout: time.Minute},
+		{tool: "search_bookmarks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s with default %v", tt.tool, tt.defaultTimeout), func(t *testing.T) {
+			var (
+				deadline    time.Time
+				hasDeadline bool
+			)
+
+			handler := toolTimeouts(timeouts, tt.defaultTimeout)(func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+				deadline, hasDeadline = ctx.Deadline()
+
+				return &mcpsdk.CallToolResult{}, nil
+			})
+
+			start := time.Now()
+			req := &mcpsdk.CallToolRequest{Params: &mcpsdk.CallToolParamsRaw{Name: tt.tool}}
+
+			if _, err := handler(context.Background(), "tools/call", req); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want == 0 {
+				if hasDeadline {
+					t.Errorf("got a deadline in %v, want none", deadline.Sub(start))
+				}
+
+				return
+			}
+
+			if got := deadline.Sub(start); !hasDeadline || got < tt.want || got > tt.want+time.Second {
+				t.Errorf("deadline in %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultToolTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		wantTimeout bool
+	}{
+		{name: "default applies to any tool", opts: []Option{WithDefaultToolTimeout(100 * time.Millisecond)}, wantTimeout: true},
+		{name: "tool timeout overrides the default", opts: []Option{WithDefaultToolTimeout(100 * time.Millisecond), WithToolTimeout("search_bookmarks", 5*time.Second)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeLinkding(t)
+			fake.fail = func(r *http.Request) int {
+				// Hang until the tool deadline, or answer once it has clearly passed
+				select {
+				case <-r.Context().Done():
+				case <-time.After(500 * time.Millisecond):
+				}
+
+				return 0
+			}
+
+			start := time.Now()
+			result := callTool(t, newTestServer(t, fake, tt.opts...), "search_bookmarks", map[string]any{})
+			elapsed := time.Since(start)
+
+			if result.IsError != tt.wantTimeout {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantTimeout, resultText(result))
+			}
+
+			if tt.wantTimeout && (elapsed >= 500*time.Millisecond || !strings.Contains(resultText(result), "deadline exceeded")) {
+				t.Errorf("call took %v with %q, want a deadline error after 100ms", elapsed, resultText(result))
+			}
+		})
+	}
+}
@@ -2,24 +2,48 @@ package server
 
 // GetTagsArgs defines the input structure for get_tags tool
 type GetTagsArgs struct {
-	Limit int `json:"limit,omitempty" jsonschema:"description:Maximum number of tags to return,default:50"`
+	Limit      int  `json:"limit,omitempty" jsonschema:"description:Maximum number of tags to return,default:50"`
+	WithCounts bool `json:"with_counts,omitempty" jsonschema:"description:Include how many bookmarks use each tag (expensive: pages through all bookmarks)"`
+	All        bool `json:"all,omitempty" jsonschema:"description:Return every tag, following pagination; the limit is ignored"`
 }
 
 // CreateBookmarkArgs defines the input structure for create_bookmark tool
 type CreateBookmarkArgs struct {
-	URL         string   `json:"url" jsonschema:"description:URL to bookmark"`
-	Title       string   `json:"title,omitempty" jsonschema:"description:Bookmark title"`
-	Description string   `json:"description,omitempty" jsonschema:"description:Bookmark description"`
-	Tags        []string `json:"tags,omitempty" jsonschema:"description:List of tags"`
+	URL             string   `json:"url" jsonschema:"description:URL to bookmark"`
+	Title           string   `json:"title,omitempty" jsonschema:"description:Bookmark title"`
+	Description     string   `json:"description,omitempty" jsonschema:"description:Bookmark description"`
+	Tags            []string `json:"tags,omitempty" jsonschema:"description:List of tags"`
+	DisableScraping bool     `json:"disable_scraping,omitempty" jsonschema:"description:Don't let Linkding scrape the page; use the supplied title, description and images instead"`
+	FaviconURL      string   `json:"favicon_url,omitempty" jsonschema:"description:Favicon URL, used when scraping is disabled (ignored by Linkding versions that don't accept it)"`
+	PreviewImageURL string   `json:"preview_image_url,omitempty" jsonschema:"description:Preview image URL, used when scraping is disabled (ignored by Linkding versions that don't accept it)"`
 }
 
 // SearchBookmarksArgs defines the input structure for search_bookmarks tool
 type SearchBookmarksArgs struct {
-	Query string `json:"query,omitempty" jsonschema:"description:Search query"`
-	Limit int    `json:"limit,omitempty" jsonschema:"description:Maximum number of results,default:20"`
+	Query  string   `json:"query,omitempty" jsonschema:"description:Search query"`
+	Limit  int      `json:"limit,omitempty" jsonschema:"description:Maximum number of results,default:20"`
+	Offset int      `json:"offset,omitempty" jsonschema:"description:Number of matching bookmarks to skip, e.g. the next_offset of a previous search to get the next page"`
+	Unread *bool    `json:"unread,omitempty" jsonschema:"description:Only return unread (true) or read (false) bookmarks"`
+	Shared *bool    `json:"shared,omitempty" jsonschema:"description:Only return shared (true) or private (false) bookmarks"`
+	Fields []string `json:"fields,omitempty" jsonschema:"description:Fields to include in the output: id, title, url, description, tags, date_added (default: title, url, description, tags)"`
+	Sort   string   `json:"sort,omitempty" jsonschema:"description:Sort order: added_asc, added_desc, title_asc, title_desc, modified_asc or modified_desc (default: newest first)"`
+}
+
+// SearchBookmarksResult defines the output structure for search_bookmarks tool.
+// Total is the number of matching bookmarks, of which Returned are included;
+// Truncated reports that more matches exist beyond this page, and NextOffset
+// is the offset to pass to get the next one.
+type SearchBookmarksResult struct {
+	Total      int               `json:"total"`
+	Returned   int               `json:"returned"`
+	Truncated  bool              `json:"truncated"`
+	NextOffset int               `json:"next_offset,omitempty"`
+	PageSorted bool              `json:"page_sorted,omitempty"`
+	Bookmarks  []BookmarkSummary `json:"bookmarks"`
 }
 
-// BookmarkResult defines the output structure for bookmark operations
+// BookmarkResult defines the output structure for bookmark operations.
+// Archived and Deleted report the resulting state after an archive, unarchive or delete.
 type BookmarkResult struct {
 	ID          int      `json:"id"`
 	URL         string   `json:"url"`
@@ -28,10 +52,600 @@ type BookmarkResult struct {
 	Tags        []string `json:"tags,omitempty"`
 	Success     bool     `json:"success"`
 	Message     string   `json:"message,omitempty"`
+	Archived    *bool    `json:"archived,omitempty"`
+	Deleted     bool     `json:"deleted,omitempty"`
 }
 
 // TagResult defines the output structure for tag operations
 type TagResult struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Count *int   `json:"count,omitempty"`
+}
+
+// TagsResult defines the output structure for tag listing operations
+type TagsResult struct {
+	Tags []TagResult `json:"tags"`
+}
+
+// UpdateBookmarksArgs defines the input structure for update_bookmarks tool
+type UpdateBookmarksArgs struct {
+	IDs         []int    `json:"ids" jsonschema:"description:IDs of the bookmarks to update"`
+	Title       *string  `json:"title,omitempty" jsonschema:"description:New title for every bookmark"`
+	Description *string  `json:"description,omitempty" jsonschema:"description:New description for every bookmark"`
+	Notes       *string  `json:"notes,omitempty" jsonschema:"description:New notes for every bookmark"`
+	Unread      *bool    `json:"unread,omitempty" jsonschema:"description:Mark bookmarks as unread (true) or read (false)"`
+	Shared      *bool    `json:"shared,omitempty" jsonschema:"description:Mark bookmarks as shared (true) or private (false)"`
+	AddTags     []string `json:"add_tags,omitempty" jsonschema:"description:Tags to add to every bookmark"`
+	RemoveTags  []string `json:"remove_tags,omitempty" jsonschema:"description:Tags to remove from every bookmark"`
+}
+
+// DeleteBookmarksArgs defines the input structure for delete_bookmarks tool
+type DeleteBookmarksArgs struct {
+	IDs     []int `json:"ids" jsonschema:"description:IDs of the bookmarks to delete"`
+	Confirm bool  `json:"confirm,omitempty" jsonschema:"description:Must be true to confirm the deletion when the client cannot prompt the user"`
+}
+
+// QueryArgs defines the input structure for tools acting on all bookmarks matching a query
+type QueryArgs struct {
+	Query string `json:"query" jsonschema:"description:Search query selecting the bookmarks, e.g. #news"`
+}
+
+// SearchActionArgs defines the input structure for search_and_archive and search_and_delete tools
+type SearchActionArgs struct {
+	Query   string `json:"query" jsonschema:"description:Search query selecting the bookmarks, e.g. #news"`
+	Confirm bool   `json:"confirm,omitempty" jsonschema:"description:Set to true to perform the action; otherwise only a preview of the matches is returned"`
+}
+
+// SearchActionResult defines the output structure for search_and_archive and search_and_delete tools.
+// Result is only set once the action was confirmed and performed.
+type SearchActionResult struct {
+	Confirmed bool               `json:"confirmed"`
+	Matches   BookmarkListResult `json:"matches"`
+	Result    *BulkResult        `json:"result,omitempty"`
+}
+
+// UpdateBookmarkArgs defines the input structure for update_bookmark tool.
+// Omitted fields keep their current value.
+type UpdateBookmarkArgs struct {
+	ID          int      `json:"id" jsonschema:"description:ID of the bookmark to update"`
+	URL         *string  `json:"url,omitempty" jsonschema:"description:New URL"`
+	Title       *string  `json:"title,omitempty" jsonschema:"description:New title"`
+	Description *string  `json:"description,omitempty" jsonschema:"description:New description"`
+	Notes       *string  `json:"notes,omitempty" jsonschema:"description:New notes"`
+	Tags        []string `json:"tags,omitempty" jsonschema:"description:New tags, replacing all current tags; an empty list removes them"`
+}
+
+// ArchiveBookmarkArgs defines the input structure for archive_bookmark and unarchive_bookmark tools
+type ArchiveBookmarkArgs struct {
+	ID int `json:"id" jsonschema:"description:ID of the bookmark"`
+}
+
+// DeleteBookmarkArgs defines the input structure for delete_bookmark tool
+type DeleteBookmarkArgs struct {
+	ID      int  `json:"id" jsonschema:"description:ID of the bookmark to delete"`
+	Confirm bool `json:"confirm,omitempty" jsonschema:"description:Must be true to confirm the deletion when the client cannot prompt the user"`
+}
+
+// DeleteBookmarkByURLArgs defines the input structure for delete_bookmark_by_url tool
+type DeleteBookmarkByURLArgs struct {
+	URL     string `json:"url" jsonschema:"description:URL of the bookmark to delete"`
+	Confirm bool   `json:"confirm,omitempty" jsonschema:"description:Must be true to confirm the deletion when the client cannot prompt the user"`
+}
+
+// BulkItemResult defines the per-item outcome of a bulk operation.
+// Archived and Deleted report the resulting state of successful archive, unarchive and delete items.
+// Skipped items were left unchanged on purpose, for the given Reason.
+type BulkItemResult struct {
+	ID       int    `json:"id"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+	Skipped  bool   `json:"skipped,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Archived *bool  `json:"archived,omitempty"`
+	Deleted  bool   `json:"deleted,omitempty"`
+}
+
+// BulkResult defines the output structure for bulk operations.
+// Skipped counts both the skipped items in Results and the items never
+// started because the operation was cancelled.
+type BulkResult struct {
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Skipped   int              `json:"skipped,omitempty"`
+	Cancelled bool             `json:"cancelled,omitempty"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// AdvancedSearchArgs defines the input structure for advanced_search tool
+type AdvancedSearchArgs struct {
+	Query        string   `json:"query" jsonschema:"description:Search terms; every term must appear in at least one of the selected fields"`
+	Fields       []string `json:"fields,omitempty" jsonschema:"description:Fields to search: title, description, notes, url (default: title, description, notes)"`
+	Limit        int      `json:"limit,omitempty" jsonschema:"description:Maximum number of results,default:20"`
+	ExcludeTags  []string `json:"exclude_tags,omitempty" jsonschema:"description:Skip bookmarks with any of these tags (adds -#tag to the query)"`
+	OnlyUntagged bool     `json:"only_untagged,omitempty" jsonschema:"description:Only search bookmarks without tags (adds !untagged to the query)"`
+}
+
+// SearchMatch defines a bookmark found by advanced_search and the fields that matched
+type SearchMatch struct {
+	ID            int      `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	Description   string   `json:"description,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	MatchedFields []string `json:"matched_fields"`
+}
+
+// AdvancedSearchResult defines the output structure for advanced_search tool
+type AdvancedSearchResult struct {
+	Total   int           `json:"total"`
+	Results []SearchMatch `json:"results"`
+}
+
+// GetShareLinkArgs defines the input structure for get_share_link tool
+type GetShareLinkArgs struct {
+	ID    int  `json:"id" jsonschema:"description:ID of the bookmark"`
+	Share bool `json:"share,omitempty" jsonschema:"description:Mark the bookmark as shared if it isn't already"`
+}
+
+// ShareLinkResult defines the output structure for get_share_link tool
+type ShareLinkResult struct {
+	ID       int    `json:"id"`
+	Shared   bool   `json:"shared"`
+	ShareURL string `json:"share_url,omitempty"`
+	FeedURL  string `json:"feed_url,omitempty"`
+}
+
+// DateRangeArgs defines the input structure for bookmarks_by_date_range tool
+type DateRangeArgs struct {
+	From  string `json:"from,omitempty" jsonschema:"description:Start of the range (RFC 3339 timestamp or YYYY-MM-DD date), inclusive"`
+	To    string `json:"to,omitempty" jsonschema:"description:End of the range (RFC 3339 timestamp or YYYY-MM-DD date), inclusive"`
+	Limit int    `json:"limit,omitempty" jsonschema:"description:Maximum number of bookmarks to list, oldest first,default:50"`
+}
+
+// BookmarkSummary defines a compact bookmark representation for listing tools.
+// DateAdded is an RFC 3339 timestamp.
+type BookmarkSummary struct {
+	ID          int      `json:"id"`
+	URL         string   `json:"url"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	DateAdded   string   `json:"date_added"`
+	Archived    bool     `json:"archived,omitempty"`
+}
+
+// BookmarkListResult defines the output structure for tools returning a list of bookmarks
+type BookmarkListResult struct {
+	Total     int               `json:"total"`
+	Bookmarks []BookmarkSummary `json:"bookmarks"`
+}
+
+// FindOrphanedTagsArgs defines the input structure for find_orphaned_tags tool
+type FindOrphanedTagsArgs struct{}
+
+// DiagnoseArgs defines the input structure for diagnose tool
+type DiagnoseArgs struct{}
+
+// DiagnosticCheck defines the outcome of a single diagnostic check
+type DiagnosticCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// DiagnosticReport defines the output structure for diagnose tool
+type DiagnosticReport struct {
+	Healthy  bool              `json:"healthy"`
+	Version  string            `json:"version,omitempty"`
+	Features map[string]bool   `json:"features,omitempty"`
+	Checks   []DiagnosticCheck `json:"checks"`
+}
+
+// SearchSharedBookmarksArgs defines the input structure for search_shared_bookmarks tool
+type SearchSharedBookmarksArgs struct {
+	Query string `json:"query,omitempty" jsonschema:"description:Search query"`
+	Limit int    `json:"limit,omitempty" jsonschema:"description:Maximum number of results,default:20"`
+}
+
+// TagTrendsArgs defines the input structure for tag_trends tool
+type TagTrendsArgs struct {
+	Months int `json:"months,omitempty" jsonschema:"description:Number of most recent months to analyze (max 36),default:12"`
+	Tags   int `json:"tags,omitempty" jsonschema:"description:Number of most used tags to include (max 50),default:10"`
+}
+
+// TagSeries defines the monthly bookmark counts of a single tag
+type TagSeries struct {
+	Tag    string `json:"tag"`
+	Total  int    `json:"total"`
+	Counts []int  `json:"counts"`
+}
+
+// TagTrendsResult defines the output structure for tag_trends tool.
+// Counts in each series line up with Months.
+type TagTrendsResult struct {
+	Months []string    `json:"months"`
+	Series []TagSeries `json:"series"`
+}
+
+// EnrichBookmarkArgs defines the input structure for enrich_bookmark tool
+type EnrichBookmarkArgs struct {
+	ID int `json:"id" jsonschema:"description:ID of the bookmark to enrich"`
+}
+
+// EnrichBookmarkResult defines the output structure for enrich_bookmark tool
+type EnrichBookmarkResult struct {
+	ID          int      `json:"id"`
+	Updated     []string `json:"updated"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+}
+
+// EstimateReadingTimeArgs defines the input structure for estimate_reading_time tool
+type EstimateReadingTimeArgs struct {
+	ID int `json:"id" jsonschema:"description:ID of the bookmark to estimate"`
+}
+
+// ReadingTimeResult defines the output structure for estimate_reading_time tool.
+// Source is "page" when the live page text was counted, or "description" when
+// the page could not be read.
+type ReadingTimeResult struct {
+	ID      int    `json:"id"`
+	URL     string `json:"url"`
+	Words   int    `json:"words"`
+	Minutes int    `json:"minutes"`
+	Source  string `json:"source"`
+}
+
+// SyncTagsArgs defines the input structure for sync_tags tool
+type SyncTagsArgs struct {
+	Tags []string `json:"tags" jsonschema:"description:The canonical list of tags that should exist"`
+}
+
+// SyncTagsResult defines the output structure for sync_tags tool.
+// Failed maps tags that could not be created to the error.
+type SyncTagsResult struct {
+	Existing []string          `json:"existing"`
+	Created  []string          `json:"created"`
+	Extra    []string          `json:"extra"`
+	Failed   map[string]string `json:"failed,omitempty"`
+}
+
+// BackupLibraryArgs defines the input structure for backup_library tool
+type BackupLibraryArgs struct {
+	Path string `json:"path,omitempty" jsonschema:"description:File name within the backup directory; defaults to a timestamped name"`
+}
+
+// BackupResult defines the output structure for backup_library tool
+type BackupResult struct {
+	Path  string `json:"path"`
+	Count int    `json:"count"`
+}
+
+// RestoreLibraryArgs defines the input structure for restore_library tool
+type RestoreLibraryArgs struct {
+	Path string `json:"path" jsonschema:"description:File name of the backup within the backup directory"`
+}
+
+// RestoreFailure defines a bookmark that could not be restored
+type RestoreFailure struct {
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// RestoreResult defines the output structure for restore_library and import_csv tools.
+// Skipped counts bookmarks whose URL already exists; NotStarted counts
+// bookmarks left out because the call was cancelled.
+type RestoreResult struct {
+	Created    int              `json:"created"`
+	Skipped    int              `json:"skipped"`
+	Failed     int              `json:"failed"`
+	NotStarted int              `json:"not_started,omitempty"`
+	Cancelled  bool             `json:"cancelled,omitempty"`
+	Failures   []RestoreFailure `json:"failures"`
+}
+
+// DailyDigestArgs defines the input structure for daily_digest tool
+type DailyDigestArgs struct {
+	Hours int `json:"hours,omitempty" jsonschema:"description:Lookback window in hours (max 744),default:24"`
+}
+
+// DigestGroup defines the bookmarks of a single tag in a digest
+type DigestGroup struct {
+	Tag       string            `json:"tag"`
+	Bookmarks []BookmarkSummary `json:"bookmarks"`
+}
+
+// DigestResult defines the output structure for daily_digest tool.
+// Total counts distinct bookmarks; a bookmark with several tags appears in each group.
+type DigestResult struct {
+	Hours  int           `json:"hours"`
+	Total  int           `json:"total"`
+	Groups []DigestGroup `json:"groups"`
+}
+
+// ListCapabilitiesArgs defines the input structure for list_capabilities tool
+type ListCapabilitiesArgs struct{}
+
+// ParameterSummary defines a single input parameter of a tool
+type ParameterSummary struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+}
+
+// ToolSummary defines a registered tool and its input parameters
+type ToolSummary struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Parameters  []ParameterSummary `json:"parameters"`
+}
+
+// CapabilitiesResult defines the output structure for list_capabilities tool
+type CapabilitiesResult struct {
+	Tools []ToolSummary `json:"tools"`
+}
+
+// AppendNoteArgs defines the input structure for append_note tool
+type AppendNoteArgs struct {
+	ID   int    `json:"id" jsonschema:"description:ID of the bookmark"`
+	Note string `json:"note" jsonschema:"description:Markdown text to append to the bookmark notes"`
+}
+
+// ListURLsArgs defines the input structure for list_urls tool
+type ListURLsArgs struct {
+	Query  string `json:"query,omitempty" jsonschema:"description:Search query"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"description:Maximum number of URLs,default:100"`
+	Unread *bool  `json:"unread,omitempty" jsonschema:"description:Only return unread (true) or read (false) bookmarks"`
+	Shared *bool  `json:"shared,omitempty" jsonschema:"description:Only return shared (true) or private (false) bookmarks"`
+}
+
+// ListURLsResult defines the output structure for list_urls tool
+type ListURLsResult struct {
+	URLs []string `json:"urls"`
+}
+
+// ListDomainsArgs defines the input structure for list_domains tool
+type ListDomainsArgs struct {
+	Limit int `json:"limit,omitempty" jsonschema:"description:Maximum number of domains to return,default:20"`
+}
+
+// DomainCount defines how many bookmarks point to a domain
+type DomainCount struct {
+	Domain string `json:"domain"`
+	Count  int    `json:"count"`
+}
+
+// DomainsResult defines the output structure for list_domains tool.
+// Bookmarks is the number of bookmarks analyzed and Total the number of distinct domains.
+type DomainsResult struct {
+	Bookmarks int           `json:"bookmarks"`
+	Total     int           `json:"total"`
+	Domains   []DomainCount `json:"domains"`
+}
+
+// SearchByDomainArgs defines the input structure for search_by_domain tool
+type SearchByDomainArgs struct {
+	Domain string `json:"domain" jsonschema:"description:Domain to search, e.g. example.com; subdomains match too"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"description:Maximum number of results,default:50"`
+}
+
+// CheckLinksArgs defines the input structure for check_links tool
+type CheckLinksArgs struct {
+	Query       string `json:"query,omitempty" jsonschema:"description:Search query selecting the bookmarks to check (default: all)"`
+	Concurrency int    `json:"concurrency,omitempty" jsonschema:"description:Number of links checked in parallel (max 20),default:5"`
+	Timeout     int    `json:"timeout,omitempty" jsonschema:"description:Seconds to wait for each link (max 60),default:10"`
+	Budget      int    `json:"budget,omitempty" jsonschema:"description:Total seconds for the whole check (max 300); links not reached in time are reported as unchecked,default:60"`
+}
+
+// LinkStatus defines the outcome of checking a single bookmarked URL
+type LinkStatus struct {
+	ID         int    `json:"id"`
+	URL        string `json:"url"`
+	OK         bool   `json:"ok"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// LinkCheckResult defines the output structure for check_links tool
+type LinkCheckResult struct {
+	Checked   int          `json:"checked"`
+	Unchecked int          `json:"unchecked"`
+	Broken    []LinkStatus `json:"broken"`
+}
+
+// ValidateTagsArgs defines the input structure for validate_tags tool
+type ValidateTagsArgs struct {
+	Tags []string `json:"tags" jsonschema:"description:Proposed tags to check against the allowed vocabulary"`
+}
+
+// DisallowedTag defines a tag outside the vocabulary with the closest allowed tags
+type DisallowedTag struct {
+	Tag         string   `json:"tag"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// ValidateTagsResult defines the output structure for validate_tags tool
+type ValidateTagsResult struct {
+	Valid      bool            `json:"valid"`
+	Allowed    []string        `json:"allowed"`
+	Disallowed []DisallowedTag `json:"disallowed"`
+}
+
+// ExportReadingListArgs defines the input structure for export_reading_list tool
+type ExportReadingListArgs struct {
+	From  string `json:"from,omitempty" jsonschema:"description:Start of the range (RFC 3339 timestamp or YYYY-MM-DD date), inclusive"`
+	To    string `json:"to,omitempty" jsonschema:"description:End of the range (RFC 3339 timestamp or YYYY-MM-DD date), inclusive"`
+	Query string `json:"query,omitempty" jsonschema:"description:Optional search query narrowing the bookmarks"`
+}
+
+// ReadingListResult defines the output structure for export_reading_list tool
+type ReadingListResult struct {
+	Total    int    `json:"total"`
+	Markdown string `json:"markdown"`
+}
+
+// ConsolidateTextArgs defines the input structure for consolidate_bookmark_text tool
+type ConsolidateTextArgs struct {
+	ID    int  `json:"id" jsonschema:"description:ID of the bookmark to consolidate"`
+	Apply bool `json:"apply,omitempty" jsonschema:"description:Save the proposed notes; without it the change is only reported"`
+}
+
+// ConsolidateTextResult defines the output structure for consolidate_bookmark_text tool.
+// Relation is one of empty, distinct, identical, notes_within_description and
+// description_within_notes; Notes holds the proposed notes.
+type ConsolidateTextResult struct {
+	ID          int    `json:"id"`
+	Relation    string `json:"relation"`
+	Changed     bool   `json:"changed"`
+	Applied     bool   `json:"applied"`
+	Description string `json:"description"`
+	Notes       string `json:"notes"`
+}
+
+// ExportTagsArgs defines the input structure for export_tags tool
+type ExportTagsArgs struct{}
+
+// ExportTagsResult defines the output structure for export_tags tool
+type ExportTagsResult struct {
+	Total        int    `json:"total"`
+	Hierarchical bool   `json:"hierarchical"`
+	Markdown     string `json:"markdown"`
+}
+
+// ArchiveCandidatesArgs defines the input structure for suggest_archive_candidates tool
+type ArchiveCandidatesArgs struct {
+	Days  int    `json:"days,omitempty" jsonschema:"description:Only suggest bookmarks added more than this many days ago (max 3650),default:90"`
+	Limit int    `json:"limit,omitempty" jsonschema:"description:Maximum number of candidates to return, oldest first (max 500),default:50"`
+	Query string `json:"query,omitempty" jsonschema:"description:Optional search query narrowing the bookmarks"`
+}
+
+// ArchiveCandidatesResult defines the output structure for suggest_archive_candidates tool.
+// Total counts all candidates, Candidates holds at most the requested limit.
+type ArchiveCandidatesResult struct {
+	Total         int               `json:"total"`
+	OlderThanDays int               `json:"older_than_days"`
+	Candidates    []BookmarkSummary `json:"candidates"`
+}
+
+// ImportCSVArgs defines the input structure for import_csv tool
+type ImportCSVArgs struct {
+	CSV         string `json:"csv" jsonschema:"description:CSV content with a header row, e.g. a Pocket or Instapaper export"`
+	URLColumn   string `json:"url_column,omitempty" jsonschema:"description:Header of the URL column (default: url, href or link)"`
+	TitleColumn string `json:"title_column,omitempty" jsonschema:"description:Header of the title column (default: title or name)"`
+	TagsColumn  string `json:"tags_column,omitempty" jsonschema:"description:Header of the tags column, tags separated by |, commas or spaces; a folder column becomes one tag (default: tags, tag, folder or labels)"`
+	Unread      bool   `json:"unread,omitempty" jsonschema:"description:Mark imported bookmarks as unread"`
+}
+
+// FindMissingFaviconsArgs defines the input structure for find_missing_favicons tool
+type FindMissingFaviconsArgs struct {
+	Query   string `json:"query,omitempty" jsonschema:"description:Search query selecting the bookmarks to check (default: all)"`
+	Refresh bool   `json:"refresh,omitempty" jsonschema:"description:Re-save the bookmarks without a favicon so Linkding loads it again"`
+}
+
+// MissingFaviconsResult defines the output structure for find_missing_favicons tool.
+// Refresh holds the outcome of the favicon reload when it was requested.
+type MissingFaviconsResult struct {
+	Checked   int               `json:"checked"`
+	Bookmarks []BookmarkSummary `json:"bookmarks"`
+	Refresh   *BulkResult       `json:"refresh,omitempty"`
+}
+
+// FindSimilarTitlesArgs defines the input structure for find_similar_titles tool
+type FindSimilarTitlesArgs struct {
+	Query      string `json:"query,omitempty" jsonschema:"description:Search query selecting the bookmarks to compare (default: all)"`
+	Similarity int    `json:"similarity,omitempty" jsonschema:"description:Minimum title similarity in percent (50-100),default:80"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"description:Maximum number of groups to return (max 100),default:20"`
+}
+
+// SimilarTitlesResult defines the output structure for find_similar_titles tool.
+// Total counts all groups found; Complete is false when the comparison limit
+// was reached before every bookmark was compared.
+type SimilarTitlesResult struct {
+	Total    int                  `json:"total"`
+	Complete bool                 `json:"complete"`
+	Groups   []BookmarkListResult `json:"groups"`
+}
+
+// SharingReportArgs defines the input structure for sharing_report tool
+type SharingReportArgs struct {
+	Tags int `json:"tags,omitempty" jsonschema:"description:Number of tags to include, those with the most shared bookmarks first (max 100),default:20"`
+}
+
+// TagSharing defines the shared and private bookmark counts of a single tag
+type TagSharing struct {
+	Tag     string `json:"tag,omitempty"`
+	Shared  int    `json:"shared"`
+	Private int    `json:"private"`
+}
+
+// SharingReportResult defines the output structure for sharing_report tool.
+// Untagged counts the bookmarks without tags, which appear in no entry of Tags.
+type SharingReportResult struct {
+	Total    int          `json:"total"`
+	Shared   int          `json:"shared"`
+	Private  int          `json:"private"`
+	Tags     []TagSharing `json:"tags"`
+	Untagged TagSharing   `json:"untagged"`
+}
+
+// TagRule defines a condition and the tags applied to bookmarks meeting it.
+// When both Domain and TitleContains are set, a bookmark must match both.
+type TagRule struct {
+	Domain        string   `json:"domain,omitempty" jsonschema:"description:Match bookmarks on this domain or its subdomains, e.g. github.com"`
+	TitleContains string   `json:"title_contains,omitempty" jsonschema:"description:Match bookmarks whose title contains these words, ignoring case"`
+	Tags          []string `json:"tags" jsonschema:"description:Tags to add to matching bookmarks"`
+}
+
+// ApplyTagRulesArgs defines the input structure for apply_tag_rules tool
+type ApplyTagRulesArgs struct {
+	Rules   []TagRule `json:"rules" jsonschema:"description:Rules to evaluate against every bookmark; a bookmark gets the tags of all rules it matches"`
+	Query   string    `json:"query,omitempty" jsonschema:"description:Search query narrowing the bookmarks the rules apply to"`
+	Preview bool      `json:"preview,omitempty" jsonschema:"description:Only report which bookmarks would get which tags, without changing anything"`
+}
+
+// TagAssignment defines the tags a rule evaluation adds to a bookmark.
+// Added lists the new tags, Tags the bookmark's tags after adding them.
+type TagAssignment struct {
+	ID    int      `json:"id"`
+	URL   string   `json:"url"`
+	Title string   `json:"title"`
+	Added []string `json:"added"`
+	Tags  []string `json:"tags"`
+}
+
+// ApplyTagRulesResult defines the output structure for apply_tag_rules tool.
+// Checked is the number of bookmarks the rules were evaluated against;
+// Applied is nil in preview mode.
+type ApplyTagRulesResult struct {
+	Checked     int             `json:"checked"`
+	Assignments []TagAssignment `json:"assignments"`
+	Applied     *BulkResult     `json:"applied,omitempty"`
+}
+
+// CleanURLsArgs defines the input structure for clean_urls tool
+type CleanURLsArgs struct {
+	Query   string `json:"query,omitempty" jsonschema:"description:Search query selecting the bookmarks to check (default: all)"`
+	Confirm bool   `json:"confirm,omitempty" jsonschema:"description:Update the bookmarks to their clean URLs; without it they are only reported"`
+}
+
+// TrackedURL defines a bookmark whose URL has tracking parameters.
+// DuplicateOf is the ID of a bookmark already having the clean URL, if any;
+// such bookmarks are left unchanged.
+type TrackedURL struct {
+	ID          int      `json:"id"`
+	Title       string   `json:"title"`
+	URL         string   `json:"url"`
+	CleanURL    string   `json:"clean_url"`
+	Removed     []string `json:"removed"`
+	DuplicateOf int      `json:"duplicate_of,omitempty"`
+}
+
+// CleanURLsResult defines the output structure for clean_urls tool.
+// Updated is only set when the bookmarks were updated with confirm.
+type CleanURLsResult struct {
+	Checked   int          `json:"checked"`
+	Bookmarks []TrackedURL `json:"bookmarks"`
+	Updated   *BulkResult  `json:"updated,omitempty"`
 }
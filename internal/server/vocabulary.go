@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxTagSuggestions limits how many allowed tags are suggested per disallowed tag
+const maxTagSuggestions = 3
+
+func (s *MCPServer) handleValidateTags(ctx context.Context, req *mcpsdk.CallToolRequest, args ValidateTagsArgs) (*mcpsdk.CallToolResult, ValidateTagsResult, error) {
+	if len(args.Tags) == 0 {
+		return errorResult("At least one tag is required"), ValidateTagsResult{}, nil
+	}
+
+	validateResult := s.validateTags(args.Tags)
+
+	if validateResult.Valid {
+		return textResult(fmt.Sprintf("%s All %s are allowed", s.mark(markSuccess), pluralize(len(args.Tags), "tag"))), validateResult, nil
+	}
+
+	return textResult(s.formatDisallowedTags(validateResult)), validateResult, nil
+}
+
+// validateTags checks tags against the vocabulary, case-insensitively like
+// Linkding itself, and suggests the closest allowed tags for the others.
+func (s *MCPServer) validateTags(tags []string) ValidateTagsResult {
+	validateResult := ValidateTagsResult{Valid: true, Allowed: []string{}, Disallowed: []DisallowedTag{}}
+
+	for _, tag := range tags {
+		if slices.ContainsFunc(s.tagVocabulary, func(allowed string) bool { return strings.EqualFold(allowed, tag) }) {
+			validateResult.Allowed = append(validateResult.Allowed, tag)
+
+			continue
+		}
+
+		validateResult.Valid = false
+		validateResult.Disallowed = append(validateResult.Disallowed, DisallowedTag{
+			Tag:         tag,
+			Suggestions: closestTags(tag, s.tagVocabulary),
+		})
+	}
+
+	return validateResult
+}
+
+// checkVocabulary returns an error result when the vocabulary is enforced and
+// some of the tags are not part of it, and nil otherwise.
+func (s *MCPServer) checkVocabulary(tags []string) *mcpsdk.CallToolResult {
+	if !s.enforceVocabulary || len(tags) == 0 {
+		return nil
+	}
+
+	validateResult := s.validateTags(tags)
+	if validateResult.Valid {
+		return nil
+	}
+
+	return errorResult("%s", s.formatDisallowedTags(validateResult))
+}
+
+// formatDisallowedTags lists disallowed tags with their suggested replacements
+func (s *MCPServer) formatDisallowedTags(validateResult ValidateTagsResult) string {
+	result := fmt.Sprintf("%s %s not in the allowed vocabulary:\n\n", s.mark(markWarning), pluralize(len(validateResult.Disallowed), "tag"))
+
+	for _, disallowed := range validateResult.Disallowed {
+		result += "• " + disallowed.Tag
+		if len(disallowed.Suggestions) > 0 {
+			result += " (did you mean: " + strings.Join(disallowed.Suggestions, ", ") + "?)"
+		}
+
+		result += "\n"
+	}
+
+	return result
+}
+
+// closestTags returns the allowed tags nearest to tag by edit distance,
+// ignoring case. Tags differing from it in more than half their characters
+// are not suggested.
+func closestTags(tag string, vocabulary []string) []string {
+	type candidate struct {
+		tag      string
+		distance int
+	}
+
+	var candidates []candidate
+
+	for _, allowed := range vocabulary {
+		distance := editDistance(strings.ToLower(tag), strings.ToLower(allowed))
+		if distance <= max(len(tag), len(allowed))/2 {
+			candidates = append(candidates, candidate{allowed, distance})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int { return a.distance - b.distance })
+
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < maxTagSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].tag)
+	}
+
+	return suggestions
+}
+
+// editDistance computes the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chickenzord/linkding-mcp/pkg/linkding"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var testVocabulary = []string{"golang", "rust", "databases", "web"}
+
+func TestValidateTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		tags           []string
+		wantValid      bool
+		wantAllowed    []string
+		wantDisallowed []DisallowedTag
+		wantError      bool
+	}{
+		{name: "all allowed, ignoring case", tags: []string{"golang", "Rust"}, wantValid: true, wantAllowed: []string{"golang", "Rust"}, wantDisallowed: []DisallowedTag{}},
+		{
+			name:        "typo with a suggestion",
+			tags:        []string{"web", "golnag"},
+			wantAllowed: []string{"web"},
+			wantDisallowed: []DisallowedTag{
+				{Tag: "golnag", Suggestions: []string{"golang"}},
+			},
+		},
+		{
+			name:           "nothing close",
+			tags:           []string{"cooking"},
+			wantAllowed:    []string{},
+			wantDisallowed: []DisallowedTag{{Tag: "cooking", Suggestions: []string{}}},
+		},
+		{name: "no tags", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, newFakeLinkding(t), WithTagVocabulary(testVocabulary, false))
+
+			result := callTool(t, s, "validate_tags", map[string]any{"tags": tt.tags})
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			got := structured[ValidateTagsResult](t, result)
+			if got.Valid != tt.wantValid || !slices.Equal(got.Allowed, tt.wantAllowed) {
+				t.Errorf("valid %v, allowed %v; want %v, %v", got.Valid, got.Allowed, tt.wantValid, tt.wantAllowed)
+			}
+
+			if !slices.EqualFunc(got.Disallowed, tt.wantDisallowed, func(a, b DisallowedTag) bool {
+				return a.Tag == b.Tag && slices.Equal(a.Suggestions, b.Suggestions)
+			}) {
+				t.Errorf("disallowed = %+v, want %+v", got.Disallowed, tt.wantDisallowed)
+			}
+		})
+	}
+}
+
+func TestValidateTagsRegistration(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantTool bool
+	}{
+		{name: "without TAG_VOCABULARY"},
+		{name: "with TAG_VOCABULARY", opts: []Option{WithTagVocabulary(testVocabulary, false)}, wantTool: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools, err := connect(t, newTestServer(t, newFakeLinkding(t), tt.opts...), nil).ListTools(context.Background(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			registered := slices.ContainsFunc(tools.Tools, func(tool *mcpsdk.Tool) bool { return tool.Name == "validate_tags" })
+			if registered != tt.wantTool {
+				t.Errorf("validate_tags registered = %v, want %v", registered, tt.wantTool)
+			}
+		})
+	}
+}
+
+func TestEnforceVocabulary(t *testing.T) {
+	tests := []struct {
+		name      string
+		tool      string
+		args      map[string]any
+		enforce   bool
+		wantError bool
+	}{
+		{name: "create refused", tool: "create_bookmark", args: map[string]any{"url": "https://new.example", "tags": []string{"golang", "golnag"}}, enforce: true, wantError: true},
+		{name: "create allowed", tool: "create_bookmark", args: map[string]any{"url": "https://new.example", "tags": []string{"Golang"}}, enforce: true},
+		{name: "create not enforced", tool: "create_bookmark", args: map[string]any{"url": "https://new.example", "tags": []string{"golnag"}}},
+		{name: "update refused", tool: "update_bookmark", args: map[string]any{"id": 1, "tags": []string{"cooking"}}, enforce: true, wantError: true},
+		{name: "update without tags", tool: "update_bookmark", args: map[string]any{"id": 1, "title": "Renamed"}, enforce: true},
+		{name: "bulk update refused", tool: "update_bookmarks", args: map[string]any{"ids": []int{1}, "add_tags": []string{"cooking"}}, enforce: true, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The existing bookmark has a tag outside the vocabulary, which
+			// updates not touching the tags keep
+			fake := newFakeLinkding(t, linkding.Bookmark{ID: 1, URL: "https://a.example", TagNames: []string{"legacy"}})
+
+			result := callTool(t, newTestServer(t, fake, WithTagVocabulary(testVocabulary, tt.enforce)), tt.tool, tt.args)
+			if result.IsError != tt.wantError {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantError, resultText(result))
+			}
+
+			if !tt.wantError {
+				return
+			}
+
+			if !strings.Contains(resultText(result), "not in the allowed vocabulary") {
+				t.Errorf("error %q doesn't explain the refusal", resultText(result))
+			}
+
+			for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch} {
+				if writes := fake.received(method, "/api/bookmarks/"); len(writes) > 0 {
+					t.Errorf("sent %s requests despite the refusal", method)
+				}
+			}
+		})
+	}
+}
+
+func TestClosestTags(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{tag: "Rust", want: []string{"rust"}},
+		{tag: "database", want: []string{"databases"}},
+		{tag: "wb", want: []string{"web"}},
+		{tag: "x", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := closestTags(tt.tag, testVocabulary); !slices.Equal(got, tt.want) {
+				t.Errorf("closestTags(%q) = %v, want %v", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
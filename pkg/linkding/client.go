@@ -5,18 +5,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Client represents a Linkding API client.
 type Client struct {
-	baseURL    string
-	apiToken   string
-	httpClient *http.Client
+	baseURL            string
+	apiToken           string
+	tokenProvider      func() string
+	headers            http.Header
+	acceptLanguage     string
+	decoderOptions     JSONDecoderOptions
+	httpClient         *http.Client
+	maxResponseBodyLog int
+	maxRetries         int
+	pageSize           int
+	maxResults         int
+	timeout            time.Duration
 }
 
 // Bookmark represents a bookmark from the Linkding API.
@@ -39,10 +51,11 @@ type Bookmark struct {
 
 // BookmarkResponse represents the response from the bookmarks list API endpoint.
 type BookmarkResponse struct {
-	Count    int        `json:"count"`    // Total number of bookmarks matching the query
-	Next     *string    `json:"next"`     // URL for the next page of results, if any
-	Previous *string    `json:"previous"` // URL for the previous page of results, if any
-	Results  []Bookmark `json:"results"`  // Array of bookmark objects
+	Count         int        `json:"count"`    // Total number of bookmarks matching the query
+	Next          *string    `json:"next"`     // URL for the next page of results, if any
+	Previous      *string    `json:"previous"` // URL for the previous page of results, if any
+	Results       []Bookmark `json:"results"`  // Array of bookmark objects
+	SortedLocally bool       `json:"-"`        // The server ignored WithSort, so only this page was sorted, by the client
 }
 
 // CreateBookmarkRequest represents the request payload for creating or updating a bookmark.
@@ -56,6 +69,8 @@ type CreateBookmarkRequest struct {
 	Shared          bool     `json:"shared,omitempty"`          // Whether to share the bookmark
 	IsArchived      bool     `json:"is_archived,omitempty"`     // Whether the bookmark should be archived
 	DisableScraping bool     `json:"disable_scraping,omitempty"` // Whether to disable metadata scraping
+	FaviconURL      string   `json:"favicon_url,omitempty"`     // Optional favicon URL, for use when scraping is disabled
+	PreviewImageURL string   `json:"preview_image_url,omitempty"` // Optional preview image URL, for use when scraping is disabled
 }
 
 // Tag represents a tag from the Linkding API.
@@ -78,65 +93,266 @@ type TagResponse struct {
 	Results  []Tag   `json:"results"`  // Array of tag objects
 }
 
+// defaultTimeout is the per-request timeout, unless changed with WithTimeout.
+const defaultTimeout = 30 * time.Second
+
 // NewClient creates a new Linkding API client with the provided base URL and API token.
 // The baseURL should include the protocol (e.g., "https://linkding.example.com").
+// For Linkding mounted under a subpath it includes that path
+// (e.g., "https://example.com/linkding/"); a trailing slash is ignored.
 // The apiToken can be obtained from the Linkding admin panel under Settings > Integrations.
-func NewClient(baseURL, apiToken string) *Client {
-	return &Client{
-		baseURL:  baseURL,
+// Without an apiToken only unauthenticated endpoints such as GetSharedBookmarks work.
+// Optional behavior can be configured by passing Option values.
+func NewClient(baseURL, apiToken string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
 		apiToken: apiToken,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		// Ask for JSON explicitly so content-negotiating proxies don't serve HTML
+		headers:            http.Header{"Accept": {"application/json"}},
+		httpClient:         &http.Client{CheckRedirect: checkRedirect},
+		timeout:            defaultTimeout,
+		maxResponseBodyLog: defaultMaxResponseBodyLog,
+		maxRetries:         defaultMaxRetries,
+		pageSize:           defaultPageSize,
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
-func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
-	url := c.baseURL + endpoint
+// token returns the API token for the next request, asking the token
+// provider for the current one if there is one.
+func (c *Client) token() string {
+	if c.tokenProvider != nil {
+		return c.tokenProvider()
+	}
+
+	return c.apiToken
+}
 
-	var reqBody *bytes.Buffer
+// makeRequest sends an API request, retrying transient failures when retries are enabled.
+// POST requests are never retried here because they aren't idempotent;
+// CreateBookmark implements its own duplicate-safe retries.
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	var jsonData []byte
 
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	retries := c.maxRetries
+	if method == http.MethodPost {
+		retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.doRequest(ctx, method, endpoint, jsonData)
+
+		retryable := (err != nil && ctx.Err() == nil && !errors.Is(err, ErrUnexpectedRedirect)) ||
+			(err == nil && isRetryableStatus(resp.StatusCode))
+		if attempt >= retries || !retryable {
+			return resp, err
+		}
+
+		var retryAfter time.Duration
+		if resp != nil {
+			retryAfter = parseRetryAfter(resp.Header, time.Now())
+		}
+
+		delay := retryDelay(attempt, retryAfter)
+		if !retryFits(ctx, delay) || !takeRetry(ctx) {
+			return resp, err
+		}
+
+		discardResponse(resp)
+
+		if err := waitRetry(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doRequest performs a single HTTP request against the API.
+// The client timeout bounds every request, also when ctx has a deadline of
+// its own; whichever expires first ends the request.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, jsonData []byte) (*http.Response, error) {
+	url := c.baseURL + endpoint
 
-		reqBody = bytes.NewBuffer(jsonData)
+	cancel := context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
 	}
 
 	var req *http.Request
 
 	var err error
 
-	if reqBody != nil {
-		req, err = http.NewRequestWithContext(ctx, method, url, reqBody)
+	if jsonData != nil {
+		req, err = http.NewRequestWithContext(ctx, method, url, bytes.NewReader(jsonData))
 	} else {
 		req, err = http.NewRequestWithContext(ctx, method, url, nil)
 	}
 
 	if err != nil {
+		cancel()
+
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Token "+c.apiToken)
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
 
-	if body != nil {
+	if c.acceptLanguage != "" && scrapesMetadata(method, endpoint) {
+		req.Header.Set("Accept-Language", c.acceptLanguage)
+	}
+
+	if token := c.token(); token != "" {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+
+	if jsonData != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	return c.httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// Custom transports may leave Request unset; error messages name its URL
+	if resp.Request == nil {
+		resp.Request = req
+	}
+
+	// The timeout must outlive this call until the caller has read the body.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// scrapesMetadata reports whether a request makes Linkding scrape the
+// bookmarked page: creating a bookmark or checking a URL
+func scrapesMetadata(method, endpoint string) bool {
+	return (method == http.MethodPost && endpoint == "/api/bookmarks/") ||
+		(method == http.MethodGet && strings.HasPrefix(endpoint, "/api/bookmarks/check/"))
+}
+
+// cancelOnClose releases a request's timeout context when its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
+
+// Ping performs a lightweight authenticated request against the API root.
+// It can be used to validate the base URL and API token, and to prime the
+// connection pool before the first real request.
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.makeRequest(ctx, "GET", "/api/", nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.newAPIError(resp)
+	}
+
+	return nil
+}
+
+// HealthInfo represents the response from Linkding's health endpoint.
+type HealthInfo struct {
+	Version string `json:"version"` // Linkding version, e.g. "1.41.0"
+	Status  string `json:"status"`  // "healthy" when the instance is operational
+}
+
+// Health retrieves the status and version of the Linkding instance.
+// The health endpoint doesn't require authentication.
+func (c *Client) Health(ctx context.Context) (*HealthInfo, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/health", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+
+	var health HealthInfo
+	if err := c.decodeResponse(resp, &health); err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
+
+// SupportsEndpoint reports whether the Linkding instance serves the given API endpoint
+// (e.g. "/api/bundles/"), which is used to detect features added in newer versions.
+// A 404 means unsupported; other non-200 statuses are returned as errors.
+func (c *Client) SupportsEndpoint(ctx context.Context, endpoint string) (bool, error) {
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, c.newAPIError(resp)
+	}
 }
 
 // GetBookmarks retrieves bookmarks from the Linkding API.
 // Parameters:
 //   - limit: Maximum number of bookmarks to return (0 for default)
 //   - offset: Number of bookmarks to skip (for pagination)
-//   - query: Search query to filter bookmarks (empty string for no filter)
+//   - query: Search query to filter bookmarks (empty string for no filter).
+//     The query is percent-encoded as UTF-8, so tag searches like "#日本語" work as-is.
+//   - opts: Optional server-side filters such as WithUnread and WithShared
 //
 // Returns a BookmarkResponse containing the results and pagination information.
-func (c *Client) GetBookmarks(ctx context.Context, limit, offset int, query string) (*BookmarkResponse, error) {
-	endpoint := "/api/bookmarks/"
+func (c *Client) GetBookmarks(ctx context.Context, limit, offset int, query string, opts ...ListOption) (*BookmarkResponse, error) {
+	return c.listBookmarks(ctx, "/api/bookmarks/", limit, offset, query, opts)
+}
+
+// GetArchivedBookmarks retrieves archived bookmarks from the Linkding API.
+// It accepts the same parameters as GetBookmarks.
+func (c *Client) GetArchivedBookmarks(ctx context.Context, limit, offset int, query string, opts ...ListOption) (*BookmarkResponse, error) {
+	return c.listBookmarks(ctx, "/api/bookmarks/archived/", limit, offset, query, opts)
+}
+
+func (c *Client) listBookmarks(ctx context.Context, endpoint string, limit, offset int, query string, opts []ListOption) (*BookmarkResponse, error) {
 	params := url.Values{}
 
 	if limit > 0 {
@@ -151,6 +367,10 @@ func (c *Client) GetBookmarks(ctx context.Context, limit, offset int, query stri
 		params.Set("q", query)
 	}
 
+	for _, opt := range opts {
+		opt(params)
+	}
+
 	if len(params) > 0 {
 		endpoint += "?" + params.Encode()
 	}
@@ -165,45 +385,167 @@ func (c *Client) GetBookmarks(ctx context.Context, limit, offset int, query stri
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, c.newAPIError(resp)
 	}
 
 	var bookmarkResponse BookmarkResponse
-	if err := json.NewDecoder(resp.Body).Decode(&bookmarkResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.decodeResponse(resp, &bookmarkResponse); err != nil {
+		return nil, err
 	}
 
+	applyLinkHeader(resp, &bookmarkResponse.Next, &bookmarkResponse.Previous)
+	bookmarkResponse.SortedLocally = ensureSorted(bookmarkResponse.Results, params)
+
 	return &bookmarkResponse, nil
 }
 
+// GetBookmark retrieves a single bookmark by its ID.
+// If there is no bookmark with that ID the error matches ErrNotFound.
+func (c *Client) GetBookmark(ctx context.Context, id int) (*Bookmark, error) {
+	endpoint := fmt.Sprintf("/api/bookmarks/%d/", id)
+
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+
+	var bookmark Bookmark
+	if err := c.decodeResponse(resp, &bookmark); err != nil {
+		return nil, err
+	}
+
+	return &bookmark, nil
+}
+
 // CreateBookmark creates a new bookmark in Linkding.
 // The URL field in the request is required; all other fields are optional.
 // Returns the created bookmark with server-generated fields populated.
+// Linkding updates the existing bookmark instead if the URL is already
+// bookmarked; use SaveBookmark to tell both cases apart.
+//
+// When retries are enabled, a failed attempt may still have been processed by
+// the server (e.g. a timeout after the bookmark was saved). Before each retry
+// the URL is looked up via CheckBookmark, and an existing bookmark is returned
+// instead of submitting the request again.
 func (c *Client) CreateBookmark(ctx context.Context, req CreateBookmarkRequest) (*Bookmark, error) {
+	bookmark, _, err := c.SaveBookmark(ctx, req)
+
+	return bookmark, err
+}
+
+// SaveBookmark is like CreateBookmark, additionally reporting whether a new
+// bookmark was created (201 Created) or an existing one with the same URL was
+// updated (200 OK). A bookmark found by the duplicate check before a retry is
+// reported as created, since the failed attempt most likely saved it.
+func (c *Client) SaveBookmark(ctx context.Context, req CreateBookmarkRequest) (*Bookmark, bool, error) {
+	for attempt := 0; ; attempt++ {
+		bookmark, created, err := c.createBookmark(ctx, req)
+		if attempt >= c.maxRetries || !isRetryableError(ctx, err) {
+			return bookmark, created, err
+		}
+
+		var retryAfter time.Duration
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			retryAfter = apiErr.RetryAfter
+		}
+
+		delay := retryDelay(attempt, retryAfter)
+		if !retryFits(ctx, delay) || !takeRetry(ctx) {
+			return bookmark, created, err
+		}
+
+		if err := waitRetry(ctx, delay); err != nil {
+			return nil, false, err
+		}
+
+		if check, checkErr := c.CheckBookmark(ctx, req.URL); checkErr == nil && check.Bookmark != nil {
+			return check.Bookmark, true, nil
+		}
+	}
+}
+
+// createBookmark performs a single bookmark creation request
+func (c *Client) createBookmark(ctx context.Context, req CreateBookmarkRequest) (*Bookmark, bool, error) {
 	resp, err := c.makeRequest(ctx, "POST", "/api/bookmarks/", req)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, false, c.newAPIError(resp)
 	}
 
 	var bookmark Bookmark
-	if err := json.NewDecoder(resp.Body).Decode(&bookmark); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.decodeResponse(resp, &bookmark); err != nil {
+		return nil, false, err
 	}
 
-	return &bookmark, nil
+	return &bookmark, resp.StatusCode == http.StatusCreated, nil
+}
+
+// CheckResult represents the response from the bookmark check API endpoint.
+type CheckResult struct {
+	Bookmark *Bookmark        `json:"bookmark"` // The existing bookmark for the URL, or nil if not bookmarked
+	Metadata *WebsiteMetadata `json:"metadata"` // Metadata scraped from the live page
+}
+
+// WebsiteMetadata represents the page metadata Linkding scrapes for a URL.
+type WebsiteMetadata struct {
+	URL          string `json:"url"`           // The URL the metadata was scraped from
+	Title        string `json:"title"`         // Page title, empty if none was found
+	Description  string `json:"description"`   // Page description, empty if none was found
+	PreviewImage string `json:"preview_image"` // Preview image URL, empty if none was found or unsupported by the server
+}
+
+// CheckBookmark checks whether a URL is already bookmarked in Linkding.
+// Returns a CheckResult whose Bookmark is nil when the URL isn't bookmarked yet.
+// The URL is query-escaped, so URLs containing "&", "#" or spaces are checked as is.
+func (c *Client) CheckBookmark(ctx context.Context, rawURL string) (*CheckResult, error) {
+	params := url.Values{}
+	params.Set("url", rawURL)
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/bookmarks/check/?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+
+	var checkResult CheckResult
+	if err := c.decodeResponse(resp, &checkResult); err != nil {
+		return nil, err
+	}
+
+	return &checkResult, nil
 }
 
 // UpdateBookmark updates an existing bookmark in Linkding.
 // The id parameter specifies which bookmark to update.
 // Returns the updated bookmark with all current field values.
+//
+// The request is sent with PUT, which replaces the whole bookmark: fields left
+// empty in req, such as notes or tags, are cleared. Use PatchBookmark to change
+// only some fields.
 func (c *Client) UpdateBookmark(ctx context.Context, id int, req CreateBookmarkRequest) (*Bookmark, error) {
 	endpoint := fmt.Sprintf("/api/bookmarks/%d/", id)
 
@@ -217,12 +559,40 @@ func (c *Client) UpdateBookmark(ctx context.Context, id int, req CreateBookmarkR
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, c.newAPIError(resp)
 	}
 
 	var bookmark Bookmark
-	if err := json.NewDecoder(resp.Body).Decode(&bookmark); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.decodeResponse(resp, &bookmark); err != nil {
+		return nil, err
+	}
+
+	return &bookmark, nil
+}
+
+// PatchBookmark partially updates an existing bookmark in Linkding.
+// Only the provided fields are sent (e.g. "title", "tag_names", "shared"),
+// leaving all other fields of the bookmark untouched.
+// Returns the updated bookmark with all current field values.
+func (c *Client) PatchBookmark(ctx context.Context, id int, fields map[string]any) (*Bookmark, error) {
+	endpoint := fmt.Sprintf("/api/bookmarks/%d/", id)
+
+	resp, err := c.makeRequest(ctx, "PATCH", endpoint, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+
+	var bookmark Bookmark
+	if err := c.decodeResponse(resp, &bookmark); err != nil {
+		return nil, err
 	}
 
 	return &bookmark, nil
@@ -244,7 +614,7 @@ func (c *Client) DeleteBookmark(ctx context.Context, id int) error {
 	}()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return c.newAPIError(resp)
 	}
 
 	return nil
@@ -266,7 +636,7 @@ func (c *Client) ArchiveBookmark(ctx context.Context, id int) error {
 	}()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return c.newAPIError(resp)
 	}
 
 	return nil
@@ -288,7 +658,7 @@ func (c *Client) UnarchiveBookmark(ctx context.Context, id int) error {
 	}()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return c.newAPIError(resp)
 	}
 
 	return nil
@@ -326,13 +696,134 @@ func (c *Client) GetTags(ctx context.Context, limit, offset int) (*TagResponse,
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, c.newAPIError(resp)
 	}
 
 	var tagResponse TagResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tagResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.decodeResponse(resp, &tagResponse); err != nil {
+		return nil, err
 	}
 
+	applyLinkHeader(resp, &tagResponse.Next, &tagResponse.Previous)
+
 	return &tagResponse, nil
 }
+
+// GetTag retrieves a single tag by its ID.
+func (c *Client) GetTag(ctx context.Context, id int) (*Tag, error) {
+	endpoint := fmt.Sprintf("/api/tags/%d/", id)
+
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+
+	var tag Tag
+	if err := c.decodeResponse(resp, &tag); err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// CreateTag creates a new tag with the given name.
+// Linkding returns the existing tag if one with the same name already exists.
+func (c *Client) CreateTag(ctx context.Context, name string) (*Tag, error) {
+	resp, err := c.makeRequest(ctx, "POST", "/api/tags/", map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+
+	var tag Tag
+	if err := c.decodeResponse(resp, &tag); err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// EnsureTag returns the tag with the given name, creating it if it doesn't exist yet.
+// Tag names are compared case-insensitively, like Linkding does. If creating
+// fails because the tag was created concurrently, the existing tag is returned.
+func (c *Client) EnsureTag(ctx context.Context, name string) (*Tag, error) {
+	if tag, err := c.FindTag(ctx, name); err != nil || tag != nil {
+		return tag, err
+	}
+
+	tag, err := c.CreateTag(ctx, name)
+	if err == nil {
+		return tag, nil
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		return nil, err
+	}
+
+	// Most likely a duplicate: look the tag up again before giving up
+	if existing, findErr := c.FindTag(ctx, name); findErr == nil && existing != nil {
+		return existing, nil
+	}
+
+	return nil, err
+}
+
+// FindTag looks up a tag by name, compared case-insensitively, returning nil
+// if there is none. Linkding can't filter tags by name, so this pages through
+// all of them; callers already holding the tag list should search it instead.
+func (c *Client) FindTag(ctx context.Context, name string) (*Tag, error) {
+	tags, err := c.GetAllTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if strings.EqualFold(tag.Name, name) {
+			return &tag, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetBookmarksByTagID retrieves bookmarks tagged with the tag of the given ID.
+// Linkding's bookmark list endpoint has no tag ID filter, so the tag is
+// resolved to its name and searched with a "#name" query.
+func (c *Client) GetBookmarksByTagID(ctx context.Context, tagID, limit, offset int) (*BookmarkResponse, error) {
+	tag, err := c.GetTag(ctx, tagID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve tag %d: %w", tagID, err)
+	}
+
+	return c.GetBookmarks(ctx, limit, offset, "#"+tag.Name)
+}
+
+// SharedBookmarkURL returns the URL of Linkding's shared bookmarks page filtered to the given bookmark.
+// The page is only reachable without login when public sharing is enabled in the Linkding settings.
+func (c *Client) SharedBookmarkURL(bookmark Bookmark) string {
+	params := url.Values{}
+	params.Set("q", bookmark.URL)
+
+	return c.baseURL + "/bookmarks/shared?" + params.Encode()
+}
+
+// SharedFeedURL returns the URL of Linkding's RSS feed of shared bookmarks.
+func (c *Client) SharedFeedURL() string {
+	return c.baseURL + "/feeds/shared"
+}
@@ -0,0 +1,921 @@
+package linkding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingServer answers every request with status and body. The returned
+// function returns the requests received so far.
+func recordingServer(t *testing.T, status int, body string) (*httptest.Server, func() []*http.Request) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Clone(context.Background()))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []*http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return slices.Clone(requests)
+	}
+}
+
+func TestGetBookmarksParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		offset    int
+		query     string
+		opts      []ListOption
+		wantQuery string
+	}{
+		{name: "no params", wantQuery: ""},
+		{name: "paging", limit: 10, offset: 20, wantQuery: "limit=10&offset=20"},
+		{name: "shared filter", query: "go", opts: []ListOption{WithShared(true)}, wantQuery: "q=go&shared=yes"},
+		{name: "unread and shared filters", opts: []ListOption{WithUnread(false), WithShared(false)}, wantQuery: "shared=no&unread=no"},
+		{name: "query encoded as UTF-8", query: "#日本語 a&b=c", wantQuery: "q=%23%E6%97%A5%E6%9C%AC%E8%AA%9E+a%26b%3Dc"},
+		{name: "sort", opts: []ListOption{WithSort(SortTitleAsc)}, wantQuery: "sort=title_asc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, http.StatusOK, `{"count": 0, "results": []}`)
+
+			if _, err := NewClient(srv.URL, "token").GetBookmarks(context.Background(), tt.limit, tt.offset, tt.query, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			received := requests()
+			if len(received) != 1 {
+				t.Fatalf("got %d requests, want 1", len(received))
+			}
+
+			r := received[0]
+			if r.URL.Path != "/api/bookmarks/" || r.URL.RawQuery != tt.wantQuery {
+				t.Errorf("requested %s?%s, want /api/bookmarks/?%s", r.URL.Path, r.URL.RawQuery, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestSaveBookmarkRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		// firstPost answers the first POST; savesFirst tells whether that
+		// attempt saved the bookmark anyway
+		firstPost   func(w http.ResponseWriter)
+		savesFirst  bool
+		wantPosts   int
+		wantChecks  int
+		wantCreated bool
+		wantErr     bool
+	}{
+		{
+			name: "timeout after the bookmark was saved",
+			firstPost: func(w http.ResponseWriter) {
+				time.Sleep(300 * time.Millisecond)
+			},
+			savesFirst:  true,
+			wantPosts:   1,
+			wantChecks:  1,
+			wantCreated: true,
+		},
+		{
+			name: "transient failure before saving",
+			firstPost: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			wantPosts:   2,
+			wantChecks:  1,
+			wantCreated: true,
+		},
+		{
+			name: "validation error is not retried",
+			firstPost: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"url": ["Enter a valid URL."]}`))
+			},
+			wantPosts: 1,
+			wantErr:   true,
+		},
+	}
+
+	const bookmark = `{"id": 7, "url": "https://example.com"}`
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				saved  atomic.Bool
+				posts  atomic.Int32
+				checks atomic.Int32
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && posts.Add(1) == 1:
+					saved.Store(tt.savesFirst)
+					tt.firstPost(w)
+				case r.Method == http.MethodPost:
+					saved.Store(true)
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(bookmark))
+				case r.URL.Path == "/api/bookmarks/check/" && saved.Load():
+					checks.Add(1)
+					_, _ = w.Write([]byte(`{"bookmark": ` + bookmark + `}`))
+				case r.URL.Path == "/api/bookmarks/check/":
+					checks.Add(1)
+					_, _ = w.Write([]byte(`{"bookmark": null}`))
+				}
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, "token", WithTimeout(100*time.Millisecond))
+
+			got, created, err := client.SaveBookmark(context.Background(), CreateBookmarkRequest{URL: "https://example.com"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SaveBookmark() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if int(posts.Load()) != tt.wantPosts || int(checks.Load()) != tt.wantChecks {
+				t.Errorf("got %d POSTs and %d checks, want %d and %d", posts.Load(), checks.Load(), tt.wantPosts, tt.wantChecks)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got == nil || got.ID != 7 || created != tt.wantCreated {
+				t.Errorf("got %+v, created %v; want bookmark 7, created %v", got, created, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestGetBookmarksByTagID(t *testing.T) {
+	tests := []struct {
+		name      string
+		tagID     int
+		wantQuery string
+		wantErr   bool
+	}{
+		{name: "resolved to a tag search", tagID: 1, wantQuery: "limit=5&offset=10&q=%23golang"},
+		{name: "tag name encoded", tagID: 2, wantQuery: "limit=5&offset=10&q=%23c%2B%2B"},
+		{name: "unknown tag", tagID: 3, wantErr: true},
+	}
+
+	tags := map[string]string{
+		"/api/tags/1/": `{"id": 1, "name": "golang"}`,
+		"/api/tags/2/": `{"id": 2, "name": "c++"}`,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				queries []string
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				if r.URL.Path == "/api/bookmarks/" {
+					mu.Lock()
+					queries = append(queries, r.URL.RawQuery)
+					mu.Unlock()
+
+					_, _ = w.Write([]byte(`{"count": 1, "results": [{"id": 9, "url": "https://example.com"}]}`))
+
+					return
+				}
+
+				tag, ok := tags[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+
+					return
+				}
+
+				_, _ = w.Write([]byte(tag))
+			}))
+			defer srv.Close()
+
+			response, err := NewClient(srv.URL, "token", WithRetries(0)).GetBookmarksByTagID(context.Background(), tt.tagID, 5, 10)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBookmarksByTagID() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if tt.wantErr {
+				if len(queries) > 0 {
+					t.Errorf("listed bookmarks %v for an unknown tag", queries)
+				}
+
+				return
+			}
+
+			if !slices.Equal(queries, []string{tt.wantQuery}) {
+				t.Errorf("queries = %v, want [%s]", queries, tt.wantQuery)
+			}
+
+			if len(response.Results) != 1 || response.Results[0].ID != 9 {
+				t.Errorf("results = %+v, want bookmark 9", response.Results)
+			}
+		})
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name          string
+		clientTimeout time.Duration
+		ctxTimeout    time.Duration
+	}{
+		{name: "context deadline shorter than the client timeout", clientTimeout: 5 * time.Second, ctxTimeout: 50 * time.Millisecond},
+		{name: "client timeout without a context deadline", clientTimeout: 50 * time.Millisecond},
+		{name: "client timeout shorter than the context deadline", clientTimeout: 50 * time.Millisecond, ctxTimeout: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			client := NewClient(srv.URL, "token", WithTimeout(tt.clientTimeout), WithRetries(0))
+
+			start := time.Now()
+			if _, err := client.GetBookmark(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("GetBookmark() error = %v, want a deadline error", err)
+			}
+
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("request took %v, want it cut off after about 50ms", elapsed)
+			}
+		})
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	tests := []struct {
+		name         string
+		budget       int
+		calls        int
+		wantRequests int
+	}{
+		{name: "budget spent across calls", budget: 2, calls: 4, wantRequests: 6},
+		{name: "empty budget disables retries", budget: 0, calls: 3, wantRequests: 3},
+		{name: "negative budget counts as empty", budget: -1, calls: 2, wantRequests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(http.StatusBadGateway)
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, "token", WithRetries(1))
+			ctx := ContextWithRetryBudget(context.Background(), tt.budget)
+
+			for i := range tt.calls {
+				if _, err := client.GetBookmark(ctx, i+1); err == nil {
+					t.Fatal("GetBookmark succeeded, want an error")
+				}
+			}
+
+			if got := int(requests.Load()); got != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestEnsureTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		lookup string
+		// createdConcurrently makes the POST fail with 400 as if another
+		// client created the tag first
+		createdConcurrently bool
+		createStatus        int
+		wantName            string
+		wantPosts           int32
+		wantErr             bool
+	}{
+		{name: "existing tag, compared case-insensitively", lookup: "go", wantName: "Go"},
+		{name: "missing tag created", lookup: "python", createStatus: http.StatusCreated, wantName: "python", wantPosts: 1},
+		{name: "created concurrently", lookup: "python", createdConcurrently: true, createStatus: http.StatusBadRequest, wantName: "python", wantPosts: 1},
+		{name: "validation error", lookup: "python", createStatus: http.StatusBadRequest, wantPosts: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				posts   atomic.Int32
+				created atomic.Bool
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				if r.Method == http.MethodPost {
+					posts.Add(1)
+					created.Store(tt.createdConcurrently)
+					w.WriteHeader(tt.createStatus)
+
+					if tt.createStatus == http.StatusCreated {
+						_, _ = w.Write([]byte(`{"id": 3, "name": "python"}`))
+					} else {
+						_, _ = w.Write([]byte(`{"name": ["Invalid tag."]}`))
+					}
+
+					return
+				}
+
+				tags := `{"id": 1, "name": "Go"}`
+				if created.Load() {
+					tags += `, {"id": 3, "name": "python"}`
+				}
+
+				_, _ = w.Write([]byte(`{"count": 1, "next": null, "previous": null, "results": [` + tags + `]}`))
+			}))
+			defer srv.Close()
+
+			tag, err := NewClient(srv.URL, "token").EnsureTag(context.Background(), tt.lookup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnsureTag() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if err == nil && tag.Name != tt.wantName {
+				t.Errorf("tag = %q, want %q", tag.Name, tt.wantName)
+			}
+
+			if got := posts.Load(); got != tt.wantPosts {
+				t.Errorf("sent %d POST requests, want %d", got, tt.wantPosts)
+			}
+		})
+	}
+}
+
+func TestTokenProvider(t *testing.T) {
+	tests := []struct {
+		name string
+		// status answers every request; retries are made with the token current at that time
+		status    int
+		retries   int
+		requests  int
+		wantAuths []string
+	}{
+		{name: "token per request", status: http.StatusOK, requests: 3, wantAuths: []string{"Token token-1", "Token token-2", "Token token-3"}},
+		{name: "token per retry", status: http.StatusServiceUnavailable, retries: 1, requests: 1, wantAuths: []string{"Token token-1", "Token token-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, tt.status, `{"count": 0, "results": []}`)
+
+			var calls atomic.Int32
+
+			client := NewClient(srv.URL, "static", WithRetries(tt.retries), WithTokenProvider(func() string {
+				return fmt.Sprintf("token-%d", calls.Add(1))
+			}))
+
+			for range tt.requests {
+				_, _ = client.GetBookmarks(context.Background(), 0, 0, "")
+			}
+
+			var auths []string
+			for _, r := range requests() {
+				auths = append(auths, r.Header.Get("Authorization"))
+			}
+
+			if !slices.Equal(auths, tt.wantAuths) {
+				t.Errorf("sent %v, want %v", auths, tt.wantAuths)
+			}
+		})
+	}
+}
+
+func TestDefaultHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "Accept: application/json by default",
+			wantHeaders: map[string]string{"Accept": "application/json", "Authorization": "Token token"},
+		},
+		{
+			name:        "extra headers",
+			opts:        []Option{WithDefaultHeaders(http.Header{"x-proxy-key": {"secret"}})},
+			wantHeaders: map[string]string{"Accept": "application/json", "X-Proxy-Key": "secret"},
+		},
+		{
+			name:        "Accept replaced",
+			opts:        []Option{WithDefaultHeaders(http.Header{"Accept": {"application/json; version=2"}})},
+			wantHeaders: map[string]string{"Accept": "application/json; version=2"},
+		},
+		{
+			name:        "Authorization not replaced",
+			opts:        []Option{WithDefaultHeaders(http.Header{"Authorization": {"Basic Zm9v"}})},
+			wantHeaders: map[string]string{"Authorization": "Token token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, http.StatusOK, `{"count": 0, "results": []}`)
+
+			client := NewClient(srv.URL, "token", tt.opts...)
+			if _, err := client.GetBookmarks(context.Background(), 0, 0, ""); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := client.GetTags(context.Background(), 0, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			received := requests()
+			if len(received) != 2 {
+				t.Fatalf("got %d requests, want 2", len(received))
+			}
+
+			for _, r := range received {
+				for name, want := range tt.wantHeaders {
+					if got := r.Header.Get(name); got != want {
+						t.Errorf("%s %s: %s = %q, want %q", r.Method, r.URL.Path, name, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSaveBookmarkCreated(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		wantCreated bool
+		wantErr     bool
+	}{
+		{name: "new bookmark", status: http.StatusCreated, wantCreated: true},
+		{name: "existing bookmark updated", status: http.StatusOK},
+		{name: "rejected", status: http.StatusBadRequest, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, tt.status, `{"id": 7, "url": "https://a.example"}`)
+
+			bookmark, created, err := NewClient(srv.URL, "token").SaveBookmark(context.Background(), CreateBookmarkRequest{URL: "https://a.example"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if created != tt.wantCreated {
+				t.Errorf("created = %v, want %v", created, tt.wantCreated)
+			}
+
+			if !tt.wantErr && bookmark.ID != 7 {
+				t.Errorf("bookmark = %+v, want ID 7", bookmark)
+			}
+
+			if len(requests()) != 1 {
+				t.Errorf("sent %d requests, want 1", len(requests()))
+			}
+		})
+	}
+}
+
+func TestGetBookmark(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{name: "found", status: http.StatusOK, body: `{"id": 5, "url": "https://a.example", "title": "A", "tag_names": ["go"]}`},
+		{name: "not found", status: http.StatusNotFound, body: `{"detail": "Not found."}`, wantErr: true, wantNotFound: true},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, tt.status, tt.body)
+
+			bookmark, err := NewClient(srv.URL, "token", WithRetries(0)).GetBookmark(context.Background(), 5)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("error = %v, want ErrNotFound %v", err, tt.wantNotFound)
+			}
+
+			if !tt.wantErr && (bookmark.ID != 5 || bookmark.Title != "A" || !slices.Equal(bookmark.TagNames, []string{"go"})) {
+				t.Errorf("bookmark = %+v, want bookmark 5", bookmark)
+			}
+
+			if received := requests(); len(received) != 1 || received[0].Method != http.MethodGet || received[0].URL.Path != "/api/bookmarks/5/" {
+				t.Errorf("requests = %v, want one GET /api/bookmarks/5/", received)
+			}
+		})
+	}
+}
+
+func TestBaseURLPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		opts     []Option
+		wantPath string
+	}{
+		{name: "no subpath", wantPath: "/api/bookmarks/"},
+		{name: "trailing slash", basePath: "/", wantPath: "/api/bookmarks/"},
+		{name: "subpath in the base URL", basePath: "/linkding", wantPath: "/linkding/api/bookmarks/"},
+		{name: "subpath with a trailing slash", basePath: "/linkding/", wantPath: "/linkding/api/bookmarks/"},
+		{name: "nested subpath", basePath: "/apps/linkding//", wantPath: "/apps/linkding/api/bookmarks/"},
+		{name: "option", opts: []Option{WithBaseURLPath("linkding")}, wantPath: "/linkding/api/bookmarks/"},
+		{name: "option with slashes", basePath: "/", opts: []Option{WithBaseURLPath("/linkding/")}, wantPath: "/linkding/api/bookmarks/"},
+		{name: "option after a subpath", basePath: "/apps", opts: []Option{WithBaseURLPath("/linkding")}, wantPath: "/apps/linkding/api/bookmarks/"},
+		{name: "empty option", opts: []Option{WithBaseURLPath("/")}, wantPath: "/api/bookmarks/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, http.StatusOK, `{"count": 0, "results": []}`)
+
+			if _, err := NewClient(srv.URL+tt.basePath, "token", tt.opts...).GetBookmarks(context.Background(), 0, 0, ""); err != nil {
+				t.Fatal(err)
+			}
+
+			if received := requests(); len(received) != 1 || received[0].URL.Path != tt.wantPath {
+				t.Errorf("requests = %v, want one for %s", received, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestDeleteBookmark(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{name: "204 No Content", status: http.StatusNoContent},
+		{name: "not found", status: http.StatusNotFound, wantErr: true, wantNotFound: true},
+		{name: "unexpected success status", status: http.StatusOK, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, tt.status, "")
+
+			err := NewClient(srv.URL, "token").DeleteBookmark(context.Background(), 3)
+			if (err != nil) != tt.wantErr || errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("error = %v, want error %v (not found %v)", err, tt.wantErr, tt.wantNotFound)
+			}
+
+			if received := requests(); len(received) != 1 || received[0].Method != http.MethodDelete || received[0].URL.Path != "/api/bookmarks/3/" {
+				t.Errorf("requests = %v, want one DELETE /api/bookmarks/3/", received)
+			}
+		})
+	}
+}
+
+func TestPatchBookmark(t *testing.T) {
+	tests := []struct {
+		name         string
+		fields       map[string]any
+		status       int
+		wantBody     string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{name: "only title", fields: map[string]any{"title": "New"}, status: http.StatusOK, wantBody: `{"title":"New"}`},
+		{name: "tags and unread", fields: map[string]any{"tag_names": []string{"go"}, "unread": false}, status: http.StatusOK, wantBody: `{"tag_names":["go"],"unread":false}`},
+		{name: "not found", fields: map[string]any{"title": "New"}, status: http.StatusNotFound, wantBody: `{"title":"New"}`, wantErr: true, wantNotFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, path, body string
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				method, path, body = r.Method, r.URL.Path, string(data)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"id": 3, "url": "https://example.com", "title": "New", "notes": "kept"}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			bookmark, err := NewClient(srv.URL, "token").PatchBookmark(context.Background(), 3, tt.fields)
+			if (err != nil) != tt.wantErr || errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Fatalf("error = %v, want error %v (not found %v)", err, tt.wantErr, tt.wantNotFound)
+			}
+
+			if method != http.MethodPatch || path != "/api/bookmarks/3/" || body != tt.wantBody {
+				t.Errorf("sent %s %s %s, want PATCH /api/bookmarks/3/ %s", method, path, body, tt.wantBody)
+			}
+
+			if !tt.wantErr && (bookmark.ID != 3 || bookmark.Notes != "kept") {
+				t.Errorf("bookmark = %+v, want the patched bookmark 3", bookmark)
+			}
+		})
+	}
+}
+
+func TestCheckBookmark(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		wantBookmark int
+		wantMetadata WebsiteMetadata
+	}{
+		{
+			name:         "not bookmarked",
+			url:          "https://example.com/a",
+			body:         `{"bookmark": null, "metadata": {"url": "https://example.com/a", "title": "A", "description": "About A", "preview_image": "https://example.com/a.png"}}`,
+			wantMetadata: WebsiteMetadata{URL: "https://example.com/a", Title: "A", Description: "About A", PreviewImage: "https://example.com/a.png"},
+		},
+		{
+			name:         "already bookmarked",
+			url:          "https://example.com/b",
+			body:         `{"bookmark": {"id": 7, "url": "https://example.com/b"}, "metadata": {"url": "https://example.com/b", "title": "B", "description": ""}}`,
+			wantBookmark: 7,
+			wantMetadata: WebsiteMetadata{URL: "https://example.com/b", Title: "B"},
+		},
+		{
+			name:         "query characters escaped",
+			url:          "https://example.com/search?q=a b&page=2#top",
+			body:         `{"bookmark": null, "metadata": {"url": "https://example.com/search?q=a b&page=2#top", "title": ""}}`,
+			wantMetadata: WebsiteMetadata{URL: "https://example.com/search?q=a b&page=2#top"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, http.StatusOK, tt.body)
+
+			checkResult, err := NewClient(srv.URL, "token").CheckBookmark(context.Background(), tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			received := requests()
+			if len(received) != 1 || received[0].URL.Path != "/api/bookmarks/check/" || received[0].URL.Query().Get("url") != tt.url {
+				t.Fatalf("requests = %v, want one check of %q", received, tt.url)
+			}
+
+			if len(received[0].URL.Query()) != 1 {
+				t.Errorf("query = %v, want only the url param", received[0].URL.Query())
+			}
+
+			gotBookmark := 0
+			if checkResult.Bookmark != nil {
+				gotBookmark = checkResult.Bookmark.ID
+			}
+
+			if gotBookmark != tt.wantBookmark {
+				t.Errorf("bookmark = %d, want %d", gotBookmark, tt.wantBookmark)
+			}
+
+			if checkResult.Metadata == nil || *checkResult.Metadata != tt.wantMetadata {
+				t.Errorf("metadata = %+v, want %+v", checkResult.Metadata, tt.wantMetadata)
+			}
+		})
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		timeout time.Duration
+	}{
+		{name: "default", timeout: defaultTimeout},
+		{name: "longer", opts: []Option{WithTimeout(2 * time.Minute)}, timeout: 2 * time.Minute},
+		{name: "disabled", opts: []Option{WithTimeout(0)}},
+		{name: "negative keeps the default", opts: []Option{WithTimeout(-time.Second)}, timeout: defaultTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline time.Time
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id": 1}`))
+			}))
+			defer srv.Close()
+
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				deadline, _ = req.Context().Deadline()
+
+				return http.DefaultTransport.RoundTrip(req)
+			})
+
+			client := NewClient(srv.URL, "token", append(tt.opts, WithHTTPClient(&http.Client{Transport: transport}))...)
+
+			start := time.Now()
+			if _, err := client.GetBookmark(context.Background(), 1); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.timeout == 0 {
+				if !deadline.IsZero() {
+					t.Errorf("request deadline in %v, want none", deadline.Sub(start))
+				}
+
+				return
+			}
+
+			if got := deadline.Sub(start); got < tt.timeout || got > tt.timeout+time.Second {
+				t.Errorf("request deadline in %v, want %v", got, tt.timeout)
+			}
+		})
+	}
+}
+
+func TestAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		call     func(ctx context.Context, c *Client) error
+		want     string
+	}{
+		{
+			name:     "create",
+			language: "de-DE,de;q=0.9",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CreateBookmark(ctx, CreateBookmarkRequest{URL: "https://example.com"})
+				return err
+			},
+			want: "de-DE,de;q=0.9",
+		},
+		{
+			name:     "check",
+			language: "de-DE,de;q=0.9",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CheckBookmark(ctx, "https://example.com")
+				return err
+			},
+			want: "de-DE,de;q=0.9",
+		},
+		{
+			name:     "not sent on other requests",
+			language: "de-DE,de;q=0.9",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.GetBookmark(ctx, 1)
+				return err
+			},
+		},
+		{
+			name: "not configured",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CreateBookmark(ctx, CreateBookmarkRequest{URL: "https://example.com"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got  string
+				sent bool
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, sent = r.Header.Get("Accept-Language"), r.Header["Accept-Language"] != nil
+
+				w.Header().Set("Content-Type", "application/json")
+
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusCreated)
+				}
+
+				_, _ = w.Write([]byte(`{"id": 1, "url": "https://example.com"}`))
+			}))
+			defer srv.Close()
+
+			if err := tt.call(context.Background(), NewClient(srv.URL, "token", WithAcceptLanguage(tt.language))); err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want || sent != (tt.want != "") {
+				t.Errorf("Accept-Language = %q (sent %v), want %q", got, sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	srv, _ := recordingServer(t, http.StatusOK, `{"id": 1, "url": "https://example.com"}`)
+
+	t.Run("requests go through the custom transport", func(t *testing.T) {
+		var requests []string
+
+		transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path+" "+req.Header.Get("Authorization"))
+
+			return http.DefaultTransport.RoundTrip(req)
+		})
+
+		httpClient := &http.Client{Transport: transport}
+
+		if _, err := NewClient(srv.URL, "token", WithHTTPClient(httpClient)).GetBookmark(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"GET /api/bookmarks/1/ Token token"}; !slices.Equal(requests, want) {
+			t.Errorf("transport saw %q, want %q", requests, want)
+		}
+
+		if httpClient.CheckRedirect != nil {
+			t.Error("the caller's client was modified")
+		}
+	})
+
+	t.Run("own redirect policy kept", func(t *testing.T) {
+		errPolicy := errors.New("custom policy")
+		client := NewClient(srv.URL, "token", WithHTTPClient(&http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error { return errPolicy },
+		}))
+
+		if err := client.httpClient.CheckRedirect(nil, nil); !errors.Is(err, errPolicy) {
+			t.Errorf("CheckRedirect() = %v, want the custom policy", err)
+		}
+	})
+
+	t.Run("own timeout applies", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer slow.Close()
+
+		client := NewClient(slow.URL, "token", WithRetries(0), WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+
+		start := time.Now()
+		if _, err := client.GetBookmark(context.Background(), 1); err == nil || time.Since(start) > 500*time.Millisecond {
+			t.Errorf("GetBookmark() = %v after %v, want a timeout after about 50ms", err, time.Since(start))
+		}
+	})
+
+	t.Run("nil keeps the default client", func(t *testing.T) {
+		if _, err := NewClient(srv.URL, "token", WithHTTPClient(nil)).GetBookmark(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
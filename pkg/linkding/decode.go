@@ -0,0 +1,67 @@
+package linkding
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrHTMLResponse is returned when the server answers an API request with an
+// HTML page instead of JSON, typically a reverse proxy login page or a wrong base URL.
+var ErrHTMLResponse = errors.New("received HTML instead of JSON, check the Linkding URL and authentication (a proxy login page?)")
+
+// JSONDecoderOptions controls how API responses are decoded. The zero value
+// is lenient: fields the client doesn't know about are ignored, so newer
+// Linkding versions adding fields keep working. Setting DisallowUnknownFields
+// makes decoding fail on them instead, which helps catch API drift in tests.
+type JSONDecoderOptions struct {
+	DisallowUnknownFields bool
+}
+
+// decodeResponse decodes a JSON response body into v, detecting HTML pages
+// served with a 200 status so they fail with a clear error. Unknown fields
+// are rejected when the client was configured for strict decoding.
+func (c *Client) decodeResponse(resp *http.Response, v any) error {
+	body := bufio.NewReader(resp.Body)
+
+	if isHTML(resp.Header.Get("Content-Type"), body) {
+		if resp.Request == nil {
+			return ErrHTMLResponse
+		}
+
+		return fmt.Errorf("%w: %s", ErrHTMLResponse, resp.Request.URL.Redacted())
+	}
+
+	decoder := json.NewDecoder(body)
+	if c.decoderOptions.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// isHTML reports whether a response is HTML, based on its content type or,
+// when that is missing or generic, on the body starting with a '<'
+func isHTML(contentType string, body *bufio.Reader) bool {
+	if strings.Contains(contentType, "html") {
+		return true
+	}
+
+	if strings.Contains(contentType, "json") {
+		return false
+	}
+
+	// JSON never starts with '<', so peeking past leading whitespace is enough.
+	peek, _ := body.Peek(512)
+	peek = bytes.TrimLeft(peek, " \t\r\n")
+
+	return len(peek) > 0 && peek[0] == '<'
+}
@@ -0,0 +1,160 @@
+package linkding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTMLResponse(t *testing.T) {
+	const loginPage = "<!DOCTYPE html><html><body><form>Log in</form></body></html>"
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantHTML    bool
+		wantErr     bool
+	}{
+		{name: "HTML content type", contentType: "text/html; charset=utf-8", body: loginPage, wantHTML: true, wantErr: true},
+		{name: "no content type", body: "\n  " + loginPage, wantHTML: true, wantErr: true},
+		{name: "generic content type", contentType: "application/octet-stream", body: "<html></html>", wantHTML: true, wantErr: true},
+		{name: "JSON", contentType: "application/json", body: `{"id": 5, "url": "https://example.com"}`},
+		{name: "JSON without content type", body: ` {"id": 5, "url": "https://example.com"}`},
+		{name: "invalid JSON", contentType: "application/json", body: "<oops>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// An empty Content-Type header stops net/http from sniffing one
+				w.Header()["Content-Type"] = []string{tt.contentType}
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			bookmark, err := NewClient(srv.URL, "token").GetBookmark(context.Background(), 5)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBookmark() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if errors.Is(err, ErrHTMLResponse) != tt.wantHTML {
+				t.Errorf("error = %v, want ErrHTMLResponse %v", err, tt.wantHTML)
+			}
+
+			if tt.wantHTML && !strings.Contains(err.Error(), "/api/bookmarks/5/") {
+				t.Errorf("error %q doesn't name the requested URL", err)
+			}
+
+			if !tt.wantErr && bookmark.ID != 5 {
+				t.Errorf("bookmark = %+v, want ID 5", bookmark)
+			}
+		})
+	}
+}
+
+// roundTripFunc lets a function serve as an http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHTMLResponseWithoutRequest(t *testing.T) {
+	// A transport that doesn't set Response.Request, unlike net/http's
+	transport := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"text/html"}},
+			Body:       io.NopCloser(strings.NewReader("<html></html>")),
+		}, nil
+	})
+
+	client := NewClient("https://linkding.example", "token", WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.GetBookmark(context.Background(), 5)
+	if !errors.Is(err, ErrHTMLResponse) || !strings.Contains(err.Error(), "https://linkding.example/api/bookmarks/5/") {
+		t.Errorf("error = %v, want ErrHTMLResponse naming the requested URL", err)
+	}
+
+	resp := &http.Response{Header: http.Header{"Content-Type": {"text/html"}}, Body: io.NopCloser(strings.NewReader("<html></html>"))}
+	if err := client.decodeResponse(resp, &Bookmark{}); !errors.Is(err, ErrHTMLResponse) {
+		t.Errorf("decodeResponse() error = %v, want ErrHTMLResponse", err)
+	}
+}
+
+func TestJSONDecoderOptions(t *testing.T) {
+	const (
+		bookmark        = `{"id": 5, "url": "https://example.com", "title": "Example"}`
+		unknownBookmark = `{"id": 5, "url": "https://example.com", "title": "Example", "added_in_a_later_release": true}`
+	)
+
+	tests := []struct {
+		name    string
+		opts    JSONDecoderOptions
+		call    func(ctx context.Context, c *Client) error
+		body    string
+		wantErr bool
+	}{
+		{name: "lenient ignores unknown fields", body: unknownBookmark, call: getBookmark},
+		{name: "strict accepts known fields", opts: JSONDecoderOptions{DisallowUnknownFields: true}, body: bookmark, call: getBookmark},
+		{name: "strict rejects unknown fields", opts: JSONDecoderOptions{DisallowUnknownFields: true}, body: unknownBookmark, call: getBookmark, wantErr: true},
+		{
+			name:    "strict rejects unknown fields in lists",
+			opts:    JSONDecoderOptions{DisallowUnknownFields: true},
+			body:    `{"count": 1, "next": null, "previous": null, "results": [` + unknownBookmark + `]}`,
+			call:    getBookmarks,
+			wantErr: true,
+		},
+		{
+			name:    "strict rejects unknown fields at the top level",
+			opts:    JSONDecoderOptions{DisallowUnknownFields: true},
+			body:    `{"count": 0, "next": null, "previous": null, "results": [], "total_pages": 0}`,
+			call:    getBookmarks,
+			wantErr: true,
+		},
+		{
+			name: "lenient ignores unknown fields in lists",
+			body: `{"count": 1, "next": null, "previous": null, "results": [` + unknownBookmark + `], "total_pages": 1}`,
+			call: getBookmarks,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := recordingServer(t, http.StatusOK, tt.body)
+
+			err := tt.call(context.Background(), NewClient(srv.URL, "token", WithJSONDecoderOptions(tt.opts)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !strings.Contains(err.Error(), "unknown field") {
+				t.Errorf("error = %v, want it to name the unknown field", err)
+			}
+		})
+	}
+}
+
+func getBookmark(ctx context.Context, c *Client) error {
+	bookmark, err := c.GetBookmark(ctx, 5)
+	if err == nil && bookmark.Title != "Example" {
+		return fmt.Errorf("decoded %+v", bookmark)
+	}
+
+	return err
+}
+
+func getBookmarks(ctx context.Context, c *Client) error {
+	page, err := c.GetBookmarks(ctx, 10, 0, "")
+	if err == nil && (len(page.Results) != 1 || page.Results[0].Title != "Example") {
+		return fmt.Errorf("decoded %+v", page)
+	}
+
+	return err
+}
@@ -0,0 +1,135 @@
+package linkding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxErrorBodySize limits how much of an error response body is read for decoding.
+	maxErrorBodySize = 64 * 1024
+	// defaultMaxResponseBodyLog is the default number of body bytes kept in APIError.Body.
+	defaultMaxResponseBodyLog = 512
+	// maxErrorMessageBody limits how much of APIError.Body is included in its error message.
+	maxErrorMessageBody = 200
+)
+
+// ErrNotFound matches APIErrors with status 404 Not Found, e.g.
+// errors.Is(err, ErrNotFound) after GetBookmark with an unknown ID.
+var ErrNotFound = errors.New("not found")
+
+// APIError represents a non-successful response from the Linkding API.
+type APIError struct {
+	StatusCode  int                 // HTTP status code returned by the API
+	Body        string              // Response body, truncated to the client's WithMaxResponseBodyLog limit
+	FieldErrors map[string][]string // Field-level validation messages, e.g. {"url": ["Enter a valid URL."]}
+	RetryAfter  time.Duration       // Delay requested by a Retry-After header, e.g. with 429 or 503; zero if none
+}
+
+// Error implements the error interface. The message includes the field
+// errors, or the start of the body when there are none.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API request failed with status %d", e.StatusCode)
+
+	if len(e.FieldErrors) == 0 {
+		// Not a validation error, e.g. a plain text or HTML error page, so
+		// show the start of the body on a single line instead
+		if body := strings.Join(strings.Fields(e.Body), " "); body != "" {
+			return msg + ": " + truncateBody([]byte(body), maxErrorMessageBody)
+		}
+
+		return msg
+	}
+
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	details := make([]string, 0, len(fields))
+	for _, field := range fields {
+		details = append(details, fmt.Sprintf("%s: %s", field, strings.Join(e.FieldErrors[field], " ")))
+	}
+
+	return msg + ": " + strings.Join(details, "; ")
+}
+
+// Is reports whether the error matches target, making a 404 APIError match ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// newAPIError builds an APIError from a response, decoding Django REST
+// Framework style validation errors from the body when present.
+func (c *Client) newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header, time.Now())}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return apiErr
+	}
+
+	apiErr.Body = truncateBody(body, c.maxResponseBodyLog)
+	apiErr.FieldErrors = decodeFieldErrors(body)
+
+	return apiErr
+}
+
+// truncateBody returns at most limit bytes of body, marking truncation with an ellipsis.
+// The cut is moved back to a rune boundary so multi-byte characters aren't split.
+func truncateBody(body []byte, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+
+	if len(body) <= limit {
+		return string(body)
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+
+	return string(body[:cut]) + "…"
+}
+
+// decodeFieldErrors parses bodies like {"url": ["Enter a valid URL."]} or
+// {"detail": "Not found."}. It returns nil if the body isn't in that shape.
+func decodeFieldErrors(body []byte) map[string][]string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil
+	}
+
+	fieldErrors := make(map[string][]string, len(raw))
+
+	for field, value := range raw {
+		var messages []string
+		if err := json.Unmarshal(value, &messages); err == nil {
+			fieldErrors[field] = messages
+
+			continue
+		}
+
+		var message string
+		if err := json.Unmarshal(value, &message); err == nil {
+			fieldErrors[field] = []string{message}
+		}
+	}
+
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	return fieldErrors
+}
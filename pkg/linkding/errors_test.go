@@ -0,0 +1,149 @@
+package linkding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidationErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantFieldErrors map[string][]string
+		wantMessage     string
+		wantNotFound    bool
+	}{
+		{
+			name:            "field errors",
+			status:          http.StatusBadRequest,
+			body:            `{"url": ["Enter a valid URL."], "tag_names": ["Tag names must not contain spaces.", "Too many tags."]}`,
+			wantFieldErrors: map[string][]string{"url": {"Enter a valid URL."}, "tag_names": {"Tag names must not contain spaces.", "Too many tags."}},
+			wantMessage:     "API request failed with status 400: tag_names: Tag names must not contain spaces. Too many tags.; url: Enter a valid URL.",
+		},
+		{
+			name:            "detail message",
+			status:          http.StatusNotFound,
+			body:            `{"detail": "Not found."}`,
+			wantFieldErrors: map[string][]string{"detail": {"Not found."}},
+			wantMessage:     "API request failed with status 404: detail: Not found.",
+			wantNotFound:    true,
+		},
+		{
+			name:        "empty body",
+			status:      http.StatusBadRequest,
+			wantMessage: "API request failed with status 400",
+		},
+		{
+			name:        "plain text body on one line",
+			status:      http.StatusInternalServerError,
+			body:        "Internal Server Error\n\n  database is locked\n",
+			wantMessage: "API request failed with status 500: Internal Server Error database is locked",
+		},
+		{
+			name:        "long body truncated",
+			status:      http.StatusBadGateway,
+			body:        "<html><body>" + strings.Repeat("a", 300) + "</body></html>",
+			wantMessage: "API request failed with status 502: " + ("<html><body>" + strings.Repeat("a", 300))[:maxErrorMessageBody] + "…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			_, err := NewClient(srv.URL, "token", WithRetries(0)).CreateBookmark(context.Background(), CreateBookmarkRequest{URL: "not a url"})
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error %v is not an *APIError", err)
+			}
+
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+
+			if !reflect.DeepEqual(apiErr.FieldErrors, tt.wantFieldErrors) {
+				t.Errorf("field errors = %v, want %v", apiErr.FieldErrors, tt.wantFieldErrors)
+			}
+
+			if err.Error() != tt.wantMessage {
+				t.Errorf("message = %q, want %q", err.Error(), tt.wantMessage)
+			}
+
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("errors.Is(err, ErrNotFound) = %v, want %v", !tt.wantNotFound, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestDecodeFieldErrors(t *testing.T) {
+	tests := []struct {
+		body string
+		want map[string][]string
+	}{
+		{body: `{"url": ["Enter a valid URL."]}`, want: map[string][]string{"url": {"Enter a valid URL."}}},
+		{body: `{"detail": "Invalid token."}`, want: map[string][]string{"detail": {"Invalid token."}}},
+		{body: `{"count": 3}`},
+		{body: `["not", "an", "object"]`},
+		{body: `<html>Bad Gateway</html>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.SplitN(tt.body, " ", 2)[0], func(t *testing.T) {
+			if got := decodeFieldErrors([]byte(tt.body)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeFieldErrors(%s) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxResponseBodyLog(t *testing.T) {
+	long := strings.Repeat("x", 1000)
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		body     string
+		wantBody string
+	}{
+		{name: "default limit", body: long, wantBody: long[:defaultMaxResponseBodyLog] + "…"},
+		{name: "custom limit", opts: []Option{WithMaxResponseBodyLog(10)}, body: long, wantBody: long[:10] + "…"},
+		{name: "short body kept", opts: []Option{WithMaxResponseBodyLog(10)}, body: "bad", wantBody: "bad"},
+		{name: "disabled", opts: []Option{WithMaxResponseBodyLog(0)}, body: long},
+		{name: "cut at a rune boundary", opts: []Option{WithMaxResponseBodyLog(4)}, body: "日本語", wantBody: "日…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			opts := append([]Option{WithRetries(0)}, tt.opts...)
+			_, err := NewClient(srv.URL, "token", opts...).GetBookmark(context.Background(), 1)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error %v is not an *APIError", err)
+			}
+
+			if apiErr.Body != tt.wantBody {
+				t.Errorf("body = %q, want %q", apiErr.Body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package linkding
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// rssFeed is the subset of Linkding's RSS feed format needed to read bookmarks.
+type rssFeed struct {
+	Items []rssItem `xml:"channel>item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+// GetSharedBookmarks retrieves shared bookmarks from Linkding's public RSS feed.
+// The feed doesn't require an API token, but is only available when public
+// sharing is enabled in the Linkding settings.
+//
+// Feed entries carry less information than the API: only URL, Title,
+// Description, TagNames and DateAdded are populated on the returned bookmarks.
+func (c *Client) GetSharedBookmarks(ctx context.Context, query string) ([]Bookmark, error) {
+	endpoint := "/feeds/shared"
+
+	if query != "" {
+		params := url.Values{}
+		params.Set("q", query)
+		endpoint += "?" + params.Encode()
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+
+	var feed rssFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode feed: %w", err)
+	}
+
+	bookmarks := make([]Bookmark, len(feed.Items))
+
+	for i, item := range feed.Items {
+		dateAdded, _ := time.Parse(time.RFC1123Z, item.PubDate)
+
+		bookmarks[i] = Bookmark{
+			URL:         item.Link,
+			Title:       item.Title,
+			Description: item.Description,
+			TagNames:    item.Categories,
+			DateAdded:   dateAdded,
+			Shared:      true,
+		}
+	}
+
+	return bookmarks, nil
+}
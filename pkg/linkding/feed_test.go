@@ -0,0 +1,82 @@
+package linkding
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGetSharedBookmarks(t *testing.T) {
+	const feed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>Shared</title>
+<item><title>Go &amp; more</title><link>https://a.example</link><description>Tips</description>
+<category>go</category><category>日本語</category><pubDate>Mon, 04 Mar 2024 10:00:00 +0000</pubDate></item>
+<item><title>No date</title><link>https://b.example</link></item>
+</channel></rss>`
+
+	tests := []struct {
+		name      string
+		token     string
+		query     string
+		status    int
+		body      string
+		wantQuery string
+		wantURLs  []string
+		wantErr   bool
+	}{
+		{name: "without a token", status: http.StatusOK, body: feed, wantURLs: []string{"https://a.example", "https://b.example"}},
+		{name: "query encoded", query: "#go a&b", status: http.StatusOK, body: feed, wantQuery: "q=%23go+a%26b", wantURLs: []string{"https://a.example", "https://b.example"}},
+		{name: "token still sent when configured", token: "token", status: http.StatusOK, body: feed, wantURLs: []string{"https://a.example", "https://b.example"}},
+		{name: "sharing disabled", status: http.StatusNotFound, body: `<h1>Not Found</h1>`, wantErr: true},
+		{name: "not a feed", status: http.StatusOK, body: `{"detail": "oops"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, tt.status, tt.body)
+
+			bookmarks, err := NewClient(srv.URL, tt.token, WithRetries(0)).GetSharedBookmarks(context.Background(), tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSharedBookmarks() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			r := requests()[0]
+			if r.URL.Path != "/feeds/shared" || r.URL.RawQuery != tt.wantQuery {
+				t.Errorf("requested %s?%s, want /feeds/shared?%s", r.URL.Path, r.URL.RawQuery, tt.wantQuery)
+			}
+
+			if auth := r.Header.Get("Authorization"); (auth != "") != (tt.token != "") {
+				t.Errorf("Authorization = %q with token %q", auth, tt.token)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var urls []string
+			for _, bookmark := range bookmarks {
+				urls = append(urls, bookmark.URL)
+
+				if !bookmark.Shared {
+					t.Errorf("bookmark %s isn't marked shared", bookmark.URL)
+				}
+			}
+
+			if !slices.Equal(urls, tt.wantURLs) {
+				t.Fatalf("URLs = %v, want %v", urls, tt.wantURLs)
+			}
+
+			first := bookmarks[0]
+			if first.Title != "Go & more" || !slices.Equal(first.TagNames, []string{"go", "日本語"}) ||
+				!first.DateAdded.Equal(time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)) {
+				t.Errorf("first bookmark = %+v", first)
+			}
+
+			if !bookmarks[1].DateAdded.IsZero() {
+				t.Errorf("bookmark without pubDate has date %v", bookmarks[1].DateAdded)
+			}
+		})
+	}
+}
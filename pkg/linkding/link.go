@@ -0,0 +1,69 @@
+package linkding
+
+import (
+	"net/http"
+	"strings"
+)
+
+// parseLinkHeader parses an RFC 8288 (formerly RFC 5988) Link header into a
+// map of relation type to target URL, e.g. `<https://...?offset=100>; rel="next"`.
+// Malformed entries are skipped.
+func parseLinkHeader(headers []string) map[string]string {
+	links := map[string]string{}
+
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			target = strings.Trim(target, "<>")
+
+			for _, param := range strings.Split(params, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(key, "rel") {
+					continue
+				}
+
+				// A rel value may list several space-separated relation types
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					links[strings.ToLower(rel)] = target
+				}
+			}
+		}
+	}
+
+	return links
+}
+
+// applyLinkHeader replaces the body's next and previous page URLs with those
+// of the response's Link header, when Linkding sends one. Without the header
+// the body fields are left as they are.
+func applyLinkHeader(resp *http.Response, next, previous **string) {
+	headers := resp.Header.Values("Link")
+	if len(headers) == 0 {
+		return
+	}
+
+	links := parseLinkHeader(headers)
+	if len(links) == 0 {
+		return
+	}
+
+	*next = linkTarget(links, "next")
+	*previous = linkTarget(links, "prev")
+
+	if *previous == nil {
+		*previous = linkTarget(links, "previous")
+	}
+}
+
+func linkTarget(links map[string]string, rel string) *string {
+	target, ok := links[rel]
+	if !ok {
+		return nil
+	}
+
+	return &target
+}
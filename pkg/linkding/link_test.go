@@ -0,0 +1,178 @@
+package linkding
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    map[string]string
+	}{
+		{name: "no header", want: map[string]string{}},
+		{
+			name:    "next and prev",
+			headers: []string{`<https://l.example/api/bookmarks/?offset=200>; rel="next", <https://l.example/api/bookmarks/?offset=0>; rel="prev"`},
+			want:    map[string]string{"next": "https://l.example/api/bookmarks/?offset=200", "prev": "https://l.example/api/bookmarks/?offset=0"},
+		},
+		{
+			name:    "several header lines",
+			headers: []string{`<https://l.example/2>; rel="next"`, `<https://l.example/9>; rel=last`},
+			want:    map[string]string{"next": "https://l.example/2", "last": "https://l.example/9"},
+		},
+		{
+			name:    "several relation types and other params",
+			headers: []string{`<https://l.example/2>; title="Next page"; REL="next last"`},
+			want:    map[string]string{"next": "https://l.example/2", "last": "https://l.example/2"},
+		},
+		{
+			name:    "malformed entries skipped",
+			headers: []string{`https://l.example/1; rel="next", <https://l.example/2>, <https://l.example/3>; rel="prev"`},
+			want:    map[string]string{"prev": "https://l.example/3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLinkHeader(tt.headers); !maps.Equal(got, tt.want) {
+				t.Errorf("parseLinkHeader(%q) = %v, want %v", tt.headers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkHeaderPagination(t *testing.T) {
+	const total = 25
+
+	tests := []struct {
+		name string
+		// link returns the Link header for the page at offset, bodyNext
+		// whether the body has a next URL
+		link      func(host string, offset int) string
+		bodyNext  bool
+		wantCount int
+		wantPages int
+	}{
+		{name: "body only", bodyNext: true, wantCount: total, wantPages: 3},
+		{
+			name: "header only",
+			link: func(host string, offset int) string {
+				if offset+10 >= total {
+					return fmt.Sprintf(`<http://%s/api/bookmarks/?offset=%d>; rel="prev"`, host, offset-10)
+				}
+
+				return fmt.Sprintf(`<http://%s/api/bookmarks/?offset=%d>; rel="next"`, host, offset+10)
+			},
+			wantCount: total,
+			wantPages: 3,
+		},
+		{
+			name: "header without next overrides the body",
+			link: func(host string, _ int) string {
+				return fmt.Sprintf(`<http://%s/api/bookmarks/?offset=0>; rel="first"`, host)
+			},
+			bodyNext:  true,
+			wantCount: 10,
+			wantPages: 1,
+		},
+		{
+			name:      "malformed header falls back to the body",
+			link:      func(string, int) string { return "garbage" },
+			bodyNext:  true,
+			wantCount: total,
+			wantPages: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := 0
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages++
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+				if tt.link != nil {
+					w.Header().Set("Link", tt.link(r.Host, offset))
+				}
+
+				next := "null"
+				if tt.bodyNext && offset+10 < total {
+					next = fmt.Sprintf(`"http://%s/api/bookmarks/?offset=%d"`, r.Host, offset+10)
+				}
+
+				results := ""
+				for id := offset + 1; id <= min(offset+10, total); id++ {
+					if results != "" {
+						results += ","
+					}
+
+					results += fmt.Sprintf(`{"id": %d, "url": "https://example.com/%d"}`, id, id)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"count": %d, "next": %s, "previous": null, "results": [%s]}`, total, next, results)
+			}))
+			defer srv.Close()
+
+			bookmarks, err := NewClient(srv.URL, "token", WithPageSize(10)).GetAllBookmarks(context.Background(), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(bookmarks) != tt.wantCount || pages != tt.wantPages {
+				t.Errorf("got %d bookmarks in %d pages, want %d in %d", len(bookmarks), pages, tt.wantCount, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestLinkHeaderPrevious(t *testing.T) {
+	tests := []struct {
+		link         string
+		wantNext     string
+		wantPrevious string
+	}{
+		{link: `<https://l.example/3>; rel="next", <https://l.example/1>; rel="prev"`, wantNext: "https://l.example/3", wantPrevious: "https://l.example/1"},
+		{link: `<https://l.example/1>; rel="previous"`, wantPrevious: "https://l.example/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Link", tt.link)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"count": 0, "next": "https://body.example/next", "previous": "https://body.example/prev", "results": []}`))
+			}))
+			defer srv.Close()
+
+			response, err := NewClient(srv.URL, "token").GetTags(context.Background(), 10, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := deref(response.Next); got != tt.wantNext {
+				t.Errorf("next = %q, want %q", got, tt.wantNext)
+			}
+
+			if got := deref(response.Previous); got != tt.wantPrevious {
+				t.Errorf("previous = %q, want %q", got, tt.wantPrevious)
+			}
+		})
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,172 @@
+package linkding
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Option configures optional behavior of a Client.
+type Option func(*Client)
+
+// WithMaxResponseBodyLog sets how many bytes of an error response body are
+// captured in APIError.Body. Longer bodies are truncated with an ellipsis.
+// A value of zero or less disables body capture.
+func WithMaxResponseBodyLog(n int) Option {
+	return func(c *Client) {
+		c.maxResponseBodyLog = n
+	}
+}
+
+// WithRetries sets how many times transient failures (timeouts, 429, 502,
+// 503, 504) are retried, with exponential backoff and jitter or after the
+// delay requested by a Retry-After header. Defaults to 3; zero disables retries.
+func WithRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithHTTPClient makes the client send requests through httpClient, e.g. one
+// with a custom http.RoundTripper for a corporate proxy, mTLS or
+// instrumentation. The client is copied; if it has no CheckRedirect policy,
+// the copy refuses redirects leaving the Linkding host like the default one
+// does (see ErrUnexpectedRedirect). Its own Timeout, if any, applies on top of
+// the per-request timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient == nil {
+			return
+		}
+
+		client := *httpClient
+		if client.CheckRedirect == nil {
+			client.CheckRedirect = checkRedirect
+		}
+
+		c.httpClient = &client
+	}
+}
+
+// WithTimeout sets the timeout of every request, e.g. longer for instances
+// with slow metadata scraping on create. A deadline on the caller's context
+// still applies when it is earlier. Zero disables it. Defaults to 30 seconds.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		if d >= 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// WithPageSize sets how many items are requested per page when iterating
+// over all results, e.g. in GetAllBookmarks. Defaults to 100.
+func WithPageSize(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.pageSize = n
+		}
+	}
+}
+
+// WithMaxResults caps how many items GetAllBookmarks, GetAllArchivedBookmarks
+// and GetAllTags collect, avoiding runaway memory use on huge libraries. When
+// more items exist, the first n are returned together with an error matching
+// ErrTruncated. Zero or less means no cap, the default.
+func WithMaxResults(n int) Option {
+	return func(c *Client) {
+		c.maxResults = n
+	}
+}
+
+// WithBaseURLPath appends a path prefix to the base URL for Linkding mounted
+// under a subpath, e.g. WithBaseURLPath("/linkding") with base URL
+// "https://example.com" sends requests to "https://example.com/linkding/api/".
+// Leading and trailing slashes are optional.
+func WithBaseURLPath(path string) Option {
+	return func(c *Client) {
+		if path = strings.Trim(path, "/"); path != "" {
+			c.baseURL += "/" + path
+		}
+	}
+}
+
+// WithTokenProvider makes the client call provider before every request to
+// get the current API token, instead of using the token passed to NewClient.
+// This allows rotating the token without restarting, e.g. by reading it from
+// a file. The provider is called concurrently and should be cheap.
+func WithTokenProvider(provider func() string) Option {
+	return func(c *Client) {
+		c.tokenProvider = provider
+	}
+}
+
+// WithDefaultHeaders adds headers sent with every request, e.g. ones
+// required by a proxy in front of Linkding. They replace headers of the same
+// name, including the default "Accept: application/json", but not the
+// Authorization and Content-Type headers set by the client.
+func WithDefaultHeaders(headers http.Header) Option {
+	return func(c *Client) {
+		for name, values := range headers {
+			c.headers[http.CanonicalHeaderKey(name)] = slices.Clone(values)
+		}
+	}
+}
+
+// WithAcceptLanguage sets the Accept-Language header, e.g. "de-DE,de;q=0.9",
+// on the requests that make Linkding scrape page metadata (CreateBookmark,
+// SaveBookmark and CheckBookmark), so the scraped title and description can
+// match the user's language. Whether it has an effect depends on the Linkding
+// version passing the locale on to the scraper.
+func WithAcceptLanguage(language string) Option {
+	return func(c *Client) {
+		c.acceptLanguage = language
+	}
+}
+
+// WithJSONDecoderOptions configures how responses are decoded, e.g.
+// JSONDecoderOptions{DisallowUnknownFields: true} for strict decoding that
+// fails when Linkding returns fields the client doesn't model. Decoding is
+// lenient by default.
+func WithJSONDecoderOptions(opts JSONDecoderOptions) Option {
+	return func(c *Client) {
+		c.decoderOptions = opts
+	}
+}
+
+// ListOption sets optional query parameters on bookmark list requests.
+type ListOption func(params url.Values)
+
+// WithUnread filters bookmarks by their unread state on the server.
+func WithUnread(unread bool) ListOption {
+	return func(params url.Values) {
+		params.Set("unread", yesNo(unread))
+	}
+}
+
+// WithShared filters bookmarks by their shared state on the server.
+func WithShared(shared bool) ListOption {
+	return func(params url.Values) {
+		params.Set("shared", yesNo(shared))
+	}
+}
+
+// WithSort asks the server to sort bookmarks in the given order. Linkding
+// versions that ignore the parameter return bookmarks newest first; the
+// client then sorts the results itself, see SortOrder.
+func WithSort(order SortOrder) ListOption {
+	return func(params url.Values) {
+		params.Set("sort", string(order))
+	}
+}
+
+// yesNo encodes a boolean filter the way Linkding expects it
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
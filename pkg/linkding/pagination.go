@@ -0,0 +1,136 @@
+package linkding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrTruncated is returned with the collected results when paging stopped at
+// the WithMaxResults cap while more items were available.
+var ErrTruncated = errors.New("results truncated")
+
+// defaultPageSize is the number of items requested per page when iterating over all results.
+const defaultPageSize = 100
+
+// GetAllBookmarks retrieves every bookmark matching the query by following
+// pagination until all pages have been fetched.
+// Archived bookmarks are not included, matching the behavior of GetBookmarks.
+//
+// If a page fails (e.g. malformed JSON or cancellation), the bookmarks
+// collected so far are returned together with an error reporting the offset of the failed page.
+//
+// Some Linkding versions cap the page size below the requested limit. When a
+// page comes back smaller than requested while more pages remain, the smaller
+// size is used for the following requests.
+//
+// With WithMaxResults, paging stops once that many bookmarks were collected;
+// if more exist, they are returned with an error matching ErrTruncated.
+func (c *Client) GetAllBookmarks(ctx context.Context, query string, opts ...ListOption) ([]Bookmark, error) {
+	return c.getAllBookmarks(ctx, c.GetBookmarks, query, opts)
+}
+
+// GetAllArchivedBookmarks retrieves every archived bookmark matching the query.
+// It pages the same way as GetAllBookmarks.
+func (c *Client) GetAllArchivedBookmarks(ctx context.Context, query string, opts ...ListOption) ([]Bookmark, error) {
+	return c.getAllBookmarks(ctx, c.GetArchivedBookmarks, query, opts)
+}
+
+type bookmarkLister func(ctx context.Context, limit, offset int, query string, opts ...ListOption) (*BookmarkResponse, error)
+
+func (c *Client) getAllBookmarks(ctx context.Context, list bookmarkLister, query string, opts []ListOption) ([]Bookmark, error) {
+	bookmarks, err := fetchAll(ctx, c.pageSize, c.maxResults, func(limit, offset int) (int, []Bookmark, *string, error) {
+		page, err := list(ctx, limit, offset, query, opts...)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+
+		return page.Count, page.Results, page.Next, nil
+	})
+	if err != nil && !errors.Is(err, ErrTruncated) {
+		return bookmarks, err
+	}
+
+	ensureSorted(bookmarks, listParams(opts))
+
+	return bookmarks, err
+}
+
+// listParams returns the query parameters set by list options
+func listParams(opts []ListOption) url.Values {
+	params := url.Values{}
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	return params
+}
+
+// GetAllTags retrieves every tag by following pagination until all pages have been fetched.
+// It pages the same way as GetAllBookmarks, including the WithMaxResults cap.
+func (c *Client) GetAllTags(ctx context.Context) ([]Tag, error) {
+	return fetchAll(ctx, c.pageSize, c.maxResults, func(limit, offset int) (int, []Tag, *string, error) {
+		page, err := c.GetTags(ctx, limit, offset)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+
+		return page.Count, page.Results, page.Next, nil
+	})
+}
+
+// fetchAll collects the items of every page returned by fetch, which
+// requests limit items starting at offset and returns the total count, the
+// items and the URL of the next page. Paging stops when there is no next
+// page or a page comes back empty.
+//
+// If a page fails (e.g. malformed JSON or cancellation), the items collected
+// so far are returned together with an error reporting the offset of the failed page.
+//
+// Some Linkding versions cap the page size below the requested limit. When a
+// page comes back smaller than requested while more pages remain, the smaller
+// size is used for the following requests.
+//
+// A positive maxItems stops paging once that many items were collected. If
+// there were more, the first maxItems are returned with an ErrTruncated error.
+func fetchAll[T any](ctx context.Context, pageSize, maxItems int, fetch func(limit, offset int) (count int, items []T, next *string, err error)) ([]T, error) {
+	var all []T
+
+	offset := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return all, fmt.Errorf("failed to fetch page at offset %d: %w", offset, err)
+		}
+
+		count, items, next, err := fetch(pageSize, offset)
+		if err != nil {
+			return all, fmt.Errorf("failed to fetch page at offset %d: %w", offset, err)
+		}
+
+		if all == nil && count > 0 {
+			capacity := count
+			if maxItems > 0 {
+				capacity = min(count, maxItems)
+			}
+
+			all = make([]T, 0, capacity)
+		}
+
+		all = append(all, items...)
+		offset += len(items)
+
+		if maxItems > 0 && len(all) >= maxItems && (len(all) > maxItems || next != nil) {
+			return all[:maxItems], fmt.Errorf("%w: stopped after %d of %d items", ErrTruncated, maxItems, max(count, len(all)))
+		}
+
+		if next == nil || len(items) == 0 {
+			return all, nil
+		}
+
+		if len(items) < pageSize {
+			pageSize = len(items)
+		}
+	}
+}
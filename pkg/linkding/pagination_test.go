@@ -0,0 +1,365 @@
+package linkding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// pagedBookmarks serves total bookmarks from /api/bookmarks/ with limit and
+// offset pagination. Pages are capped at maxPage items when it is positive,
+// and page, when set, is called with the requested limit and offset of every
+// request and can replace the body of that page by returning non-empty.
+func pagedBookmarks(t *testing.T, total, maxPage int, page func(limit, offset int) string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if page != nil {
+			if body := page(limit, offset); body != "" {
+				_, _ = w.Write([]byte(body))
+
+				return
+			}
+		}
+
+		if maxPage > 0 && limit > maxPage {
+			limit = maxPage
+		}
+
+		response := BookmarkResponse{Count: total, Results: []Bookmark{}}
+		for id := offset + 1; id <= min(offset+limit, total); id++ {
+			response.Results = append(response.Results, Bookmark{ID: id, URL: fmt.Sprintf("https://example.com/%d", id)})
+		}
+
+		if offset+limit < total {
+			next := fmt.Sprintf("http://%s%s?limit=%d&offset=%d", r.Host, r.URL.Path, limit, offset+limit)
+			response.Next = &next
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestGetAllBookmarksPartialFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       func(limit, offset int) string
+		wantCount  int
+		wantErrMsg string
+	}{
+		{name: "all pages", wantCount: 25},
+		{
+			name: "invalid JSON on the third page",
+			page: func(_, offset int) string {
+				if offset == 20 {
+					return `{"count": 25, "results": [{"id": 21,`
+				}
+
+				return ""
+			},
+			wantCount:  20,
+			wantErrMsg: "failed to fetch page at offset 20",
+		},
+		{
+			name: "invalid JSON on the first page",
+			page: func(_, _ int) string {
+				return "not json"
+			},
+			wantErrMsg: "failed to fetch page at offset 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := pagedBookmarks(t, 25, 0, tt.page)
+			client := NewClient(srv.URL, "token", WithPageSize(10))
+
+			bookmarks, err := client.GetAllBookmarks(context.Background(), "")
+
+			if len(bookmarks) != tt.wantCount {
+				t.Errorf("got %d bookmarks, want %d", len(bookmarks), tt.wantCount)
+			}
+
+			for i, bookmark := range bookmarks {
+				if bookmark.ID != i+1 {
+					t.Fatalf("bookmark %d has ID %d, want the pages in order", i, bookmark.ID)
+				}
+			}
+
+			if tt.wantErrMsg == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+				t.Errorf("error = %v, want %q", err, tt.wantErrMsg)
+			}
+
+			if errors.Is(err, ErrTruncated) {
+				t.Errorf("a failed page must not look like truncation: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetAllBookmarksCappedPageSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		pageSize   int
+		maxPage    int
+		wantLimits []int
+	}{
+		{name: "not capped", pageSize: 40, wantLimits: []int{40, 40, 40}},
+		{name: "capped below the page size", pageSize: 40, maxPage: 30, wantLimits: []int{40, 30, 30, 30}},
+		{name: "capped at the page size", pageSize: 30, maxPage: 30, wantLimits: []int{30, 30, 30, 30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				limits []int
+			)
+
+			srv := pagedBookmarks(t, 100, tt.maxPage, func(limit, _ int) string {
+				mu.Lock()
+				defer mu.Unlock()
+
+				limits = append(limits, limit)
+
+				return ""
+			})
+
+			bookmarks, err := NewClient(srv.URL, "token", WithPageSize(tt.pageSize)).GetAllBookmarks(context.Background(), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(bookmarks) != 100 || bookmarks[99].ID != 100 {
+				t.Errorf("got %d bookmarks, want all 100 in order", len(bookmarks))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !slices.Equal(limits, tt.wantLimits) {
+				t.Errorf("requested limits %v, want %v", limits, tt.wantLimits)
+			}
+		})
+	}
+}
+
+// pagesOf returns a fetch function for fetchAll serving items page by page
+func pagesOf[T any](items []T, calls *int) func(limit, offset int) (int, []T, *string, error) {
+	return func(limit, offset int) (int, []T, *string, error) {
+		*calls++
+
+		if offset >= len(items) {
+			return len(items), nil, nil, nil
+		}
+
+		end := min(offset+limit, len(items))
+
+		var next *string
+		if end < len(items) {
+			url := fmt.Sprintf("?limit=%d&offset=%d", limit, end)
+			next = &url
+		}
+
+		return len(items), items[offset:end], next, nil
+	}
+}
+
+// testFetchAll runs the fetchAll cases with items of type T built by item
+func testFetchAll[T any](t *testing.T, item func(i int) T) {
+	tests := []struct {
+		name          string
+		total         int
+		pageSize      int
+		maxItems      int
+		wantCount     int
+		wantCalls     int
+		wantTruncated bool
+	}{
+		{name: "single page", total: 5, pageSize: 10, wantCount: 5, wantCalls: 1},
+		{name: "several pages", total: 25, pageSize: 10, wantCount: 25, wantCalls: 3},
+		{name: "exact pages", total: 20, pageSize: 10, wantCount: 20, wantCalls: 2},
+		{name: "empty", pageSize: 10, wantCalls: 1},
+		{name: "capped", total: 25, pageSize: 10, maxItems: 15, wantCount: 15, wantCalls: 2, wantTruncated: true},
+		{name: "cap not reached", total: 15, pageSize: 10, maxItems: 15, wantCount: 15, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]T, tt.total)
+			for i := range items {
+				items[i] = item(i)
+			}
+
+			calls := 0
+
+			got, err := fetchAll(context.Background(), tt.pageSize, tt.maxItems, pagesOf(items, &calls))
+			if errors.Is(err, ErrTruncated) != tt.wantTruncated || (err != nil && !tt.wantTruncated) {
+				t.Fatalf("error = %v, want truncated %v", err, tt.wantTruncated)
+			}
+
+			if len(got) != tt.wantCount || calls != tt.wantCalls {
+				t.Errorf("got %d items in %d calls, want %d in %d", len(got), calls, tt.wantCount, tt.wantCalls)
+			}
+
+			if !slices.EqualFunc(got, items[:len(got)], func(a, b T) bool { return fmt.Sprint(a) == fmt.Sprint(b) }) {
+				t.Error("items aren't in page order")
+			}
+		})
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	t.Run("bookmarks", func(t *testing.T) {
+		testFetchAll(t, func(i int) Bookmark { return Bookmark{ID: i + 1} })
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		testFetchAll(t, func(i int) Tag { return Tag{ID: i + 1, Name: fmt.Sprintf("tag%d", i+1)} })
+	})
+}
+
+func TestFetchAllFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	fetch := pagesOf([]Tag{{ID: 1}, {ID: 2}, {ID: 3}}, &calls)
+
+	tags, err := fetchAll(ctx, 1, 0, func(limit, offset int) (int, []Tag, *string, error) {
+		if offset == 1 {
+			cancel()
+		}
+
+		return fetch(limit, offset)
+	})
+	if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), "offset 2") {
+		t.Errorf("error = %v, want cancellation at offset 2", err)
+	}
+
+	if len(tags) != 2 || calls != 2 {
+		t.Errorf("got %d tags in %d calls, want the 2 collected before the cancellation", len(tags), calls)
+	}
+}
+
+func TestGetAllTags(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		wantCount     int
+		wantRequests  int
+		wantTruncated bool
+	}{
+		{name: "every page", opts: []Option{WithPageSize(10)}, wantCount: 25, wantRequests: 3},
+		{name: "single page", wantCount: 25, wantRequests: 1},
+		{name: "capped", opts: []Option{WithPageSize(10), WithMaxResults(15)}, wantCount: 15, wantRequests: 2, wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+
+				limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+				response := TagResponse{Count: 25, Results: []Tag{}}
+				for id := offset + 1; id <= min(offset+limit, 25); id++ {
+					response.Results = append(response.Results, Tag{ID: id, Name: fmt.Sprintf("tag%d", id)})
+				}
+
+				if offset+limit < 25 {
+					next := fmt.Sprintf("http://%s%s?limit=%d&offset=%d", r.Host, r.URL.Path, limit, offset+limit)
+					response.Next = &next
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(response)
+			}))
+			t.Cleanup(srv.Close)
+
+			tags, err := NewClient(srv.URL, "token", tt.opts...).GetAllTags(context.Background())
+			if errors.Is(err, ErrTruncated) != tt.wantTruncated || (err != nil && !tt.wantTruncated) {
+				t.Fatalf("error = %v, want truncated %v", err, tt.wantTruncated)
+			}
+
+			if len(tags) != tt.wantCount || tags[len(tags)-1].ID != tt.wantCount {
+				t.Errorf("got %d tags, want the first %d in order", len(tags), tt.wantCount)
+			}
+
+			if got := requests.Load(); int(got) != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestGetAllBookmarksMaxResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int
+		maxResults    int
+		cancelled     bool
+		wantCount     int
+		wantTruncated bool
+		wantCancelled bool
+	}{
+		{name: "every page without a cap", total: 25, wantCount: 25},
+		{name: "cap within a page", total: 25, maxResults: 15, wantCount: 15, wantTruncated: true},
+		{name: "cap at a page boundary", total: 25, maxResults: 20, wantCount: 20, wantTruncated: true},
+		{name: "cap equal to the total", total: 25, maxResults: 25, wantCount: 25},
+		{name: "cap above the total", total: 25, maxResults: 100, wantCount: 25},
+		{name: "cancelled context", total: 25, maxResults: 15, cancelled: true, wantCancelled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := pagedBookmarks(t, tt.total, 0, nil)
+			client := NewClient(srv.URL, "token", WithPageSize(10), WithMaxResults(tt.maxResults))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancelled {
+				cancel()
+			}
+			defer cancel()
+
+			for _, list := range []func(context.Context, string, ...ListOption) ([]Bookmark, error){client.GetAllBookmarks, client.GetAllArchivedBookmarks} {
+				bookmarks, err := list(ctx, "")
+				if errors.Is(err, ErrTruncated) != tt.wantTruncated || errors.Is(err, context.Canceled) != tt.wantCancelled ||
+					(err != nil && !tt.wantTruncated && !tt.wantCancelled) {
+					t.Fatalf("error = %v, want truncated %v, cancelled %v", err, tt.wantTruncated, tt.wantCancelled)
+				}
+
+				if len(bookmarks) != tt.wantCount || (tt.wantCount > 0 && bookmarks[len(bookmarks)-1].ID != tt.wantCount) {
+					t.Errorf("got %d bookmarks, want the first %d", len(bookmarks), tt.wantCount)
+				}
+			}
+		})
+	}
+}
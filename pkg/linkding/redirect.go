@@ -0,0 +1,30 @@
+package linkding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxRedirects matches the default redirect limit of net/http
+const maxRedirects = 10
+
+// ErrUnexpectedRedirect is returned when the server redirects an API request
+// to another host, typically an authenticating reverse proxy sending the
+// client to its login page. Go drops the Authorization header on cross-host
+// redirects, so following them would only produce confusing failures.
+var ErrUnexpectedRedirect = errors.New("unexpected redirect")
+
+// checkRedirect follows redirects within the Linkding host and refuses the
+// ones leaving it
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	if req.URL.Host != via[0].URL.Host {
+		return fmt.Errorf("%w to %s — check proxy/auth config", ErrUnexpectedRedirect, req.URL.Host)
+	}
+
+	return nil
+}
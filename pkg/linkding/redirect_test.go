@@ -0,0 +1,94 @@
+package linkding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRedirects(t *testing.T) {
+	// login stands in for the login page of an authenticating proxy, on another host
+	var loginRequests atomic.Int32
+
+	login := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loginRequests.Add(1)
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html>Please log in</html>"))
+	}))
+	t.Cleanup(login.Close)
+
+	loginURL, _ := url.Parse(login.URL)
+
+	tests := []struct {
+		name string
+		// location is where /api/bookmarks/ redirects to
+		location        string
+		httpClient      *http.Client
+		wantErr         bool
+		wantRedirectErr bool
+		wantAuthSent    bool
+	}{
+		{name: "same host followed", location: "/moved/api/bookmarks/", wantAuthSent: true},
+		{name: "other host refused", location: login.URL + "/login", wantErr: true, wantRedirectErr: true},
+		{
+			name:            "other host refused with a custom HTTP client",
+			location:        login.URL + "/login",
+			httpClient:      &http.Client{},
+			wantErr:         true,
+			wantRedirectErr: true,
+		},
+		{name: "redirect loop stopped", location: "/api/bookmarks/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loginRequests.Store(0)
+
+			var authSent atomic.Bool
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/bookmarks/" {
+					http.Redirect(w, r, tt.location, http.StatusFound)
+
+					return
+				}
+
+				authSent.Store(r.Header.Get("Authorization") == "Token token")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"count": 0, "results": []}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			opts := []Option{WithRetries(0)}
+			if tt.httpClient != nil {
+				opts = append(opts, WithHTTPClient(tt.httpClient))
+			}
+
+			_, err := NewClient(srv.URL, "token", opts...).GetBookmarks(context.Background(), 0, 0, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if authSent.Load() != tt.wantAuthSent {
+				t.Errorf("authorization sent after the redirect = %v, want %v", authSent.Load(), tt.wantAuthSent)
+			}
+
+			if loginRequests.Load() != 0 {
+				t.Error("followed the redirect to another host")
+			}
+
+			if tt.wantRedirectErr && (!errors.Is(err, ErrUnexpectedRedirect) || !strings.Contains(err.Error(), loginURL.Host+" — check proxy/auth config")) {
+				t.Errorf("error = %v, want ErrUnexpectedRedirect naming %s", err, loginURL.Host)
+			}
+
+			if !tt.wantRedirectErr && errors.Is(err, ErrUnexpectedRedirect) {
+				t.Errorf("error = %v, want no ErrUnexpectedRedirect", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package linkding
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultMaxRetries is the number of retries per request unless changed with WithRetries.
+	defaultMaxRetries = 3
+	// retryBaseDelay is the delay before the first retry; it doubles on every further attempt.
+	retryBaseDelay = 500 * time.Millisecond
+	// maxRetryDelay caps the backoff and the delay a Retry-After header may ask for.
+	maxRetryDelay = 30 * time.Second
+)
+
+// isRetryableStatus reports whether a response status indicates a transient failure
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableError reports whether an error returned by a client method is transient.
+// Transport failures (timeouts, resets) and gateway errors are retryable,
+// other API errors, redirects away from Linkding and cancellation of the
+// caller's context are not.
+func isRetryableError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ErrUnexpectedRedirect) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.StatusCode)
+	}
+
+	return true
+}
+
+// retryBudget caps the total number of retries across all requests sharing a context
+type retryBudget struct {
+	remaining atomic.Int64
+}
+
+type retryBudgetKey struct{}
+
+// ContextWithRetryBudget returns a context under which all client requests
+// together retry at most n times. Operations issuing many requests (e.g. a
+// bulk update) use it so that transient failures across many items don't
+// multiply into a retry storm. Requests still stop at the client's own
+// per-request retry limit.
+func ContextWithRetryBudget(ctx context.Context, n int) context.Context {
+	budget := &retryBudget{}
+	budget.remaining.Store(int64(max(n, 0)))
+
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// takeRetry consumes one retry from the context's budget, reporting false
+// once the budget is exhausted. Without a budget retries are always allowed.
+func takeRetry(ctx context.Context) bool {
+	budget, ok := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	if !ok {
+		return true
+	}
+
+	return budget.remaining.Add(-1) >= 0
+}
+
+// retryDelay returns how long to wait before retrying after the given
+// attempt: the delay the server asked for with Retry-After, if any, or else
+// exponential backoff with jitter, so clients failing together don't retry
+// in lockstep. Both are capped at maxRetryDelay.
+func retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, maxRetryDelay)
+	}
+
+	backoff := min(retryBaseDelay<<min(attempt, 16), maxRetryDelay)
+
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as
+// an HTTP date. It returns zero if the header is missing or invalid.
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+
+	return 0
+}
+
+// retryFits reports whether a retry after delay can still finish before the
+// deadline of ctx. Waiting for a retry bound to miss it would only hide the
+// original error behind a deadline error.
+func retryFits(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+
+	return !ok || time.Until(deadline) > delay
+}
+
+// waitRetry blocks for delay, returning early if ctx is done
+func waitRetry(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// discardResponse drains and closes a response that is about to be retried,
+// so the underlying connection can be reused
+func discardResponse(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,220 @@
+package linkding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with status, sending
+// retryAfter as the Retry-After header when set, and answers the rest with a
+// bookmark. It returns the server and the number of requests received.
+func flakyServer(t *testing.T, status, failures int, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if int(requests.Add(1)) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+
+			w.WriteHeader(status)
+
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = w.Write([]byte(`{"id": 1, "url": "https://example.com"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestRetryStatuses(t *testing.T) {
+	tests := []struct {
+		status       int
+		failures     int
+		wantRequests int
+		wantErr      bool
+	}{
+		{status: http.StatusTooManyRequests, failures: 1, wantRequests: 2},
+		{status: http.StatusBadGateway, failures: 1, wantRequests: 2},
+		{status: http.StatusServiceUnavailable, failures: 1, wantRequests: 2},
+		{status: http.StatusGatewayTimeout, failures: 1, wantRequests: 2},
+		// Retries stop at the limit, and the last response is returned
+		{status: http.StatusServiceUnavailable, failures: 5, wantRequests: 2, wantErr: true},
+		{status: http.StatusInternalServerError, failures: 1, wantRequests: 1, wantErr: true},
+		{status: http.StatusNotFound, failures: 1, wantRequests: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d with %d failures", tt.status, tt.failures), func(t *testing.T) {
+			srv, requests := flakyServer(t, tt.status, tt.failures, "")
+
+			_, err := NewClient(srv.URL, "token", WithRetries(1)).GetBookmark(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+
+			var apiErr *APIError
+			if tt.wantErr && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.status) {
+				t.Errorf("error = %v, want an APIError with status %d", err, tt.status)
+			}
+
+			if got := int(requests.Load()); got != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want int
+	}{
+		{name: "three retries by default", want: defaultMaxRetries},
+		{name: "configured", opts: []Option{WithRetries(1)}, want: 1},
+		{name: "disabled", opts: []Option{WithRetries(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewClient("https://linkding.example", "token", tt.opts...).maxRetries; got != tt.want {
+				t.Errorf("maxRetries = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		retryAfter  string
+		ctxTimeout  time.Duration
+		minElapsed  time.Duration
+		maxElapsed  time.Duration
+		wantRetried bool
+	}{
+		{name: "429 waits the requested seconds", status: http.StatusTooManyRequests, retryAfter: "1", minElapsed: time.Second, maxElapsed: 2 * time.Second, wantRetried: true},
+		{name: "503 waits the requested seconds", status: http.StatusServiceUnavailable, retryAfter: "1", minElapsed: time.Second, maxElapsed: 2 * time.Second, wantRetried: true},
+		{name: "zero retries with backoff", status: http.StatusTooManyRequests, retryAfter: "0", minElapsed: retryBaseDelay / 2, maxElapsed: 2 * retryBaseDelay, wantRetried: true},
+		{name: "delay past the deadline gives up at once", status: http.StatusTooManyRequests, retryAfter: "10", ctxTimeout: 2 * time.Second, maxElapsed: 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := flakyServer(t, tt.status, 1, tt.retryAfter)
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			_, err := NewClient(srv.URL, "token", WithRetries(1)).GetBookmark(ctx, 1)
+			elapsed := time.Since(start)
+
+			if (err == nil) != tt.wantRetried || (requests.Load() == 2) != tt.wantRetried {
+				t.Fatalf("error = %v after %d requests, want retried %v", err, requests.Load(), tt.wantRetried)
+			}
+
+			var apiErr *APIError
+			if !tt.wantRetried && (!errors.As(err, &apiErr) || apiErr.RetryAfter != 10*time.Second) {
+				t.Errorf("error = %v, want an APIError carrying the Retry-After delay", err)
+			}
+
+			if elapsed < tt.minElapsed || elapsed > tt.maxElapsed {
+				t.Errorf("took %v, want between %v and %v", elapsed, tt.minElapsed, tt.maxElapsed)
+			}
+		})
+	}
+}
+
+func TestRetryCancellation(t *testing.T) {
+	srv, requests := flakyServer(t, http.StatusTooManyRequests, 5, "20")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := NewClient(srv.URL, "token", WithRetries(3)).GetBookmark(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want cancellation", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v, want the wait aborted on cancellation", elapsed)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "5", want: 5 * time.Second},
+		{value: " 120 ", want: 2 * time.Minute},
+		{value: "-3", want: 0},
+		{value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseRetryAfter(http.Header{"Retry-After": {tt.value}}, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter time.Duration
+		min, max   time.Duration
+	}{
+		{name: "first backoff", attempt: 0, min: retryBaseDelay / 2, max: retryBaseDelay},
+		{name: "doubled backoff", attempt: 2, min: 2 * retryBaseDelay, max: 4 * retryBaseDelay},
+		{name: "backoff capped", attempt: 30, min: maxRetryDelay / 2, max: maxRetryDelay},
+		{name: "Retry-After used as is", attempt: 0, retryAfter: 7 * time.Second, min: 7 * time.Second, max: 7 * time.Second},
+		{name: "Retry-After capped", attempt: 0, retryAfter: time.Hour, min: maxRetryDelay, max: maxRetryDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				if got := retryDelay(tt.attempt, tt.retryAfter); got < tt.min || got > tt.max {
+					t.Fatalf("retryDelay(%d, %v) = %v, want between %v and %v", tt.attempt, tt.retryAfter, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package linkding
+
+import (
+	"cmp"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SortOrder is a bookmark sort order supported by WithSort.
+//
+// Whether the server honors the order is detected from the results: when a
+// page isn't in the requested order, the client sorts it. That fallback only
+// orders the bookmarks of each page, which GetBookmarks reports with
+// BookmarkResponse.SortedLocally, except in GetAllBookmarks, which sorts the
+// complete result.
+type SortOrder string
+
+const (
+	SortAddedAsc     SortOrder = "added_asc"
+	SortAddedDesc    SortOrder = "added_desc"
+	SortTitleAsc     SortOrder = "title_asc"
+	SortTitleDesc    SortOrder = "title_desc"
+	SortModifiedAsc  SortOrder = "modified_asc"
+	SortModifiedDesc SortOrder = "modified_desc"
+)
+
+// SortOrders lists every supported sort order
+var SortOrders = []SortOrder{SortAddedAsc, SortAddedDesc, SortTitleAsc, SortTitleDesc, SortModifiedAsc, SortModifiedDesc}
+
+// compareFunc returns the comparison implementing a sort order, or nil for unknown orders
+func (o SortOrder) compareFunc() func(a, b Bookmark) int {
+	switch o {
+	case SortAddedAsc:
+		return func(a, b Bookmark) int { return a.DateAdded.Compare(b.DateAdded) }
+	case SortAddedDesc:
+		return func(a, b Bookmark) int { return b.DateAdded.Compare(a.DateAdded) }
+	case SortTitleAsc:
+		return func(a, b Bookmark) int { return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)) }
+	case SortTitleDesc:
+		return func(a, b Bookmark) int { return cmp.Compare(strings.ToLower(b.Title), strings.ToLower(a.Title)) }
+	case SortModifiedAsc:
+		return func(a, b Bookmark) int { return a.DateModified.Compare(b.DateModified) }
+	case SortModifiedDesc:
+		return func(a, b Bookmark) int { return b.DateModified.Compare(a.DateModified) }
+	default:
+		return nil
+	}
+}
+
+// ensureSorted sorts bookmarks in the order requested by params, unless the
+// server already returned them in that order. It reports whether it sorted them.
+func ensureSorted(bookmarks []Bookmark, params url.Values) bool {
+	compare := SortOrder(params.Get("sort")).compareFunc()
+	if compare == nil || slices.IsSortedFunc(bookmarks, compare) {
+		return false
+	}
+
+	slices.SortStableFunc(bookmarks, compare)
+
+	return true
+}
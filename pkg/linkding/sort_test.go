@@ -0,0 +1,70 @@
+package linkding
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestGetBookmarksSort(t *testing.T) {
+	tests := []struct {
+		name string
+		// body is what the server answers, regardless of the sort parameter
+		body          string
+		order         SortOrder
+		wantTitles    []string
+		wantLocalSort bool
+	}{
+		{
+			name:       "honored by the server",
+			body:       `{"count": 3, "results": [{"title": "a"}, {"title": "B"}, {"title": "c"}]}`,
+			order:      SortTitleAsc,
+			wantTitles: []string{"a", "B", "c"},
+		},
+		{
+			name:          "ignored by the server",
+			body:          `{"count": 3, "results": [{"title": "B"}, {"title": "c"}, {"title": "a"}]}`,
+			order:         SortTitleAsc,
+			wantTitles:    []string{"a", "B", "c"},
+			wantLocalSort: true,
+		},
+		{
+			name:          "modified date, newest first",
+			body:          `{"count": 2, "results": [{"title": "old", "date_modified": "2024-01-01T00:00:00Z"}, {"title": "new", "date_modified": "2024-02-01T00:00:00Z"}]}`,
+			order:         SortModifiedDesc,
+			wantTitles:    []string{"new", "old"},
+			wantLocalSort: true,
+		},
+		{
+			name:       "no sort requested",
+			body:       `{"count": 2, "results": [{"title": "c"}, {"title": "a"}]}`,
+			wantTitles: []string{"c", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := recordingServer(t, http.StatusOK, tt.body)
+
+			var opts []ListOption
+			if tt.order != "" {
+				opts = append(opts, WithSort(tt.order))
+			}
+
+			page, err := NewClient(srv.URL, "token").GetBookmarks(context.Background(), 0, 0, "", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var titles []string
+			for _, bookmark := range page.Results {
+				titles = append(titles, bookmark.Title)
+			}
+
+			if !slices.Equal(titles, tt.wantTitles) || page.SortedLocally != tt.wantLocalSort {
+				t.Errorf("got %v (sorted locally %v), want %v (sorted locally %v)", titles, page.SortedLocally, tt.wantTitles, tt.wantLocalSort)
+			}
+		})
+	}
+}